@@ -169,6 +169,10 @@ loop:
 		}
 		fmt.Fprintf(w, "}\n")
 		fmt.Fprintf(w, "}\n")
+
+		if e.Name == "TextureFormat" {
+			writeTextureFormatInfo(w, e.Enums)
+		}
 	}
 
 	out := mustv(os.Create(outputFile))
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TextureFormatInfo is the metadata of a texture format, derived from its
+// name.
+type TextureFormatInfo struct {
+	BlockWidth      uint32
+	BlockHeight     uint32
+	BlockSize       uint32
+	Components      uint32
+	Color           bool
+	Depth           bool
+	Stencil         bool
+	SampleType      string
+	Srgb            bool
+	Compressed      bool
+	SrgbFormat      string
+	LinearFormat    string
+	RequiredFeature string
+}
+
+var (
+	uncompressedFormatRegexp = regexp.MustCompile(`^(R|RG|RGBA|BGRA)(8|16|32)(Unorm|Snorm|Uint|Sint|Float)(Srgb)?$`)
+	astcFormatRegexp         = regexp.MustCompile(`^ASTC(\d+)x(\d+)Unorm(Srgb)?$`)
+)
+
+// depthStencilFormats are the depth and stencil formats, their block size
+// is zero if they can't be copied.
+var depthStencilFormats = map[string]TextureFormatInfo{
+	"Stencil8":             {BlockSize: 1, Components: 1, Stencil: true, SampleType: "Uint"},
+	"Depth16Unorm":         {BlockSize: 2, Components: 1, Depth: true, SampleType: "Depth"},
+	"Depth24Plus":          {Components: 1, Depth: true, SampleType: "Depth"},
+	"Depth24PlusStencil8":  {Components: 2, Depth: true, Stencil: true, SampleType: "Depth"},
+	"Depth32Float":         {BlockSize: 4, Components: 1, Depth: true, SampleType: "Depth"},
+	"Depth32FloatStencil8": {Components: 2, Depth: true, Stencil: true, SampleType: "Depth", RequiredFeature: "Depth32FloatStencil8"},
+}
+
+// compressedFormats are the block size and components of the BC, ETC2 and
+// EAC formats, by name without their Unorm, Snorm, Float or Srgb suffix.
+var compressedFormats = map[string]TextureFormatInfo{
+	"BC1RGBA":    {BlockSize: 8, Components: 4, RequiredFeature: "TextureCompressionBC"},
+	"BC2RGBA":    {BlockSize: 16, Components: 4, RequiredFeature: "TextureCompressionBC"},
+	"BC3RGBA":    {BlockSize: 16, Components: 4, RequiredFeature: "TextureCompressionBC"},
+	"BC4R":       {BlockSize: 8, Components: 1, RequiredFeature: "TextureCompressionBC"},
+	"BC5RG":      {BlockSize: 16, Components: 2, RequiredFeature: "TextureCompressionBC"},
+	"BC6HRGB":    {BlockSize: 16, Components: 3, RequiredFeature: "TextureCompressionBC"},
+	"BC7RGBA":    {BlockSize: 16, Components: 4, RequiredFeature: "TextureCompressionBC"},
+	"ETC2RGB8":   {BlockSize: 8, Components: 3, RequiredFeature: "TextureCompressionETC2"},
+	"ETC2RGB8A1": {BlockSize: 8, Components: 4, RequiredFeature: "TextureCompressionETC2"},
+	"ETC2RGBA8":  {BlockSize: 16, Components: 4, RequiredFeature: "TextureCompressionETC2"},
+	"EACR11":     {BlockSize: 8, Components: 1, RequiredFeature: "TextureCompressionETC2"},
+	"EACRG11":    {BlockSize: 16, Components: 2, RequiredFeature: "TextureCompressionETC2"},
+}
+
+// packedFormats are the formats whose components don't all have the same
+// size.
+var packedFormats = map[string]TextureFormatInfo{
+	"RGB10A2Unorm":  {BlockSize: 4, Components: 4},
+	"RG11B10Ufloat": {BlockSize: 4, Components: 3},
+	"RGB9E5Ufloat":  {BlockSize: 4, Components: 3},
+}
+
+// textureFormatInfo derives the metadata of the format name from it, names
+// is the set of all the formats.
+func textureFormatInfo(name string, names map[string]bool) (TextureFormatInfo, error) {
+	var info TextureFormatInfo
+	if v, ok := depthStencilFormats[name]; ok {
+		info = v
+		info.BlockWidth, info.BlockHeight = 1, 1
+		info.LinearFormat = name
+		return info, nil
+	}
+
+	base := strings.TrimSuffix(name, "Srgb")
+	packed, isPacked := packedFormats[name]
+	switch {
+	case isPacked:
+		info = packed
+		info.BlockWidth, info.BlockHeight = 1, 1
+		info.SampleType = "Float"
+
+	case uncompressedFormatRegexp.MatchString(name):
+		m := uncompressedFormatRegexp.FindStringSubmatch(name)
+		bits, _ := strconv.Atoi(m[2])
+		info.BlockWidth, info.BlockHeight = 1, 1
+		info.Components = uint32(len(m[1]))
+		info.BlockSize = info.Components * uint32(bits) / 8
+		switch m[3] {
+		case "Uint", "Sint":
+			info.SampleType = m[3]
+		case "Float":
+			if bits == 32 {
+				info.SampleType = "UnfilterableFloat"
+			} else {
+				info.SampleType = "Float"
+			}
+		default:
+			info.SampleType = "Float"
+		}
+
+	case astcFormatRegexp.MatchString(name):
+		m := astcFormatRegexp.FindStringSubmatch(name)
+		w, _ := strconv.Atoi(m[1])
+		h, _ := strconv.Atoi(m[2])
+		info.BlockWidth, info.BlockHeight = uint32(w), uint32(h)
+		info.BlockSize = 16
+		info.Components = 4
+		info.SampleType = "Float"
+		info.Compressed = true
+		info.RequiredFeature = "TextureCompressionASTC"
+
+	default:
+		found := false
+		for _, suffix := range []string{"Unorm", "Snorm", "Ufloat", "Float"} {
+			v, ok := compressedFormats[strings.TrimSuffix(base, suffix)]
+			if ok && strings.HasSuffix(base, suffix) {
+				info = v
+				found = true
+				break
+			}
+		}
+		if !found {
+			return info, fmt.Errorf("unknown texture format %s", name)
+		}
+		info.BlockWidth, info.BlockHeight = 4, 4
+		info.SampleType = "Float"
+		info.Compressed = true
+	}
+
+	info.Srgb = base != name
+	info.Color = true
+	info.LinearFormat = base
+	if names[base+"Srgb"] {
+		info.SrgbFormat = base + "Srgb"
+	}
+	return info, nil
+}
+
+// writeTextureFormatInfo writes the TextureFormatInfo type, and the Info
+// method of TextureFormat returning the metadata of formats.
+func writeTextureFormatInfo(w io.Writer, formats []Pair) {
+	names := map[string]bool{}
+	for _, v := range formats {
+		names[strings.TrimPrefix(v.Enum, "TextureFormat_")] = true
+	}
+
+	fmt.Fprint(w, `// TextureFormatInfo is the metadata of a texture format.
+type TextureFormatInfo struct {
+	// BlockWidth and BlockHeight are the size of the blocks of the format in
+	// texels, 1 for uncompressed formats.
+	BlockWidth  uint32
+	BlockHeight uint32
+	// BlockSize is the size of a block in bytes in copies between buffers and
+	// textures, zero for depth formats that can't be copied and for combined
+	// depth stencil formats, whose aspects must be copied one at a time.
+	BlockSize  uint32
+	Components uint32
+	// Color, Depth and Stencil are the aspects of the format.
+	Color   bool
+	Depth   bool
+	Stencil bool
+	// SampleType is the sample type of the format, of its depth aspect for
+	// combined depth stencil formats.
+	SampleType TextureSampleType
+	Srgb       bool
+	Compressed bool
+	// Filterable is set if the format can be sampled with a filtering
+	// sampler. 32-bit float formats can be too with FeatureName_Float32Filterable.
+	Filterable bool
+	// SrgbFormat and LinearFormat are the sRGB and linear variants of the
+	// format, SrgbFormat is TextureFormat_Undefined if it has none. Either is
+	// the format itself.
+	SrgbFormat   TextureFormat
+	LinearFormat TextureFormat
+	// RequiredFeature is the feature a device needs to create textures of the
+	// format, FeatureName_Undefined if none is.
+	RequiredFeature FeatureName
+}
+
+`)
+
+	fmt.Fprint(w, "func (v TextureFormat) Info() TextureFormatInfo {\n")
+	fmt.Fprint(w, "switch v {\n")
+	for _, v := range formats {
+		name := strings.TrimPrefix(v.Enum, "TextureFormat_")
+		if name == "Undefined" {
+			continue
+		}
+		info, err := textureFormatInfo(name, names)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Fprintf(w, "case %s:\n", v.Enum)
+		fmt.Fprint(w, "return TextureFormatInfo{\n")
+		fmt.Fprintf(w, "BlockWidth: %d,\n", info.BlockWidth)
+		fmt.Fprintf(w, "BlockHeight: %d,\n", info.BlockHeight)
+		fmt.Fprintf(w, "BlockSize: %d,\n", info.BlockSize)
+		fmt.Fprintf(w, "Components: %d,\n", info.Components)
+		fmt.Fprintf(w, "Color: %t,\n", info.Color)
+		fmt.Fprintf(w, "Depth: %t,\n", info.Depth)
+		fmt.Fprintf(w, "Stencil: %t,\n", info.Stencil)
+		fmt.Fprintf(w, "SampleType: TextureSampleType_%s,\n", info.SampleType)
+		fmt.Fprintf(w, "Srgb: %t,\n", info.Srgb)
+		fmt.Fprintf(w, "Compressed: %t,\n", info.Compressed)
+		fmt.Fprintf(w, "Filterable: %t,\n", info.SampleType == "Float")
+		if info.SrgbFormat != "" {
+			fmt.Fprintf(w, "SrgbFormat: TextureFormat_%s,\n", info.SrgbFormat)
+		}
+		if info.LinearFormat != "" {
+			fmt.Fprintf(w, "LinearFormat: TextureFormat_%s,\n", info.LinearFormat)
+		}
+		if info.RequiredFeature != "" {
+			fmt.Fprintf(w, "RequiredFeature: FeatureName_%s,\n", info.RequiredFeature)
+		}
+		fmt.Fprint(w, "}\n")
+	}
+	fmt.Fprint(w, "default:\n")
+	fmt.Fprint(w, "return TextureFormatInfo{}\n")
+	fmt.Fprint(w, "}\n")
+	fmt.Fprint(w, "}\n")
+}
@@ -0,0 +1,155 @@
+// Command bench_validation compares ValidationMode_Immediate, the default
+// behavior where every call opens its own error scope, with
+// ValidationMode_Deferred on Queue.WriteBuffer and
+// CommandEncoder.CopyBufferToBuffer.
+package main
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+var forceFallbackAdapter = os.Getenv("WGPU_FORCE_FALLBACK_ADAPTER") == "1"
+
+func init() {
+	switch os.Getenv("WGPU_LOG_LEVEL") {
+	case "OFF":
+		wgpu.SetLogLevel(wgpu.LogLevel_Off)
+	case "ERROR":
+		wgpu.SetLogLevel(wgpu.LogLevel_Error)
+	case "WARN":
+		wgpu.SetLogLevel(wgpu.LogLevel_Warn)
+	case "INFO":
+		wgpu.SetLogLevel(wgpu.LogLevel_Info)
+	case "DEBUG":
+		wgpu.SetLogLevel(wgpu.LogLevel_Debug)
+	case "TRACE":
+		wgpu.SetLogLevel(wgpu.LogLevel_Trace)
+	}
+}
+
+// callsPerSubmit is the number of calls batched between two submissions,
+// i.e. between two collections in deferred mode.
+const callsPerSubmit = 64
+
+const bufferSize = 256
+
+func main() {
+	instance := wgpu.CreateInstance(nil)
+	defer instance.Release()
+
+	adapter, err := instance.RequestAdapter(&wgpu.RequestAdapterOptions{
+		ForceFallbackAdapter: forceFallbackAdapter,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer adapter.Release()
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		panic(err)
+	}
+	defer device.Release()
+	queue := device.GetQueue()
+	defer queue.Release()
+
+	src, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "src",
+		Size:  bufferSize,
+		Usage: wgpu.BufferUsage_CopySrc | wgpu.BufferUsage_CopyDst,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer src.Release()
+
+	dst, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "dst",
+		Size:  bufferSize,
+		Usage: wgpu.BufferUsage_CopyDst,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer dst.Release()
+
+	data := make([]byte, bufferSize)
+
+	writeBuffer := func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := queue.WriteBuffer(src, 0, data); err != nil {
+				b.Fatal(err)
+			}
+			if i%callsPerSubmit == callsPerSubmit-1 {
+				queue.Submit()
+			}
+		}
+		queue.Submit()
+		if err := device.FlushErrors(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	copyBufferToBuffer := func(b *testing.B) {
+		b.ReportAllocs()
+		encoder, err := device.CreateCommandEncoder(nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for i := 0; i < b.N; i++ {
+			if err := encoder.CopyBufferToBuffer(src, 0, dst, 0, bufferSize); err != nil {
+				b.Fatal(err)
+			}
+			if i%callsPerSubmit == callsPerSubmit-1 {
+				submit(queue, encoder)
+				encoder, err = device.CreateCommandEncoder(nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		submit(queue, encoder)
+		if err := device.FlushErrors(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	for _, bench := range []struct {
+		name string
+		fn   func(b *testing.B)
+	}{
+		{"WriteBuffer", writeBuffer},
+		{"CopyBufferToBuffer", copyBufferToBuffer},
+	} {
+		for _, mode := range []wgpu.ValidationMode{
+			wgpu.ValidationMode_Immediate,
+			wgpu.ValidationMode_Deferred,
+		} {
+			if err := device.SetValidationMode(mode); err != nil {
+				panic(err)
+			}
+			r := testing.Benchmark(bench.fn)
+			fmt.Printf("%s/%s\t%s\t%s\n", bench.name, mode, r, r.MemString())
+		}
+		if err := device.SetValidationMode(wgpu.ValidationMode_Immediate); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func submit(queue *wgpu.Queue, encoder *wgpu.CommandEncoder) {
+	defer encoder.Release()
+
+	cmdBuffer, err := encoder.Finish(nil)
+	if err != nil {
+		panic(err)
+	}
+	defer cmdBuffer.Release()
+
+	queue.Submit(cmdBuffer)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 	"unsafe"
 
 	"github.com/rajveermalviya/go-webgpu/wgpu"
@@ -55,6 +56,8 @@ func main() {
 		panic(err)
 	}
 	defer device.Release()
+	device.StartPoller(time.Millisecond)
+	defer device.StopPoller()
 	queue := device.GetQueue()
 	defer queue.Release()
 
@@ -142,20 +145,11 @@ func main() {
 	defer cmdBuffer.Release()
 	queue.Submit(cmdBuffer)
 
-	var status wgpu.BufferMapAsyncStatus
-	err = stagingBuffer.MapAsync(wgpu.MapMode_Read, 0, size, func(s wgpu.BufferMapAsyncStatus) {
-		status = s
-	})
-	if err != nil {
-		panic(err)
-	}
-	defer stagingBuffer.Unmap()
-
-	device.Poll(true, nil)
-
+	status := <-stagingBuffer.MapAsyncChan(wgpu.MapMode_Read, 0, size)
 	if status != wgpu.BufferMapAsyncStatus_Success {
 		panic(status)
 	}
+	defer stagingBuffer.Unmap()
 
 	steps := wgpu.FromBytes[uint32](stagingBuffer.GetMappedRange(0, uint(size)))
 
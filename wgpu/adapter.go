@@ -11,8 +11,10 @@ extern void gowebgpu_device_lost_callback_c(WGPUDeviceLostReason reason, char co
 */
 import "C"
 import (
-	"errors"
+	"context"
 	"runtime/cgo"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -21,6 +23,7 @@ type Adapter struct {
 }
 
 func (p *Adapter) EnumerateFeatures() []FeatureName {
+	p.checkReleased()
 	size := C.wgpuAdapterEnumerateFeatures(p.ref, nil)
 	if size == 0 {
 		return nil
@@ -36,6 +39,7 @@ type SupportedLimits struct {
 }
 
 func (p *Adapter) GetLimits() SupportedLimits {
+	p.checkReleased()
 	var supportedLimits C.WGPUSupportedLimits
 
 	extras := (*C.WGPUSupportedLimitsExtras)(C.malloc(C.size_t(unsafe.Sizeof(C.WGPUSupportedLimitsExtras{}))))
@@ -96,6 +100,7 @@ type AdapterProperties struct {
 }
 
 func (p *Adapter) GetProperties() AdapterProperties {
+	p.checkReleased()
 	var props C.WGPUAdapterProperties
 
 	C.wgpuAdapterGetProperties(p.ref, &props)
@@ -113,6 +118,7 @@ func (p *Adapter) GetProperties() AdapterProperties {
 }
 
 func (p *Adapter) HasFeature(feature FeatureName) bool {
+	p.checkReleased()
 	hasFeature := C.wgpuAdapterHasFeature(p.ref, C.WGPUFeatureName(feature))
 	return bool(hasFeature)
 }
@@ -121,12 +127,9 @@ type requestDeviceCb func(status RequestDeviceStatus, device *Device, message st
 
 //export gowebgpu_request_device_callback_go
 func gowebgpu_request_device_callback_go(status C.WGPURequestDeviceStatus, device C.WGPUDevice, message *C.char, userdata unsafe.Pointer) {
-	handle := *(*cgo.Handle)(userdata)
-	defer handle.Delete()
-
-	cb, ok := handle.Value().(requestDeviceCb)
+	cb, ok := takeCallbackHandle(userdata).(requestDeviceCb)
 	if ok {
-		cb(RequestDeviceStatus(status), &Device{ref: device}, C.GoString(message))
+		cb(RequestDeviceStatus(status), newDevice(device), C.GoString(message))
 	}
 }
 
@@ -134,15 +137,40 @@ type DeviceLostCallback func(reason DeviceLostReason, message string)
 
 //export gowebgpu_device_lost_callback_go
 func gowebgpu_device_lost_callback_go(reason C.WGPUDeviceLostReason, message *C.char, userdata unsafe.Pointer) {
-	handle := *(*cgo.Handle)(userdata)
-	defer handle.Delete()
-
-	cb, ok := handle.Value().(DeviceLostCallback)
-	if ok {
+	if cb := takeDeviceLostCallback((*cgo.Handle)(userdata)); cb != nil {
 		cb(DeviceLostReason(reason), C.GoString(message))
 	}
 }
 
+// newDeviceLostHandle returns the userdata of the device lost callback. It is
+// in C memory, as native keeps it for as long as the device lives, and is
+// freed by freeDeviceLostHandle.
+func newDeviceLostHandle(callback DeviceLostCallback) *cgo.Handle {
+	p := (*cgo.Handle)(C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0)))))
+	*p = cgo.NewHandle(callback)
+	return p
+}
+
+// takeDeviceLostCallback deletes the handle and returns its callback, or nil
+// if it was already deleted.
+func takeDeviceLostCallback(p *cgo.Handle) DeviceLostCallback {
+	handle := cgo.Handle(atomic.SwapUintptr((*uintptr)(unsafe.Pointer(p)), 0))
+	if handle == 0 {
+		return nil
+	}
+	defer handle.Delete()
+
+	cb, _ := handle.Value().(DeviceLostCallback)
+	return cb
+}
+
+// freeDeviceLostHandle deletes the handle if needed and frees its memory,
+// once native can't call the callback anymore.
+func freeDeviceLostHandle(p *cgo.Handle) {
+	takeDeviceLostCallback(p)
+	C.free(unsafe.Pointer(p))
+}
+
 type RequiredLimits struct {
 	Limits Limits
 }
@@ -156,6 +184,19 @@ type DeviceDescriptor struct {
 }
 
 func (p *Adapter) RequestDevice(descriptor *DeviceDescriptor) (*Device, error) {
+	p.checkReleased()
+	return p.RequestDeviceContext(context.Background(), descriptor)
+}
+
+// RequestDeviceContext is like RequestDevice but returns ctx.Err() if ctx
+// is done before the device is acquired. A device that arrives after that
+// is released.
+func (p *Adapter) RequestDeviceContext(ctx context.Context, descriptor *DeviceDescriptor) (*Device, error) {
+	p.checkReleased()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	var desc *C.WGPUDeviceDescriptor = nil
 
 	if descriptor != nil {
@@ -229,13 +270,6 @@ func (p *Adapter) RequestDevice(descriptor *DeviceDescriptor) (*Device, error) {
 			desc.requiredLimits.nextInChain = (*C.WGPUChainedStruct)(unsafe.Pointer(requiredLimitsExtras))
 		}
 
-		if descriptor.DeviceLostCallback != nil {
-			handle := cgo.NewHandle(descriptor.DeviceLostCallback)
-
-			desc.deviceLostCallback = C.WGPUDeviceLostCallback(C.gowebgpu_device_lost_callback_c)
-			desc.deviceLostUserdata = unsafe.Pointer(&handle)
-		}
-
 		if descriptor.TracePath != "" {
 			deviceExtras := (*C.WGPUDeviceExtras)(C.malloc(C.size_t(unsafe.Sizeof(C.WGPUDeviceExtras{}))))
 			defer C.free(unsafe.Pointer(deviceExtras))
@@ -252,23 +286,100 @@ func (p *Adapter) RequestDevice(descriptor *DeviceDescriptor) (*Device, error) {
 		}
 	}
 
-	var status RequestDeviceStatus
-	var device *Device
+	if desc == nil {
+		desc = &C.WGPUDeviceDescriptor{}
+	}
+
+	lost := &deviceLostState{}
+	var deviceLostCallback DeviceLostCallback = func(reason DeviceLostReason, message string) {
+		lost.lose(DeviceLostInfo{Reason: reason, Message: message})
 
-	var cb requestDeviceCb = func(s RequestDeviceStatus, d *Device, _ string) {
-		status = s
-		device = d
+		if descriptor != nil && descriptor.DeviceLostCallback != nil {
+			descriptor.DeviceLostCallback(reason, message)
+		}
+	}
+	// owned by the device once there is one, freed below otherwise.
+	deviceLostHandle := newDeviceLostHandle(deviceLostCallback)
+	desc.deviceLostCallback = C.WGPUDeviceLostCallback(C.gowebgpu_device_lost_callback_c)
+	desc.deviceLostUserdata = unsafe.Pointer(deviceLostHandle)
+
+	type result struct {
+		status  RequestDeviceStatus
+		device  *Device
+		message string
 	}
-	handle := cgo.NewHandle(cb)
-	C.wgpuAdapterRequestDevice(p.ref, desc, C.WGPURequestDeviceCallback(C.gowebgpu_request_device_callback_c), unsafe.Pointer(&handle))
+	results := make(chan result, 1)
+
+	var mu sync.Mutex
+	var abandoned bool
 
-	if status != RequestDeviceStatus_Success {
-		return nil, errors.New("failed to request device")
+	var cb requestDeviceCb = func(s RequestDeviceStatus, d *Device, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if s == RequestDeviceStatus_Success {
+			d.native.mu.Lock()
+			d.native.lostHandle = deviceLostHandle
+			d.native.mu.Unlock()
+		} else {
+			freeDeviceLostHandle(deviceLostHandle)
+		}
+		if abandoned {
+			if s == RequestDeviceStatus_Success {
+				d.Release()
+			}
+			return
+		}
+		results <- result{s, d, message}
 	}
+	C.wgpuAdapterRequestDevice(p.ref, desc, C.WGPURequestDeviceCallback(C.gowebgpu_request_device_callback_c), newCallbackHandle(cb))
+
+	var r result
+	select {
+	case r = <-results:
+	case <-ctx.Done():
+		mu.Lock()
+		select {
+		case r = <-results:
+		default:
+			abandoned = true
+			// drop the callback now, the memory is freed once native
+			// answers.
+			takeDeviceLostCallback(deviceLostHandle)
+		}
+		mu.Unlock()
 
-	return device, nil
+		if abandoned {
+			return nil, ctx.Err()
+		}
+	}
+
+	if r.status != RequestDeviceStatus_Success {
+		message := r.message
+		if message == "" {
+			message = "failed to request device: " + r.status.String()
+		}
+		return nil, &Error{
+			Type:    ErrorType_Unknown,
+			Op:      "wgpu.(*Adapter).RequestDevice()",
+			Message: message,
+		}
+	}
+
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	r.device.label = label
+	r.device.lost = lost
+	return trackDevice(r.device, label), nil
 }
 
 func (p *Adapter) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
 	C.wgpuAdapterRelease(p.ref)
+	p.ref = nil
 }
@@ -0,0 +1,1182 @@
+package wgpu
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+// apiHandle is implemented by the wrappers returned from the API methods.
+type apiHandle[T any] interface {
+	native() *T
+}
+
+// fromAPI returns the object wrapped by v, or nil if v is nil. It panics
+// with a *api.ForeignHandleError if v was created by another implementation
+// of package api.
+func fromAPI[T any](v any, typ string) *T {
+	if v == nil {
+		return nil
+	}
+	h, ok := v.(apiHandle[T])
+	if !ok {
+		panic(&api.ForeignHandleError{Type: typ, Package: "wgpu"})
+	}
+	return h.native()
+}
+
+func featuresToAPI(features []FeatureName) []api.FeatureName {
+	if features == nil {
+		return nil
+	}
+	ret := make([]api.FeatureName, len(features))
+	for i, v := range features {
+		ret[i] = api.FeatureName(v)
+	}
+	return ret
+}
+
+func supportedLimitsToAPI(limits SupportedLimits) api.SupportedLimits {
+	return api.SupportedLimits{Limits: api.Limits(limits.Limits)}
+}
+
+func imageCopyTextureFromAPI(v *api.ImageCopyTexture) *ImageCopyTexture {
+	if v == nil {
+		return nil
+	}
+	return &ImageCopyTexture{
+		Texture:  fromAPI[Texture](v.Texture, "Texture"),
+		MipLevel: v.MipLevel,
+		Origin:   Origin3D(v.Origin),
+		Aspect:   TextureAspect(v.Aspect),
+	}
+}
+
+func imageCopyBufferFromAPI(v *api.ImageCopyBuffer) *ImageCopyBuffer {
+	if v == nil {
+		return nil
+	}
+	return &ImageCopyBuffer{
+		Layout: TextureDataLayout(v.Layout),
+		Buffer: fromAPI[Buffer](v.Buffer, "Buffer"),
+	}
+}
+
+// API returns p as an api.Instance.
+//
+// Methods of the returned handle and of the handles created from it panic
+// with a *api.ForeignHandleError when passed a handle of another
+// implementation of package api.
+func (p *Instance) API() api.Instance {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiInstance{p}
+}
+
+type apiInstance struct{ instance *Instance }
+
+func (p *apiInstance) native() *Instance { return p.instance }
+
+func (p *apiInstance) RequestAdapter(options *api.RequestAdapterOptions) (api.Adapter, error) {
+	var opts *RequestAdapterOptions
+	if options != nil {
+		opts = &RequestAdapterOptions{
+			PowerPreference:      PowerPreference(options.PowerPreference),
+			ForceFallbackAdapter: options.ForceFallbackAdapter,
+			BackendType:          BackendType(options.BackendType),
+		}
+	}
+	adapter, err := p.instance.RequestAdapter(opts)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.API(), nil
+}
+
+func (p *apiInstance) Release() { p.instance.Release() }
+
+// API returns p as an api.Adapter.
+func (p *Adapter) API() api.Adapter {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiAdapter{p}
+}
+
+type apiAdapter struct{ adapter *Adapter }
+
+func (p *apiAdapter) native() *Adapter { return p.adapter }
+
+func (p *apiAdapter) EnumerateFeatures() []api.FeatureName {
+	return featuresToAPI(p.adapter.EnumerateFeatures())
+}
+
+func (p *apiAdapter) GetLimits() api.SupportedLimits {
+	return supportedLimitsToAPI(p.adapter.GetLimits())
+}
+
+func (p *apiAdapter) GetProperties() api.AdapterProperties {
+	props := p.adapter.GetProperties()
+	return api.AdapterProperties{
+		VendorId:          props.VendorId,
+		VendorName:        props.VendorName,
+		Architecture:      props.Architecture,
+		DeviceId:          props.DeviceId,
+		Name:              props.Name,
+		DriverDescription: props.DriverDescription,
+		AdapterType:       api.AdapterType(props.AdapterType),
+		BackendType:       api.BackendType(props.BackendType),
+	}
+}
+
+func (p *apiAdapter) HasFeature(feature api.FeatureName) bool {
+	return p.adapter.HasFeature(FeatureName(feature))
+}
+
+func (p *apiAdapter) RequestDevice(descriptor *api.DeviceDescriptor) (api.Device, error) {
+	var desc *DeviceDescriptor
+	if descriptor != nil {
+		desc = &DeviceDescriptor{Label: descriptor.Label}
+		for _, v := range descriptor.RequiredFeatures {
+			desc.RequiredFeatures = append(desc.RequiredFeatures, FeatureName(v))
+		}
+		if descriptor.RequiredLimits != nil {
+			desc.RequiredLimits = &RequiredLimits{Limits: Limits(descriptor.RequiredLimits.Limits)}
+		}
+	}
+	device, err := p.adapter.RequestDevice(desc)
+	if err != nil {
+		return nil, err
+	}
+	return device.API(), nil
+}
+
+func (p *apiAdapter) Release() { p.adapter.Release() }
+
+// API returns p as an api.Device. Like with Instance.API, its methods panic
+// when passed a handle of another implementation.
+func (p *Device) API() api.Device {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiDevice{p}
+}
+
+type apiDevice struct{ device *Device }
+
+func (p *apiDevice) native() *Device { return p.device }
+
+func (p *apiDevice) CreateBindGroup(descriptor *api.BindGroupDescriptor) (api.BindGroup, error) {
+	var desc *BindGroupDescriptor
+	if descriptor != nil {
+		desc = &BindGroupDescriptor{
+			Label:  descriptor.Label,
+			Layout: fromAPI[BindGroupLayout](descriptor.Layout, "BindGroupLayout"),
+		}
+		for _, v := range descriptor.Entries {
+			desc.Entries = append(desc.Entries, BindGroupEntry{
+				Binding:     v.Binding,
+				Buffer:      fromAPI[Buffer](v.Buffer, "Buffer"),
+				Offset:      v.Offset,
+				Size:        v.Size,
+				Sampler:     fromAPI[Sampler](v.Sampler, "Sampler"),
+				TextureView: fromAPI[TextureView](v.TextureView, "TextureView"),
+			})
+		}
+	}
+	bindGroup, err := p.device.CreateBindGroup(desc)
+	if err != nil {
+		return nil, err
+	}
+	return bindGroup.API(), nil
+}
+
+func (p *apiDevice) CreateBindGroupLayout(descriptor *api.BindGroupLayoutDescriptor) (api.BindGroupLayout, error) {
+	var desc *BindGroupLayoutDescriptor
+	if descriptor != nil {
+		desc = &BindGroupLayoutDescriptor{Label: descriptor.Label}
+		for _, v := range descriptor.Entries {
+			desc.Entries = append(desc.Entries, BindGroupLayoutEntry{
+				Binding:    v.Binding,
+				Visibility: ShaderStage(v.Visibility),
+				Buffer: BufferBindingLayout{
+					Type:             BufferBindingType(v.Buffer.Type),
+					HasDynamicOffset: v.Buffer.HasDynamicOffset,
+					MinBindingSize:   v.Buffer.MinBindingSize,
+				},
+				Sampler: SamplerBindingLayout{
+					Type: SamplerBindingType(v.Sampler.Type),
+				},
+				Texture: TextureBindingLayout{
+					SampleType:    TextureSampleType(v.Texture.SampleType),
+					ViewDimension: TextureViewDimension(v.Texture.ViewDimension),
+					Multisampled:  v.Texture.Multisampled,
+				},
+				StorageTexture: StorageTextureBindingLayout{
+					Access:        StorageTextureAccess(v.StorageTexture.Access),
+					Format:        TextureFormat(v.StorageTexture.Format),
+					ViewDimension: TextureViewDimension(v.StorageTexture.ViewDimension),
+				},
+			})
+		}
+	}
+	bindGroupLayout, err := p.device.CreateBindGroupLayout(desc)
+	if err != nil {
+		return nil, err
+	}
+	return bindGroupLayout.API(), nil
+}
+
+func (p *apiDevice) CreateBuffer(descriptor *api.BufferDescriptor) (api.Buffer, error) {
+	var desc *BufferDescriptor
+	if descriptor != nil {
+		desc = &BufferDescriptor{
+			Label:            descriptor.Label,
+			Usage:            BufferUsage(descriptor.Usage),
+			Size:             descriptor.Size,
+			MappedAtCreation: descriptor.MappedAtCreation,
+		}
+	}
+	buffer, err := p.device.CreateBuffer(desc)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.API(), nil
+}
+
+func (p *apiDevice) CreateBufferInit(descriptor *api.BufferInitDescriptor) (api.Buffer, error) {
+	var desc *BufferInitDescriptor
+	if descriptor != nil {
+		desc = &BufferInitDescriptor{
+			Label:    descriptor.Label,
+			Contents: descriptor.Contents,
+			Usage:    BufferUsage(descriptor.Usage),
+		}
+	}
+	buffer, err := p.device.CreateBufferInit(desc)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.API(), nil
+}
+
+func (p *apiDevice) CreateCommandEncoder(descriptor *api.CommandEncoderDescriptor) (api.CommandEncoder, error) {
+	var desc *CommandEncoderDescriptor
+	if descriptor != nil {
+		desc = &CommandEncoderDescriptor{Label: descriptor.Label}
+	}
+	encoder, err := p.device.CreateCommandEncoder(desc)
+	if err != nil {
+		return nil, err
+	}
+	return encoder.API(), nil
+}
+
+func (p *apiDevice) CreateComputePipeline(descriptor *api.ComputePipelineDescriptor) (api.ComputePipeline, error) {
+	var desc *ComputePipelineDescriptor
+	if descriptor != nil {
+		desc = &ComputePipelineDescriptor{
+			Label:  descriptor.Label,
+			Layout: fromAPI[PipelineLayout](descriptor.Layout, "PipelineLayout"),
+			Compute: ProgrammableStageDescriptor{
+				Module:     fromAPI[ShaderModule](descriptor.Compute.Module, "ShaderModule"),
+				EntryPoint: descriptor.Compute.EntryPoint,
+			},
+		}
+	}
+	pipeline, err := p.device.CreateComputePipeline(desc)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.API(), nil
+}
+
+func (p *apiDevice) CreatePipelineLayout(descriptor *api.PipelineLayoutDescriptor) (api.PipelineLayout, error) {
+	var desc *PipelineLayoutDescriptor
+	if descriptor != nil {
+		desc = &PipelineLayoutDescriptor{Label: descriptor.Label}
+		for _, v := range descriptor.BindGroupLayouts {
+			desc.BindGroupLayouts = append(desc.BindGroupLayouts, fromAPI[BindGroupLayout](v, "BindGroupLayout"))
+		}
+		for _, v := range descriptor.PushConstantRanges {
+			desc.PushConstantRanges = append(desc.PushConstantRanges, PushConstantRange{
+				Stages: ShaderStage(v.Stages),
+				Start:  v.Start,
+				End:    v.End,
+			})
+		}
+	}
+	pipelineLayout, err := p.device.CreatePipelineLayout(desc)
+	if err != nil {
+		return nil, err
+	}
+	return pipelineLayout.API(), nil
+}
+
+func (p *apiDevice) CreateQuerySet(descriptor *api.QuerySetDescriptor) (api.QuerySet, error) {
+	var desc *QuerySetDescriptor
+	if descriptor != nil {
+		desc = &QuerySetDescriptor{
+			Label: descriptor.Label,
+			Type:  QueryType(descriptor.Type),
+			Count: descriptor.Count,
+		}
+		for _, v := range descriptor.PipelineStatistics {
+			desc.PipelineStatistics = append(desc.PipelineStatistics, PipelineStatisticName(v))
+		}
+	}
+	querySet, err := p.device.CreateQuerySet(desc)
+	if err != nil {
+		return nil, err
+	}
+	return querySet.API(), nil
+}
+
+func (p *apiDevice) CreateRenderBundleEncoder(descriptor *api.RenderBundleEncoderDescriptor) (api.RenderBundleEncoder, error) {
+	var desc *RenderBundleEncoderDescriptor
+	if descriptor != nil {
+		desc = &RenderBundleEncoderDescriptor{
+			Label:              descriptor.Label,
+			DepthStencilFormat: TextureFormat(descriptor.DepthStencilFormat),
+			SampleCount:        descriptor.SampleCount,
+			DepthReadOnly:      descriptor.DepthReadOnly,
+			StencilReadOnly:    descriptor.StencilReadOnly,
+		}
+		for _, v := range descriptor.ColorFormats {
+			desc.ColorFormats = append(desc.ColorFormats, TextureFormat(v))
+		}
+	}
+	encoder, err := p.device.CreateRenderBundleEncoder(desc)
+	if err != nil {
+		return nil, err
+	}
+	return encoder.API(), nil
+}
+
+func (p *apiDevice) CreateRenderPipeline(descriptor *api.RenderPipelineDescriptor) (api.RenderPipeline, error) {
+	var desc *RenderPipelineDescriptor
+	if descriptor != nil {
+		desc = &RenderPipelineDescriptor{
+			Label:  descriptor.Label,
+			Layout: fromAPI[PipelineLayout](descriptor.Layout, "PipelineLayout"),
+			Vertex: VertexState{
+				Module:     fromAPI[ShaderModule](descriptor.Vertex.Module, "ShaderModule"),
+				EntryPoint: descriptor.Vertex.EntryPoint,
+			},
+			Primitive: PrimitiveState{
+				Topology:         PrimitiveTopology(descriptor.Primitive.Topology),
+				StripIndexFormat: IndexFormat(descriptor.Primitive.StripIndexFormat),
+				FrontFace:        FrontFace(descriptor.Primitive.FrontFace),
+				CullMode:         CullMode(descriptor.Primitive.CullMode),
+			},
+			Multisample: MultisampleState(descriptor.Multisample),
+		}
+		for _, v := range descriptor.Vertex.Buffers {
+			layout := VertexBufferLayout{
+				ArrayStride: v.ArrayStride,
+				StepMode:    VertexStepMode(v.StepMode),
+			}
+			for _, a := range v.Attributes {
+				layout.Attributes = append(layout.Attributes, VertexAttribute{
+					Format:         VertexFormat(a.Format),
+					Offset:         a.Offset,
+					ShaderLocation: a.ShaderLocation,
+				})
+			}
+			desc.Vertex.Buffers = append(desc.Vertex.Buffers, layout)
+		}
+		if ds := descriptor.DepthStencil; ds != nil {
+			desc.DepthStencil = &DepthStencilState{
+				Format:              TextureFormat(ds.Format),
+				DepthWriteEnabled:   ds.DepthWriteEnabled,
+				DepthCompare:        CompareFunction(ds.DepthCompare),
+				StencilFront:        stencilFaceStateFromAPI(ds.StencilFront),
+				StencilBack:         stencilFaceStateFromAPI(ds.StencilBack),
+				StencilReadMask:     ds.StencilReadMask,
+				StencilWriteMask:    ds.StencilWriteMask,
+				DepthBias:           ds.DepthBias,
+				DepthBiasSlopeScale: ds.DepthBiasSlopeScale,
+				DepthBiasClamp:      ds.DepthBiasClamp,
+			}
+		}
+		if f := descriptor.Fragment; f != nil {
+			desc.Fragment = &FragmentState{
+				Module:     fromAPI[ShaderModule](f.Module, "ShaderModule"),
+				EntryPoint: f.EntryPoint,
+			}
+			for _, v := range f.Targets {
+				target := ColorTargetState{
+					Format:    TextureFormat(v.Format),
+					WriteMask: ColorWriteMask(v.WriteMask),
+				}
+				if v.Blend != nil {
+					target.Blend = &BlendState{
+						Color: blendComponentFromAPI(v.Blend.Color),
+						Alpha: blendComponentFromAPI(v.Blend.Alpha),
+					}
+				}
+				desc.Fragment.Targets = append(desc.Fragment.Targets, target)
+			}
+		}
+	}
+	pipeline, err := p.device.CreateRenderPipeline(desc)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.API(), nil
+}
+
+func stencilFaceStateFromAPI(v api.StencilFaceState) StencilFaceState {
+	return StencilFaceState{
+		Compare:     CompareFunction(v.Compare),
+		FailOp:      StencilOperation(v.FailOp),
+		DepthFailOp: StencilOperation(v.DepthFailOp),
+		PassOp:      StencilOperation(v.PassOp),
+	}
+}
+
+func blendComponentFromAPI(v api.BlendComponent) BlendComponent {
+	return BlendComponent{
+		Operation: BlendOperation(v.Operation),
+		SrcFactor: BlendFactor(v.SrcFactor),
+		DstFactor: BlendFactor(v.DstFactor),
+	}
+}
+
+func (p *apiDevice) CreateSampler(descriptor *api.SamplerDescriptor) (api.Sampler, error) {
+	var desc *SamplerDescriptor
+	if descriptor != nil {
+		desc = &SamplerDescriptor{
+			Label:          descriptor.Label,
+			AddressModeU:   AddressMode(descriptor.AddressModeU),
+			AddressModeV:   AddressMode(descriptor.AddressModeV),
+			AddressModeW:   AddressMode(descriptor.AddressModeW),
+			MagFilter:      FilterMode(descriptor.MagFilter),
+			MinFilter:      FilterMode(descriptor.MinFilter),
+			MipmapFilter:   MipmapFilterMode(descriptor.MipmapFilter),
+			LodMinClamp:    descriptor.LodMinClamp,
+			LodMaxClamp:    descriptor.LodMaxClamp,
+			Compare:        CompareFunction(descriptor.Compare),
+			MaxAnisotrophy: descriptor.MaxAnisotrophy,
+		}
+	}
+	sampler, err := p.device.CreateSampler(desc)
+	if err != nil {
+		return nil, err
+	}
+	return sampler.API(), nil
+}
+
+func (p *apiDevice) CreateShaderModule(descriptor *api.ShaderModuleDescriptor) (api.ShaderModule, error) {
+	var desc *ShaderModuleDescriptor
+	if descriptor != nil {
+		desc = &ShaderModuleDescriptor{Label: descriptor.Label}
+		if v := descriptor.SPIRVDescriptor; v != nil {
+			desc.SPIRVDescriptor = &ShaderModuleSPIRVDescriptor{Code: v.Code}
+		}
+		if v := descriptor.WGSLDescriptor; v != nil {
+			desc.WGSLDescriptor = &ShaderModuleWGSLDescriptor{Code: v.Code}
+		}
+		if v := descriptor.GLSLDescriptor; v != nil {
+			desc.GLSLDescriptor = &ShaderModuleGLSLDescriptor{
+				Code:        v.Code,
+				Defines:     v.Defines,
+				ShaderStage: ShaderStage(v.ShaderStage),
+			}
+		}
+	}
+	shaderModule, err := p.device.CreateShaderModule(desc)
+	if err != nil {
+		return nil, err
+	}
+	return shaderModule.API(), nil
+}
+
+func (p *apiDevice) CreateTexture(descriptor *api.TextureDescriptor) (api.Texture, error) {
+	var desc *TextureDescriptor
+	if descriptor != nil {
+		desc = &TextureDescriptor{
+			Label:         descriptor.Label,
+			Usage:         TextureUsage(descriptor.Usage),
+			Dimension:     TextureDimension(descriptor.Dimension),
+			Size:          Extent3D(descriptor.Size),
+			Format:        TextureFormat(descriptor.Format),
+			MipLevelCount: descriptor.MipLevelCount,
+			SampleCount:   descriptor.SampleCount,
+		}
+	}
+	texture, err := p.device.CreateTexture(desc)
+	if err != nil {
+		return nil, err
+	}
+	return texture.API(), nil
+}
+
+func (p *apiDevice) EnumerateFeatures() []api.FeatureName {
+	return featuresToAPI(p.device.EnumerateFeatures())
+}
+
+func (p *apiDevice) GetLimits() api.SupportedLimits {
+	return supportedLimitsToAPI(p.device.GetLimits())
+}
+
+func (p *apiDevice) GetQueue() api.Queue { return p.device.GetQueue().API() }
+
+func (p *apiDevice) HasFeature(feature api.FeatureName) bool {
+	return p.device.HasFeature(FeatureName(feature))
+}
+
+func (p *apiDevice) Poll(wait bool, wrappedSubmissionIndex *api.WrappedSubmissionIndex) (queueEmpty bool) {
+	var index *WrappedSubmissionIndex
+	if wrappedSubmissionIndex != nil {
+		index = &WrappedSubmissionIndex{
+			Queue:           fromAPI[Queue](wrappedSubmissionIndex.Queue, "Queue"),
+			SubmissionIndex: SubmissionIndex(wrappedSubmissionIndex.SubmissionIndex),
+		}
+	}
+	return p.device.Poll(wait, index)
+}
+
+func (p *apiDevice) PushErrorScope(filter api.ErrorFilter) {
+	p.device.PushErrorScope(ErrorFilter(filter))
+}
+
+func (p *apiDevice) PopErrorScope() error { return p.device.PopErrorScope() }
+
+func (p *apiDevice) SetUncapturedErrorCallback(callback func(err error)) {
+	if callback == nil {
+		p.device.SetUncapturedErrorCallback(nil)
+		return
+	}
+	p.device.SetUncapturedErrorCallback(func(err *Error) { callback(err) })
+}
+
+func (p *apiDevice) Release() { p.device.Release() }
+
+// API returns p as an api.Queue.
+func (p *Queue) API() api.Queue {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiQueue{p}
+}
+
+type apiQueue struct{ queue *Queue }
+
+func (p *apiQueue) native() *Queue { return p.queue }
+
+func (p *apiQueue) OnSubmittedWorkDone(callback api.QueueWorkDoneCallback) {
+	p.queue.OnSubmittedWorkDone(func(status QueueWorkDoneStatus) {
+		callback(api.QueueWorkDoneStatus(status))
+	})
+}
+
+func (p *apiQueue) Submit(commands ...api.CommandBuffer) api.SubmissionIndex {
+	cmds := make([]*CommandBuffer, len(commands))
+	for i, v := range commands {
+		cmds[i] = fromAPI[CommandBuffer](v, "CommandBuffer")
+	}
+	return api.SubmissionIndex(p.queue.Submit(cmds...))
+}
+
+func (p *apiQueue) WriteBuffer(buffer api.Buffer, bufferOffset uint64, data []byte) error {
+	return p.queue.WriteBuffer(fromAPI[Buffer](buffer, "Buffer"), bufferOffset, data)
+}
+
+func (p *apiQueue) WriteTexture(destination *api.ImageCopyTexture, data []byte, dataLayout *api.TextureDataLayout, writeSize *api.Extent3D) error {
+	return p.queue.WriteTexture(imageCopyTextureFromAPI(destination), data, (*TextureDataLayout)(dataLayout), (*Extent3D)(writeSize))
+}
+
+func (p *apiQueue) Release() { p.queue.Release() }
+
+// API returns p as an api.Buffer.
+func (p *Buffer) API() api.Buffer {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiBuffer{p}
+}
+
+type apiBuffer struct{ buffer *Buffer }
+
+func (p *apiBuffer) native() *Buffer { return p.buffer }
+
+func (p *apiBuffer) Destroy() { p.buffer.Destroy() }
+
+func (p *apiBuffer) GetMappedRange(offset, size uint) []byte {
+	return p.buffer.GetMappedRange(offset, size)
+}
+
+func (p *apiBuffer) GetSize() uint64 { return p.buffer.GetSize() }
+
+func (p *apiBuffer) GetUsage() api.BufferUsage { return api.BufferUsage(p.buffer.GetUsage()) }
+
+func (p *apiBuffer) MapAsync(mode api.MapMode, offset uint64, size uint64, callback api.BufferMapCallback) error {
+	return p.buffer.MapAsync(MapMode(mode), offset, size, func(status BufferMapAsyncStatus) {
+		callback(api.BufferMapAsyncStatus(status))
+	})
+}
+
+func (p *apiBuffer) Unmap() error { return p.buffer.Unmap() }
+
+func (p *apiBuffer) Release() { p.buffer.Release() }
+
+// API returns p as an api.Texture.
+func (p *Texture) API() api.Texture {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiTexture{p}
+}
+
+type apiTexture struct{ texture *Texture }
+
+func (p *apiTexture) native() *Texture { return p.texture }
+
+func (p *apiTexture) CreateView(descriptor *api.TextureViewDescriptor) (api.TextureView, error) {
+	var desc *TextureViewDescriptor
+	if descriptor != nil {
+		desc = &TextureViewDescriptor{
+			Label:           descriptor.Label,
+			Format:          TextureFormat(descriptor.Format),
+			Dimension:       TextureViewDimension(descriptor.Dimension),
+			BaseMipLevel:    descriptor.BaseMipLevel,
+			MipLevelCount:   descriptor.MipLevelCount,
+			BaseArrayLayer:  descriptor.BaseArrayLayer,
+			ArrayLayerCount: descriptor.ArrayLayerCount,
+			Aspect:          TextureAspect(descriptor.Aspect),
+		}
+	}
+	view, err := p.texture.CreateView(desc)
+	if err != nil {
+		return nil, err
+	}
+	return view.API(), nil
+}
+
+func (p *apiTexture) Destroy() { p.texture.Destroy() }
+
+func (p *apiTexture) GetDepthOrArrayLayers() uint32 { return p.texture.GetDepthOrArrayLayers() }
+
+func (p *apiTexture) GetDimension() api.TextureDimension {
+	return api.TextureDimension(p.texture.GetDimension())
+}
+
+func (p *apiTexture) GetFormat() api.TextureFormat { return api.TextureFormat(p.texture.GetFormat()) }
+
+func (p *apiTexture) GetHeight() uint32 { return p.texture.GetHeight() }
+
+func (p *apiTexture) GetMipLevelCount() uint32 { return p.texture.GetMipLevelCount() }
+
+func (p *apiTexture) GetSampleCount() uint32 { return p.texture.GetSampleCount() }
+
+func (p *apiTexture) GetUsage() api.TextureUsage { return api.TextureUsage(p.texture.GetUsage()) }
+
+func (p *apiTexture) GetWidth() uint32 { return p.texture.GetWidth() }
+
+func (p *apiTexture) Release() { p.texture.Release() }
+
+// API returns p as an api.TextureView.
+func (p *TextureView) API() api.TextureView {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiTextureView{p}
+}
+
+type apiTextureView struct{ view *TextureView }
+
+func (p *apiTextureView) native() *TextureView { return p.view }
+
+func (p *apiTextureView) Release() { p.view.Release() }
+
+// API returns p as an api.Sampler.
+func (p *Sampler) API() api.Sampler {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiSampler{p}
+}
+
+type apiSampler struct{ sampler *Sampler }
+
+func (p *apiSampler) native() *Sampler { return p.sampler }
+
+func (p *apiSampler) Release() { p.sampler.Release() }
+
+// API returns p as an api.QuerySet.
+func (p *QuerySet) API() api.QuerySet {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiQuerySet{p}
+}
+
+type apiQuerySet struct{ querySet *QuerySet }
+
+func (p *apiQuerySet) native() *QuerySet { return p.querySet }
+
+func (p *apiQuerySet) Release() { p.querySet.Release() }
+
+// API returns p as an api.ShaderModule.
+func (p *ShaderModule) API() api.ShaderModule {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiShaderModule{p}
+}
+
+type apiShaderModule struct{ shaderModule *ShaderModule }
+
+func (p *apiShaderModule) native() *ShaderModule { return p.shaderModule }
+
+func (p *apiShaderModule) Release() { p.shaderModule.Release() }
+
+// API returns p as an api.BindGroupLayout.
+func (p *BindGroupLayout) API() api.BindGroupLayout {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiBindGroupLayout{p}
+}
+
+type apiBindGroupLayout struct{ bindGroupLayout *BindGroupLayout }
+
+func (p *apiBindGroupLayout) native() *BindGroupLayout { return p.bindGroupLayout }
+
+func (p *apiBindGroupLayout) Release() { p.bindGroupLayout.Release() }
+
+// API returns p as an api.BindGroup.
+func (p *BindGroup) API() api.BindGroup {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiBindGroup{p}
+}
+
+type apiBindGroup struct{ bindGroup *BindGroup }
+
+func (p *apiBindGroup) native() *BindGroup { return p.bindGroup }
+
+func (p *apiBindGroup) Release() { p.bindGroup.Release() }
+
+// API returns p as an api.PipelineLayout.
+func (p *PipelineLayout) API() api.PipelineLayout {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiPipelineLayout{p}
+}
+
+type apiPipelineLayout struct{ pipelineLayout *PipelineLayout }
+
+func (p *apiPipelineLayout) native() *PipelineLayout { return p.pipelineLayout }
+
+func (p *apiPipelineLayout) Release() { p.pipelineLayout.Release() }
+
+// API returns p as an api.ComputePipeline.
+func (p *ComputePipeline) API() api.ComputePipeline {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiComputePipeline{p}
+}
+
+type apiComputePipeline struct{ pipeline *ComputePipeline }
+
+func (p *apiComputePipeline) native() *ComputePipeline { return p.pipeline }
+
+func (p *apiComputePipeline) GetBindGroupLayout(groupIndex uint32) api.BindGroupLayout {
+	return p.pipeline.GetBindGroupLayout(groupIndex).API()
+}
+
+func (p *apiComputePipeline) Release() { p.pipeline.Release() }
+
+// API returns p as an api.RenderPipeline.
+func (p *RenderPipeline) API() api.RenderPipeline {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiRenderPipeline{p}
+}
+
+type apiRenderPipeline struct{ pipeline *RenderPipeline }
+
+func (p *apiRenderPipeline) native() *RenderPipeline { return p.pipeline }
+
+func (p *apiRenderPipeline) GetBindGroupLayout(groupIndex uint32) api.BindGroupLayout {
+	return p.pipeline.GetBindGroupLayout(groupIndex).API()
+}
+
+func (p *apiRenderPipeline) Release() { p.pipeline.Release() }
+
+// API returns p as an api.CommandBuffer.
+func (p *CommandBuffer) API() api.CommandBuffer {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiCommandBuffer{p}
+}
+
+type apiCommandBuffer struct{ commandBuffer *CommandBuffer }
+
+func (p *apiCommandBuffer) native() *CommandBuffer { return p.commandBuffer }
+
+func (p *apiCommandBuffer) Release() { p.commandBuffer.Release() }
+
+// API returns p as an api.RenderBundle.
+func (p *RenderBundle) API() api.RenderBundle {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiRenderBundle{p}
+}
+
+type apiRenderBundle struct{ bundle *RenderBundle }
+
+func (p *apiRenderBundle) native() *RenderBundle { return p.bundle }
+
+func (p *apiRenderBundle) Release() { p.bundle.Release() }
+
+// API returns p as an api.CommandEncoder.
+func (p *CommandEncoder) API() api.CommandEncoder {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiCommandEncoder{p}
+}
+
+type apiCommandEncoder struct{ encoder *CommandEncoder }
+
+func (p *apiCommandEncoder) native() *CommandEncoder { return p.encoder }
+
+func (p *apiCommandEncoder) BeginComputePass(descriptor *api.ComputePassDescriptor) api.ComputePassEncoder {
+	var desc *ComputePassDescriptor
+	if descriptor != nil {
+		desc = &ComputePassDescriptor{Label: descriptor.Label}
+	}
+	return p.encoder.BeginComputePass(desc).API()
+}
+
+func (p *apiCommandEncoder) BeginRenderPass(descriptor *api.RenderPassDescriptor) api.RenderPassEncoder {
+	var desc *RenderPassDescriptor
+	if descriptor != nil {
+		desc = &RenderPassDescriptor{Label: descriptor.Label}
+		for _, v := range descriptor.ColorAttachments {
+			desc.ColorAttachments = append(desc.ColorAttachments, RenderPassColorAttachment{
+				View:          fromAPI[TextureView](v.View, "TextureView"),
+				ResolveTarget: fromAPI[TextureView](v.ResolveTarget, "TextureView"),
+				LoadOp:        LoadOp(v.LoadOp),
+				StoreOp:       StoreOp(v.StoreOp),
+				ClearValue:    Color(v.ClearValue),
+			})
+		}
+		if v := descriptor.DepthStencilAttachment; v != nil {
+			desc.DepthStencilAttachment = &RenderPassDepthStencilAttachment{
+				View:              fromAPI[TextureView](v.View, "TextureView"),
+				DepthLoadOp:       LoadOp(v.DepthLoadOp),
+				DepthStoreOp:      StoreOp(v.DepthStoreOp),
+				DepthClearValue:   v.DepthClearValue,
+				DepthReadOnly:     v.DepthReadOnly,
+				StencilLoadOp:     LoadOp(v.StencilLoadOp),
+				StencilStoreOp:    StoreOp(v.StencilStoreOp),
+				StencilClearValue: v.StencilClearValue,
+				StencilReadOnly:   v.StencilReadOnly,
+			}
+		}
+	}
+	return p.encoder.BeginRenderPass(desc).API()
+}
+
+func (p *apiCommandEncoder) ClearBuffer(buffer api.Buffer, offset uint64, size uint64) error {
+	return p.encoder.ClearBuffer(fromAPI[Buffer](buffer, "Buffer"), offset, size)
+}
+
+func (p *apiCommandEncoder) CopyBufferToBuffer(source api.Buffer, sourceOffset uint64, destination api.Buffer, destinationOffset uint64, size uint64) error {
+	return p.encoder.CopyBufferToBuffer(fromAPI[Buffer](source, "Buffer"), sourceOffset, fromAPI[Buffer](destination, "Buffer"), destinationOffset, size)
+}
+
+func (p *apiCommandEncoder) CopyBufferToTexture(source *api.ImageCopyBuffer, destination *api.ImageCopyTexture, copySize *api.Extent3D) error {
+	return p.encoder.CopyBufferToTexture(imageCopyBufferFromAPI(source), imageCopyTextureFromAPI(destination), (*Extent3D)(copySize))
+}
+
+func (p *apiCommandEncoder) CopyTextureToBuffer(source *api.ImageCopyTexture, destination *api.ImageCopyBuffer, copySize *api.Extent3D) error {
+	return p.encoder.CopyTextureToBuffer(imageCopyTextureFromAPI(source), imageCopyBufferFromAPI(destination), (*Extent3D)(copySize))
+}
+
+func (p *apiCommandEncoder) CopyTextureToTexture(source *api.ImageCopyTexture, destination *api.ImageCopyTexture, copySize *api.Extent3D) error {
+	return p.encoder.CopyTextureToTexture(imageCopyTextureFromAPI(source), imageCopyTextureFromAPI(destination), (*Extent3D)(copySize))
+}
+
+func (p *apiCommandEncoder) Finish(descriptor *api.CommandBufferDescriptor) (api.CommandBuffer, error) {
+	var desc *CommandBufferDescriptor
+	if descriptor != nil {
+		desc = &CommandBufferDescriptor{Label: descriptor.Label}
+	}
+	commandBuffer, err := p.encoder.Finish(desc)
+	if err != nil {
+		return nil, err
+	}
+	return commandBuffer.API(), nil
+}
+
+func (p *apiCommandEncoder) InsertDebugMarker(markerLabel string) error {
+	return p.encoder.InsertDebugMarker(markerLabel)
+}
+
+func (p *apiCommandEncoder) PopDebugGroup() error { return p.encoder.PopDebugGroup() }
+
+func (p *apiCommandEncoder) PushDebugGroup(groupLabel string) error {
+	return p.encoder.PushDebugGroup(groupLabel)
+}
+
+func (p *apiCommandEncoder) ResolveQuerySet(querySet api.QuerySet, firstQuery uint32, queryCount uint32, destination api.Buffer, destinationOffset uint64) error {
+	return p.encoder.ResolveQuerySet(fromAPI[QuerySet](querySet, "QuerySet"), firstQuery, queryCount, fromAPI[Buffer](destination, "Buffer"), destinationOffset)
+}
+
+func (p *apiCommandEncoder) WriteTimestamp(querySet api.QuerySet, queryIndex uint32) error {
+	return p.encoder.WriteTimestamp(fromAPI[QuerySet](querySet, "QuerySet"), queryIndex)
+}
+
+func (p *apiCommandEncoder) Release() { p.encoder.Release() }
+
+// API returns p as an api.ComputePassEncoder.
+func (p *ComputePassEncoder) API() api.ComputePassEncoder {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiComputePassEncoder{p}
+}
+
+type apiComputePassEncoder struct{ pass *ComputePassEncoder }
+
+func (p *apiComputePassEncoder) native() *ComputePassEncoder { return p.pass }
+
+func (p *apiComputePassEncoder) BeginPipelineStatisticsQuery(querySet api.QuerySet, queryIndex uint32) {
+	p.pass.BeginPipelineStatisticsQuery(fromAPI[QuerySet](querySet, "QuerySet"), queryIndex)
+}
+
+func (p *apiComputePassEncoder) DispatchWorkgroups(workgroupCountX, workgroupCountY, workgroupCountZ uint32) {
+	p.pass.DispatchWorkgroups(workgroupCountX, workgroupCountY, workgroupCountZ)
+}
+
+func (p *apiComputePassEncoder) DispatchWorkgroupsIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.pass.DispatchWorkgroupsIndirect(fromAPI[Buffer](indirectBuffer, "Buffer"), indirectOffset)
+}
+
+func (p *apiComputePassEncoder) End() error { return p.pass.End() }
+
+func (p *apiComputePassEncoder) EndPipelineStatisticsQuery() { p.pass.EndPipelineStatisticsQuery() }
+
+func (p *apiComputePassEncoder) InsertDebugMarker(markerLabel string) {
+	p.pass.InsertDebugMarker(markerLabel)
+}
+
+func (p *apiComputePassEncoder) PopDebugGroup() { p.pass.PopDebugGroup() }
+
+func (p *apiComputePassEncoder) PushDebugGroup(groupLabel string) { p.pass.PushDebugGroup(groupLabel) }
+
+func (p *apiComputePassEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	p.pass.SetBindGroup(groupIndex, fromAPI[BindGroup](group, "BindGroup"), dynamicOffsets)
+}
+
+func (p *apiComputePassEncoder) SetPipeline(pipeline api.ComputePipeline) {
+	p.pass.SetPipeline(fromAPI[ComputePipeline](pipeline, "ComputePipeline"))
+}
+
+func (p *apiComputePassEncoder) Release() { p.pass.Release() }
+
+// API returns p as an api.RenderPassEncoder.
+func (p *RenderPassEncoder) API() api.RenderPassEncoder {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiRenderPassEncoder{p}
+}
+
+type apiRenderPassEncoder struct{ pass *RenderPassEncoder }
+
+func (p *apiRenderPassEncoder) native() *RenderPassEncoder { return p.pass }
+
+func (p *apiRenderPassEncoder) BeginOcclusionQuery(queryIndex uint32) {
+	p.pass.BeginOcclusionQuery(queryIndex)
+}
+
+func (p *apiRenderPassEncoder) BeginPipelineStatisticsQuery(querySet api.QuerySet, queryIndex uint32) {
+	p.pass.BeginPipelineStatisticsQuery(fromAPI[QuerySet](querySet, "QuerySet"), queryIndex)
+}
+
+func (p *apiRenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	p.pass.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+func (p *apiRenderPassEncoder) DrawIndexed(indexCount uint32, instanceCount uint32, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	p.pass.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+func (p *apiRenderPassEncoder) DrawIndexedIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.pass.DrawIndexedIndirect(fromAPI[Buffer](indirectBuffer, "Buffer"), indirectOffset)
+}
+
+func (p *apiRenderPassEncoder) DrawIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.pass.DrawIndirect(fromAPI[Buffer](indirectBuffer, "Buffer"), indirectOffset)
+}
+
+func (p *apiRenderPassEncoder) End() error { return p.pass.End() }
+
+func (p *apiRenderPassEncoder) EndOcclusionQuery() { p.pass.EndOcclusionQuery() }
+
+func (p *apiRenderPassEncoder) EndPipelineStatisticsQuery() { p.pass.EndPipelineStatisticsQuery() }
+
+func (p *apiRenderPassEncoder) ExecuteBundles(bundles ...api.RenderBundle) {
+	b := make([]*RenderBundle, len(bundles))
+	for i, v := range bundles {
+		b[i] = fromAPI[RenderBundle](v, "RenderBundle")
+	}
+	p.pass.ExecuteBundles(b...)
+}
+
+func (p *apiRenderPassEncoder) InsertDebugMarker(markerLabel string) {
+	p.pass.InsertDebugMarker(markerLabel)
+}
+
+func (p *apiRenderPassEncoder) PopDebugGroup() { p.pass.PopDebugGroup() }
+
+func (p *apiRenderPassEncoder) PushDebugGroup(groupLabel string) { p.pass.PushDebugGroup(groupLabel) }
+
+func (p *apiRenderPassEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	p.pass.SetBindGroup(groupIndex, fromAPI[BindGroup](group, "BindGroup"), dynamicOffsets)
+}
+
+func (p *apiRenderPassEncoder) SetBlendConstant(color *api.Color) {
+	p.pass.SetBlendConstant((*Color)(color))
+}
+
+func (p *apiRenderPassEncoder) SetIndexBuffer(buffer api.Buffer, format api.IndexFormat, offset uint64, size uint64) {
+	p.pass.SetIndexBuffer(fromAPI[Buffer](buffer, "Buffer"), IndexFormat(format), offset, size)
+}
+
+func (p *apiRenderPassEncoder) SetPipeline(pipeline api.RenderPipeline) {
+	p.pass.SetPipeline(fromAPI[RenderPipeline](pipeline, "RenderPipeline"))
+}
+
+func (p *apiRenderPassEncoder) SetPushConstants(stages api.ShaderStage, offset uint32, data []byte) {
+	p.pass.SetPushConstants(ShaderStage(stages), offset, data)
+}
+
+func (p *apiRenderPassEncoder) SetScissorRect(x, y, width, height uint32) {
+	p.pass.SetScissorRect(x, y, width, height)
+}
+
+func (p *apiRenderPassEncoder) SetStencilReference(reference uint32) {
+	p.pass.SetStencilReference(reference)
+}
+
+func (p *apiRenderPassEncoder) SetVertexBuffer(slot uint32, buffer api.Buffer, offset uint64, size uint64) {
+	p.pass.SetVertexBuffer(slot, fromAPI[Buffer](buffer, "Buffer"), offset, size)
+}
+
+func (p *apiRenderPassEncoder) SetViewport(x, y, width, height, minDepth, maxDepth float32) {
+	p.pass.SetViewport(x, y, width, height, minDepth, maxDepth)
+}
+
+func (p *apiRenderPassEncoder) Release() { p.pass.Release() }
+
+// API returns p as an api.RenderBundleEncoder.
+func (p *RenderBundleEncoder) API() api.RenderBundleEncoder {
+	if p == nil {
+		return nil
+	}
+	p.checkReleased()
+	return &apiRenderBundleEncoder{p}
+}
+
+type apiRenderBundleEncoder struct{ encoder *RenderBundleEncoder }
+
+func (p *apiRenderBundleEncoder) native() *RenderBundleEncoder { return p.encoder }
+
+func (p *apiRenderBundleEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	p.encoder.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+func (p *apiRenderBundleEncoder) DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance uint32) {
+	p.encoder.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+func (p *apiRenderBundleEncoder) DrawIndexedIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.encoder.DrawIndexedIndirect(fromAPI[Buffer](indirectBuffer, "Buffer"), indirectOffset)
+}
+
+func (p *apiRenderBundleEncoder) DrawIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.encoder.DrawIndirect(fromAPI[Buffer](indirectBuffer, "Buffer"), indirectOffset)
+}
+
+func (p *apiRenderBundleEncoder) Finish(descriptor *api.RenderBundleDescriptor) api.RenderBundle {
+	var desc *RenderBundleDescriptor
+	if descriptor != nil {
+		desc = &RenderBundleDescriptor{Label: descriptor.Label}
+	}
+	return p.encoder.Finish(desc).API()
+}
+
+func (p *apiRenderBundleEncoder) InsertDebugMarker(markerLabel string) {
+	p.encoder.InsertDebugMarker(markerLabel)
+}
+
+func (p *apiRenderBundleEncoder) PopDebugGroup() { p.encoder.PopDebugGroup() }
+
+func (p *apiRenderBundleEncoder) PushDebugGroup(groupLabel string) {
+	p.encoder.PushDebugGroup(groupLabel)
+}
+
+func (p *apiRenderBundleEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	p.encoder.SetBindGroup(groupIndex, fromAPI[BindGroup](group, "BindGroup"), dynamicOffsets)
+}
+
+func (p *apiRenderBundleEncoder) SetIndexBuffer(buffer api.Buffer, format api.IndexFormat, offset uint64, size uint64) {
+	p.encoder.SetIndexBuffer(fromAPI[Buffer](buffer, "Buffer"), IndexFormat(format), offset, size)
+}
+
+func (p *apiRenderBundleEncoder) SetPipeline(pipeline api.RenderPipeline) {
+	p.encoder.SetPipeline(fromAPI[RenderPipeline](pipeline, "RenderPipeline"))
+}
+
+func (p *apiRenderBundleEncoder) SetVertexBuffer(slot uint32, buffer api.Buffer, offset uint64, size uint64) {
+	p.encoder.SetVertexBuffer(slot, fromAPI[Buffer](buffer, "Buffer"), offset, size)
+}
+
+func (p *apiRenderBundleEncoder) Release() { p.encoder.Release() }
+
+var (
+	_ api.Instance            = (*apiInstance)(nil)
+	_ api.Adapter             = (*apiAdapter)(nil)
+	_ api.Device              = (*apiDevice)(nil)
+	_ api.Queue               = (*apiQueue)(nil)
+	_ api.Buffer              = (*apiBuffer)(nil)
+	_ api.Texture             = (*apiTexture)(nil)
+	_ api.CommandEncoder      = (*apiCommandEncoder)(nil)
+	_ api.ComputePassEncoder  = (*apiComputePassEncoder)(nil)
+	_ api.RenderPassEncoder   = (*apiRenderPassEncoder)(nil)
+	_ api.RenderBundleEncoder = (*apiRenderBundleEncoder)(nil)
+	_ api.ComputePipeline     = (*apiComputePipeline)(nil)
+	_ api.RenderPipeline      = (*apiRenderPipeline)(nil)
+)
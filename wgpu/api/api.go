@@ -0,0 +1,218 @@
+// Package api defines interfaces mirroring the wgpu API.
+//
+// Code written against these interfaces can run on the native
+// implementation (see the API methods in package wgpu) or on an in-memory
+// one, like package fake. The package itself has no cgo dependency.
+//
+// Handles passed to an implementation must have been created by that same
+// implementation. Passing another implementation's handle, e.g. a
+// fake.Buffer to a device of package wgpu, is a programming error: the
+// method panics with a *ForeignHandleError.
+package api
+
+// The enums are generated from the same header as package wgpu's, so that
+// values convert between the two.
+//go:generate go run github.com/rajveermalviya/go-webgpu/cmd/gen_enums -i ../lib/wgpu.h -o enums.go -pkg api
+
+// ForeignHandleError is the panic value when a handle created by another
+// implementation is passed to a method.
+type ForeignHandleError struct {
+	// Type is the name of the handle type, e.g. "Buffer".
+	Type string
+	// Package is the name of the implementation the method belongs to.
+	Package string
+}
+
+func (v *ForeignHandleError) Error() string {
+	return v.Package + ": api." + v.Type + " was not created by package " + v.Package
+}
+
+type Instance interface {
+	RequestAdapter(options *RequestAdapterOptions) (Adapter, error)
+	Release()
+}
+
+type Adapter interface {
+	EnumerateFeatures() []FeatureName
+	GetLimits() SupportedLimits
+	GetProperties() AdapterProperties
+	HasFeature(feature FeatureName) bool
+	RequestDevice(descriptor *DeviceDescriptor) (Device, error)
+	Release()
+}
+
+type Device interface {
+	CreateBindGroup(descriptor *BindGroupDescriptor) (BindGroup, error)
+	CreateBindGroupLayout(descriptor *BindGroupLayoutDescriptor) (BindGroupLayout, error)
+	CreateBuffer(descriptor *BufferDescriptor) (Buffer, error)
+	CreateBufferInit(descriptor *BufferInitDescriptor) (Buffer, error)
+	CreateCommandEncoder(descriptor *CommandEncoderDescriptor) (CommandEncoder, error)
+	CreateComputePipeline(descriptor *ComputePipelineDescriptor) (ComputePipeline, error)
+	CreatePipelineLayout(descriptor *PipelineLayoutDescriptor) (PipelineLayout, error)
+	CreateQuerySet(descriptor *QuerySetDescriptor) (QuerySet, error)
+	CreateRenderBundleEncoder(descriptor *RenderBundleEncoderDescriptor) (RenderBundleEncoder, error)
+	CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (RenderPipeline, error)
+	CreateSampler(descriptor *SamplerDescriptor) (Sampler, error)
+	CreateShaderModule(descriptor *ShaderModuleDescriptor) (ShaderModule, error)
+	CreateTexture(descriptor *TextureDescriptor) (Texture, error)
+	EnumerateFeatures() []FeatureName
+	GetLimits() SupportedLimits
+	GetQueue() Queue
+	HasFeature(feature FeatureName) bool
+	Poll(wait bool, wrappedSubmissionIndex *WrappedSubmissionIndex) (queueEmpty bool)
+	PushErrorScope(filter ErrorFilter)
+	PopErrorScope() error
+	SetUncapturedErrorCallback(callback func(err error))
+	Release()
+}
+
+type Queue interface {
+	OnSubmittedWorkDone(callback QueueWorkDoneCallback)
+	Submit(commands ...CommandBuffer) SubmissionIndex
+	WriteBuffer(buffer Buffer, bufferOffset uint64, data []byte) error
+	WriteTexture(destination *ImageCopyTexture, data []byte, dataLayout *TextureDataLayout, writeSize *Extent3D) error
+	Release()
+}
+
+type Buffer interface {
+	Destroy()
+	GetMappedRange(offset, size uint) []byte
+	GetSize() uint64
+	GetUsage() BufferUsage
+	MapAsync(mode MapMode, offset uint64, size uint64, callback BufferMapCallback) error
+	Unmap() error
+	Release()
+}
+
+type Texture interface {
+	CreateView(descriptor *TextureViewDescriptor) (TextureView, error)
+	Destroy()
+	GetDepthOrArrayLayers() uint32
+	GetDimension() TextureDimension
+	GetFormat() TextureFormat
+	GetHeight() uint32
+	GetMipLevelCount() uint32
+	GetSampleCount() uint32
+	GetUsage() TextureUsage
+	GetWidth() uint32
+	Release()
+}
+
+type TextureView interface {
+	Release()
+}
+
+type Sampler interface {
+	Release()
+}
+
+type QuerySet interface {
+	Release()
+}
+
+type ShaderModule interface {
+	Release()
+}
+
+type BindGroupLayout interface {
+	Release()
+}
+
+type BindGroup interface {
+	Release()
+}
+
+type PipelineLayout interface {
+	Release()
+}
+
+type ComputePipeline interface {
+	GetBindGroupLayout(groupIndex uint32) BindGroupLayout
+	Release()
+}
+
+type RenderPipeline interface {
+	GetBindGroupLayout(groupIndex uint32) BindGroupLayout
+	Release()
+}
+
+type CommandBuffer interface {
+	Release()
+}
+
+type RenderBundle interface {
+	Release()
+}
+
+type CommandEncoder interface {
+	BeginComputePass(descriptor *ComputePassDescriptor) ComputePassEncoder
+	BeginRenderPass(descriptor *RenderPassDescriptor) RenderPassEncoder
+	ClearBuffer(buffer Buffer, offset uint64, size uint64) error
+	CopyBufferToBuffer(source Buffer, sourceOffset uint64, destination Buffer, destinationOffset uint64, size uint64) error
+	CopyBufferToTexture(source *ImageCopyBuffer, destination *ImageCopyTexture, copySize *Extent3D) error
+	CopyTextureToBuffer(source *ImageCopyTexture, destination *ImageCopyBuffer, copySize *Extent3D) error
+	CopyTextureToTexture(source *ImageCopyTexture, destination *ImageCopyTexture, copySize *Extent3D) error
+	Finish(descriptor *CommandBufferDescriptor) (CommandBuffer, error)
+	InsertDebugMarker(markerLabel string) error
+	PopDebugGroup() error
+	PushDebugGroup(groupLabel string) error
+	ResolveQuerySet(querySet QuerySet, firstQuery uint32, queryCount uint32, destination Buffer, destinationOffset uint64) error
+	WriteTimestamp(querySet QuerySet, queryIndex uint32) error
+	Release()
+}
+
+type ComputePassEncoder interface {
+	BeginPipelineStatisticsQuery(querySet QuerySet, queryIndex uint32)
+	DispatchWorkgroups(workgroupCountX, workgroupCountY, workgroupCountZ uint32)
+	DispatchWorkgroupsIndirect(indirectBuffer Buffer, indirectOffset uint64)
+	End() error
+	EndPipelineStatisticsQuery()
+	InsertDebugMarker(markerLabel string)
+	PopDebugGroup()
+	PushDebugGroup(groupLabel string)
+	SetBindGroup(groupIndex uint32, group BindGroup, dynamicOffsets []uint32)
+	SetPipeline(pipeline ComputePipeline)
+	Release()
+}
+
+type RenderPassEncoder interface {
+	BeginOcclusionQuery(queryIndex uint32)
+	BeginPipelineStatisticsQuery(querySet QuerySet, queryIndex uint32)
+	Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32)
+	DrawIndexed(indexCount uint32, instanceCount uint32, firstIndex uint32, baseVertex int32, firstInstance uint32)
+	DrawIndexedIndirect(indirectBuffer Buffer, indirectOffset uint64)
+	DrawIndirect(indirectBuffer Buffer, indirectOffset uint64)
+	End() error
+	EndOcclusionQuery()
+	EndPipelineStatisticsQuery()
+	ExecuteBundles(bundles ...RenderBundle)
+	InsertDebugMarker(markerLabel string)
+	PopDebugGroup()
+	PushDebugGroup(groupLabel string)
+	SetBindGroup(groupIndex uint32, group BindGroup, dynamicOffsets []uint32)
+	SetBlendConstant(color *Color)
+	SetIndexBuffer(buffer Buffer, format IndexFormat, offset uint64, size uint64)
+	SetPipeline(pipeline RenderPipeline)
+	SetPushConstants(stages ShaderStage, offset uint32, data []byte)
+	SetScissorRect(x, y, width, height uint32)
+	SetStencilReference(reference uint32)
+	SetVertexBuffer(slot uint32, buffer Buffer, offset uint64, size uint64)
+	SetViewport(x, y, width, height, minDepth, maxDepth float32)
+	Release()
+}
+
+type RenderBundleEncoder interface {
+	Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32)
+	DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance uint32)
+	DrawIndexedIndirect(indirectBuffer Buffer, indirectOffset uint64)
+	DrawIndirect(indirectBuffer Buffer, indirectOffset uint64)
+	Finish(descriptor *RenderBundleDescriptor) RenderBundle
+	InsertDebugMarker(markerLabel string)
+	PopDebugGroup()
+	PushDebugGroup(groupLabel string)
+	SetBindGroup(groupIndex uint32, group BindGroup, dynamicOffsets []uint32)
+	SetIndexBuffer(buffer Buffer, format IndexFormat, offset uint64, size uint64)
+	SetPipeline(pipeline RenderPipeline)
+	SetVertexBuffer(slot uint32, buffer Buffer, offset uint64, size uint64)
+	Release()
+}
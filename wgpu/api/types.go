@@ -0,0 +1,428 @@
+package api
+
+const (
+	ArrayLayerCountUndefined        = 0xffffffff
+	CopyStrideUndefined             = 0xffffffff
+	LimitU32Undefined        uint32 = 0xffffffff
+	LimitU64Undefined        uint64 = 0xffffffffffffffff
+	MipLevelCountUndefined          = 0xffffffff
+	WholeMapSize                    = ^uint(0)
+	WholeSize                       = 0xffffffffffffffff
+)
+
+const (
+	// Buffer-Texture copies must have `TextureDataLayout.BytesPerRow` aligned to this number.
+	//
+	// This doesn't apply to `Queue.WriteTexture()`.
+	CopyBytesPerRowAlignment = 256
+	// An offset into the query resolve buffer has to be aligned to this.
+	QueryResolveBufferAlignment = 256
+	// Buffer to buffer copy as well as buffer clear offsets and sizes must be aligned to this number.
+	CopyBufferAlignment = 4
+	// Size to align mappings.
+	MapAlignment = 8
+	// Vertex buffer strides have to be aligned to this number.
+	VertexStrideAlignment = 4
+	// Alignment all push constants need
+	PushConstantAlignment = 4
+	// Maximum queries in a query set
+	QuerySetMaxQueries = 8192
+	// Size of a single piece of query data.
+	QuerySize = 8
+)
+
+type SubmissionIndex uint64
+
+type (
+	BufferMapCallback     func(BufferMapAsyncStatus)
+	QueueWorkDoneCallback func(QueueWorkDoneStatus)
+)
+
+type Limits struct {
+	MaxTextureDimension1D                     uint32
+	MaxTextureDimension2D                     uint32
+	MaxTextureDimension3D                     uint32
+	MaxTextureArrayLayers                     uint32
+	MaxBindGroups                             uint32
+	MaxBindingsPerBindGroup                   uint32
+	MaxDynamicUniformBuffersPerPipelineLayout uint32
+	MaxDynamicStorageBuffersPerPipelineLayout uint32
+	MaxSampledTexturesPerShaderStage          uint32
+	MaxSamplersPerShaderStage                 uint32
+	MaxStorageBuffersPerShaderStage           uint32
+	MaxStorageTexturesPerShaderStage          uint32
+	MaxUniformBuffersPerShaderStage           uint32
+	MaxUniformBufferBindingSize               uint64
+	MaxStorageBufferBindingSize               uint64
+	MinUniformBufferOffsetAlignment           uint32
+	MinStorageBufferOffsetAlignment           uint32
+	MaxVertexBuffers                          uint32
+	MaxBufferSize                             uint64
+	MaxVertexAttributes                       uint32
+	MaxVertexBufferArrayStride                uint32
+	MaxInterStageShaderComponents             uint32
+	MaxInterStageShaderVariables              uint32
+	MaxColorAttachments                       uint32
+	MaxColorAttachmentBytesPerSample          uint32
+	MaxComputeWorkgroupStorageSize            uint32
+	MaxComputeInvocationsPerWorkgroup         uint32
+	MaxComputeWorkgroupSizeX                  uint32
+	MaxComputeWorkgroupSizeY                  uint32
+	MaxComputeWorkgroupSizeZ                  uint32
+	MaxComputeWorkgroupsPerDimension          uint32
+
+	MaxPushConstantSize uint32
+}
+
+type SupportedLimits struct {
+	Limits Limits
+}
+
+type RequiredLimits struct {
+	Limits Limits
+}
+
+type AdapterProperties struct {
+	VendorId          uint32
+	VendorName        string
+	Architecture      string
+	DeviceId          uint32
+	Name              string
+	DriverDescription string
+	AdapterType       AdapterType
+	BackendType       BackendType
+}
+
+type RequestAdapterOptions struct {
+	PowerPreference      PowerPreference
+	ForceFallbackAdapter bool
+	BackendType          BackendType
+}
+
+type DeviceDescriptor struct {
+	Label            string
+	RequiredFeatures []FeatureName
+	RequiredLimits   *RequiredLimits
+}
+
+type Color struct {
+	R, G, B, A float64
+}
+
+type Origin3D struct {
+	X, Y, Z uint32
+}
+
+type Extent3D struct {
+	Width              uint32
+	Height             uint32
+	DepthOrArrayLayers uint32
+}
+
+type TextureDataLayout struct {
+	Offset       uint64
+	BytesPerRow  uint32
+	RowsPerImage uint32
+}
+
+type ImageCopyTexture struct {
+	Texture  Texture
+	MipLevel uint32
+	Origin   Origin3D
+	Aspect   TextureAspect
+}
+
+type ImageCopyBuffer struct {
+	Layout TextureDataLayout
+	Buffer Buffer
+}
+
+type WrappedSubmissionIndex struct {
+	Queue           Queue
+	SubmissionIndex SubmissionIndex
+}
+
+type BufferDescriptor struct {
+	Label            string
+	Usage            BufferUsage
+	Size             uint64
+	MappedAtCreation bool
+}
+
+type BufferInitDescriptor struct {
+	Label    string
+	Contents []byte
+	Usage    BufferUsage
+}
+
+type TextureDescriptor struct {
+	Label         string
+	Usage         TextureUsage
+	Dimension     TextureDimension
+	Size          Extent3D
+	Format        TextureFormat
+	MipLevelCount uint32
+	SampleCount   uint32
+}
+
+type TextureViewDescriptor struct {
+	Label           string
+	Format          TextureFormat
+	Dimension       TextureViewDimension
+	BaseMipLevel    uint32
+	MipLevelCount   uint32
+	BaseArrayLayer  uint32
+	ArrayLayerCount uint32
+	Aspect          TextureAspect
+}
+
+type SamplerDescriptor struct {
+	Label          string
+	AddressModeU   AddressMode
+	AddressModeV   AddressMode
+	AddressModeW   AddressMode
+	MagFilter      FilterMode
+	MinFilter      FilterMode
+	MipmapFilter   MipmapFilterMode
+	LodMinClamp    float32
+	LodMaxClamp    float32
+	Compare        CompareFunction
+	MaxAnisotrophy uint16
+}
+
+type QuerySetDescriptor struct {
+	Label              string
+	Type               QueryType
+	Count              uint32
+	PipelineStatistics []PipelineStatisticName
+}
+
+type ShaderModuleSPIRVDescriptor struct {
+	Code []byte
+}
+
+type ShaderModuleWGSLDescriptor struct {
+	Code string
+}
+
+type ShaderModuleGLSLDescriptor struct {
+	Code        string
+	Defines     map[string]string
+	ShaderStage ShaderStage
+}
+
+type ShaderModuleDescriptor struct {
+	Label           string
+	SPIRVDescriptor *ShaderModuleSPIRVDescriptor
+	WGSLDescriptor  *ShaderModuleWGSLDescriptor
+	GLSLDescriptor  *ShaderModuleGLSLDescriptor
+}
+
+type BufferBindingLayout struct {
+	Type             BufferBindingType
+	HasDynamicOffset bool
+	MinBindingSize   uint64
+}
+
+type SamplerBindingLayout struct {
+	Type SamplerBindingType
+}
+
+type TextureBindingLayout struct {
+	SampleType    TextureSampleType
+	ViewDimension TextureViewDimension
+	Multisampled  bool
+}
+
+type StorageTextureBindingLayout struct {
+	Access        StorageTextureAccess
+	Format        TextureFormat
+	ViewDimension TextureViewDimension
+}
+
+type BindGroupLayoutEntry struct {
+	Binding        uint32
+	Visibility     ShaderStage
+	Buffer         BufferBindingLayout
+	Sampler        SamplerBindingLayout
+	Texture        TextureBindingLayout
+	StorageTexture StorageTextureBindingLayout
+}
+
+type BindGroupLayoutDescriptor struct {
+	Label   string
+	Entries []BindGroupLayoutEntry
+}
+
+type BindGroupEntry struct {
+	Binding     uint32
+	Buffer      Buffer
+	Offset      uint64
+	Size        uint64
+	Sampler     Sampler
+	TextureView TextureView
+}
+
+type BindGroupDescriptor struct {
+	Label   string
+	Layout  BindGroupLayout
+	Entries []BindGroupEntry
+}
+
+type PushConstantRange struct {
+	Stages ShaderStage
+	Start  uint32
+	End    uint32
+}
+
+type PipelineLayoutDescriptor struct {
+	Label              string
+	BindGroupLayouts   []BindGroupLayout
+	PushConstantRanges []PushConstantRange
+}
+
+type ProgrammableStageDescriptor struct {
+	Module     ShaderModule
+	EntryPoint string
+}
+
+type ComputePipelineDescriptor struct {
+	Label   string
+	Layout  PipelineLayout
+	Compute ProgrammableStageDescriptor
+}
+
+type BlendComponent struct {
+	Operation BlendOperation
+	SrcFactor BlendFactor
+	DstFactor BlendFactor
+}
+
+type BlendState struct {
+	Color BlendComponent
+	Alpha BlendComponent
+}
+
+type ColorTargetState struct {
+	Format    TextureFormat
+	Blend     *BlendState
+	WriteMask ColorWriteMask
+}
+
+type FragmentState struct {
+	Module     ShaderModule
+	EntryPoint string
+	Targets    []ColorTargetState
+}
+
+type VertexAttribute struct {
+	Format         VertexFormat
+	Offset         uint64
+	ShaderLocation uint32
+}
+
+type VertexBufferLayout struct {
+	ArrayStride uint64
+	StepMode    VertexStepMode
+	Attributes  []VertexAttribute
+}
+
+type VertexState struct {
+	Module     ShaderModule
+	EntryPoint string
+	Buffers    []VertexBufferLayout
+}
+
+type PrimitiveState struct {
+	Topology         PrimitiveTopology
+	StripIndexFormat IndexFormat
+	FrontFace        FrontFace
+	CullMode         CullMode
+}
+
+type StencilFaceState struct {
+	Compare     CompareFunction
+	FailOp      StencilOperation
+	DepthFailOp StencilOperation
+	PassOp      StencilOperation
+}
+
+type DepthStencilState struct {
+	Format              TextureFormat
+	DepthWriteEnabled   bool
+	DepthCompare        CompareFunction
+	StencilFront        StencilFaceState
+	StencilBack         StencilFaceState
+	StencilReadMask     uint32
+	StencilWriteMask    uint32
+	DepthBias           int32
+	DepthBiasSlopeScale float32
+	DepthBiasClamp      float32
+}
+
+type MultisampleState struct {
+	Count                  uint32
+	Mask                   uint32
+	AlphaToCoverageEnabled bool
+}
+
+type RenderPipelineDescriptor struct {
+	Label        string
+	Layout       PipelineLayout
+	Vertex       VertexState
+	Primitive    PrimitiveState
+	DepthStencil *DepthStencilState
+	Multisample  MultisampleState
+	Fragment     *FragmentState
+}
+
+type CommandEncoderDescriptor struct {
+	Label string
+}
+
+type CommandBufferDescriptor struct {
+	Label string
+}
+
+type ComputePassDescriptor struct {
+	Label string
+}
+
+type RenderPassColorAttachment struct {
+	View          TextureView
+	ResolveTarget TextureView
+	LoadOp        LoadOp
+	StoreOp       StoreOp
+	ClearValue    Color
+}
+
+type RenderPassDepthStencilAttachment struct {
+	View              TextureView
+	DepthLoadOp       LoadOp
+	DepthStoreOp      StoreOp
+	DepthClearValue   float32
+	DepthReadOnly     bool
+	StencilLoadOp     LoadOp
+	StencilStoreOp    StoreOp
+	StencilClearValue uint32
+	StencilReadOnly   bool
+}
+
+type RenderPassDescriptor struct {
+	Label                  string
+	ColorAttachments       []RenderPassColorAttachment
+	DepthStencilAttachment *RenderPassDepthStencilAttachment
+}
+
+type RenderBundleEncoderDescriptor struct {
+	Label              string
+	ColorFormats       []TextureFormat
+	DepthStencilFormat TextureFormat
+	SampleCount        uint32
+	DepthReadOnly      bool
+	StencilReadOnly    bool
+}
+
+type RenderBundleDescriptor struct {
+	Label string
+}
@@ -9,49 +9,63 @@ extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message,
 extern void gowebgpu_buffer_map_callback_c(WGPUBufferMapAsyncStatus status, void *userdata);
 
 static inline void gowebgpu_buffer_map_async(WGPUBuffer buffer, WGPUMapModeFlags mode, size_t offset, size_t size, WGPUBufferMapCallback callback, void * userdata, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuBufferMapAsync(buffer, mode, offset, size, callback, userdata);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_buffer_unmap(WGPUBuffer buffer, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuBufferUnmap(buffer);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
-}
-
-static inline void gowebgpu_buffer_release(WGPUBuffer buffer, WGPUDevice device) {
-	wgpuDeviceRelease(device);
-	wgpuBufferRelease(buffer);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 */
 import "C"
 import (
+	"context"
 	"errors"
 	"runtime/cgo"
 	"unsafe"
 )
 
 type Buffer struct {
-	deviceRef C.WGPUDevice
-	ref       C.WGPUBuffer
+	device *Device
+	ref    C.WGPUBuffer
+	label  string
+	child  *deviceChild
 }
 
 func (p *Buffer) Destroy() {
+	p.checkReleased()
+	p.child.invalidate()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuBufferDestroy(p.ref)
 }
 
 func (p *Buffer) GetMappedRange(offset, size uint) []byte {
+	p.checkReleased()
 	buf := C.wgpuBufferGetMappedRange(p.ref, C.size_t(offset), C.size_t(size))
 	return unsafe.Slice((*byte)(buf), size)
 }
 
 func (p *Buffer) GetSize() uint64 {
+	p.checkReleased()
 	return uint64(C.wgpuBufferGetSize(p.ref))
 }
 
 func (p *Buffer) GetUsage() BufferUsage {
+	p.checkReleased()
 	return BufferUsage(C.wgpuBufferGetUsage(p.ref))
 }
 
@@ -59,52 +73,149 @@ type BufferMapCallback func(BufferMapAsyncStatus)
 
 //export gowebgpu_buffer_map_callback_go
 func gowebgpu_buffer_map_callback_go(status C.WGPUBufferMapAsyncStatus, userdata unsafe.Pointer) {
-	handle := *(*cgo.Handle)(userdata)
-	defer handle.Delete()
-
-	cb, ok := handle.Value().(BufferMapCallback)
+	cb, ok := takeCallbackHandle(userdata).(BufferMapCallback)
 	if ok {
 		cb(BufferMapAsyncStatus(status))
 	}
 }
 
 func (p *Buffer) MapAsync(mode MapMode, offset uint64, size uint64, callback BufferMapCallback) (err error) {
-	callbackHandle := cgo.NewHandle(callback)
+	p.checkReleased()
+	if err := p.device.lostError("wgpu.(*Buffer).MapAsync()", p.label); err != nil {
+		return err
+	}
 
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Buffer).MapAsync(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*Buffer).MapAsync()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Buffer).MapAsync()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_buffer_map_async(
 		p.ref,
 		C.WGPUMapModeFlags(mode),
 		C.size_t(offset),
 		C.size_t(size),
 		(C.WGPUBufferMapCallback)(C.gowebgpu_buffer_map_callback_c),
-		unsafe.Pointer(&callbackHandle),
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		newCallbackHandle(callback),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
+// MapAsyncChan is like MapAsync but sends the status on the returned channel.
+// The device has to be polled for it to arrive, see (*Device).StartPoller.
+func (p *Buffer) MapAsyncChan(mode MapMode, offset uint64, size uint64) <-chan BufferMapAsyncStatus {
+	p.checkReleased()
+	statuses := make(chan BufferMapAsyncStatus, 1)
+
+	err := p.MapAsync(mode, offset, size, func(status BufferMapAsyncStatus) {
+		select {
+		case statuses <- status:
+		default:
+		}
+	})
+	if err != nil {
+		status := BufferMapAsyncStatus_ValidationError
+		if errors.Is(err, ErrDeviceLost) {
+			status = BufferMapAsyncStatus_DeviceLost
+		}
+		select {
+		case statuses <- status:
+		default:
+		}
+	}
+
+	return statuses
+}
+
+// MapAsyncContext maps the buffer and polls the device until the mapping
+// completes or ctx is done. On cancellation the pending mapping is aborted,
+// so its callback fires and nothing is leaked.
+func (p *Buffer) MapAsyncContext(ctx context.Context, mode MapMode, offset uint64, size uint64) error {
+	p.checkReleased()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	statuses := make(chan BufferMapAsyncStatus, 1)
+	err := p.MapAsync(mode, offset, size, func(status BufferMapAsyncStatus) {
+		statuses <- status
+	})
+	if err != nil {
+		return err
+	}
+
+	status, err := pollUntil(ctx, p.device.ref, statuses)
+	if err != nil {
+		// unmapping a pending mapping fires its callback with
+		// BufferMapAsyncStatus_UnmappedBeforeCallback
+		p.Unmap()
+		return err
+	}
+	if status != BufferMapAsyncStatus_Success {
+		return &Error{
+			Type:    status.errorType(),
+			Op:      "wgpu.(*Buffer).MapAsyncContext()",
+			Label:   p.label,
+			Message: status.String(),
+		}
+	}
+	return nil
+}
+
+func (v BufferMapAsyncStatus) errorType() ErrorType {
+	switch v {
+	case BufferMapAsyncStatus_ValidationError,
+		BufferMapAsyncStatus_MappingAlreadyPending,
+		BufferMapAsyncStatus_OffsetOutOfRange,
+		BufferMapAsyncStatus_SizeOutOfRange:
+		return ErrorType_Validation
+	case BufferMapAsyncStatus_DeviceLost:
+		return ErrorType_DeviceLost
+	default:
+		return ErrorType_Unknown
+	}
+}
+
 func (p *Buffer) Unmap() (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Buffer).Unmap(): " + message)
+	p.checkReleased()
+	if err := p.device.lostError("wgpu.(*Buffer).Unmap()", p.label); err != nil {
+		return err
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*Buffer).Unmap()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Buffer).Unmap()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
+	}
+
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_buffer_unmap(
 		p.ref,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *Buffer) Release() {
-	C.gowebgpu_buffer_release(p.ref, p.deviceRef)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
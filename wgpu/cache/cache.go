@@ -0,0 +1,321 @@
+// Package cache shares the pipelines, pipeline layouts, samplers and bind
+// groups created from identical descriptors.
+//
+// Descriptors are hashed with everything they refer to: the objects they
+// hold, like shader modules and bind group layouts, by identity, and
+// everything else, including pipeline constants, by value. Labels are not
+// part of the hash, a shared object keeps the label of the descriptor it was
+// first created from.
+//
+// Objects whose descriptor refers to a buffer, texture view, sampler or bind
+// group layout that is released or destroyed are removed from the cache.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+// Cache creates objects on a device, sharing them between identical
+// descriptors.
+//
+// Objects are kept while they are referenced. Once they aren't, at most
+// capacity of them are kept, the least recently used being released first.
+type Cache struct {
+	device   *wgpu.Device
+	capacity int
+
+	mu      sync.Mutex
+	entries map[key]*entry
+	// unused holds the entries that aren't referenced, most recently used
+	// first.
+	unused list.List
+	stats  Stats
+}
+
+// Stats are the statistics of a cache.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Invalidations is the number of objects removed from the cache because
+	// an object their descriptor refers to was released or destroyed.
+	Invalidations uint64
+	// Entries is the number of objects in the cache, Unused the number of
+	// them that aren't referenced.
+	Entries int
+	Unused  int
+}
+
+type entry struct {
+	key    key
+	refs   int
+	object releaser
+	err    error
+	// ready is closed once the object is created.
+	ready chan struct{}
+	// elem is the element of the entry in unused, if it isn't referenced.
+	elem *list.Element
+	// objects are the objects the descriptor refers to.
+	objects []any
+	// stale is set once one of objects is invalidated, removes unregisters
+	// the callbacks invalidating the entry.
+	stale   bool
+	removes []func()
+}
+
+// invalidator is implemented by the wgpu objects that can be released or
+// destroyed while objects created from them are still cached.
+type invalidator interface {
+	OnInvalidate(callback func()) (remove func())
+}
+
+// New returns a cache creating objects on device, keeping at most capacity
+// unreferenced objects.
+func New(device *wgpu.Device, capacity int) *Cache {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &Cache{
+		device:   device,
+		capacity: capacity,
+		entries:  map[key]*entry{},
+	}
+}
+
+// Ref is a reference to an object of a cache.
+type Ref[T any] struct {
+	Object T
+
+	cache    *Cache
+	entry    *entry
+	released atomic.Bool
+}
+
+// Release drops the reference. The object must not be used after it, unless
+// other references to it are held.
+func (p *Ref[T]) Release() {
+	if p.released.Swap(true) {
+		return
+	}
+	p.cache.release(p.entry)
+}
+
+// RenderPipeline returns a render pipeline created from descriptor.
+func (p *Cache) RenderPipeline(descriptor *wgpu.RenderPipelineDescriptor) (*Ref[*wgpu.RenderPipeline], error) {
+	return get(p, "RenderPipeline", descriptor, func() (*wgpu.RenderPipeline, error) {
+		return p.device.CreateRenderPipeline(descriptor)
+	})
+}
+
+// ComputePipeline returns a compute pipeline created from descriptor.
+func (p *Cache) ComputePipeline(descriptor *wgpu.ComputePipelineDescriptor) (*Ref[*wgpu.ComputePipeline], error) {
+	return get(p, "ComputePipeline", descriptor, func() (*wgpu.ComputePipeline, error) {
+		return p.device.CreateComputePipeline(descriptor)
+	})
+}
+
+// PipelineLayout returns a pipeline layout created from descriptor.
+func (p *Cache) PipelineLayout(descriptor *wgpu.PipelineLayoutDescriptor) (*Ref[*wgpu.PipelineLayout], error) {
+	return get(p, "PipelineLayout", descriptor, func() (*wgpu.PipelineLayout, error) {
+		return p.device.CreatePipelineLayout(descriptor)
+	})
+}
+
+// BindGroup returns a bind group created from descriptor. The bind group is
+// removed from the cache once its layout, or one of the buffers, samplers and
+// texture views it binds, is released or destroyed.
+func (p *Cache) BindGroup(descriptor *wgpu.BindGroupDescriptor) (*Ref[*wgpu.BindGroup], error) {
+	return get(p, "BindGroup", descriptor, func() (*wgpu.BindGroup, error) {
+		return p.device.CreateBindGroup(descriptor)
+	})
+}
+
+// Sampler returns a sampler created from descriptor.
+func (p *Cache) Sampler(descriptor *wgpu.SamplerDescriptor) (*Ref[*wgpu.Sampler], error) {
+	return get(p, "Sampler", descriptor, func() (*wgpu.Sampler, error) {
+		return p.device.CreateSampler(descriptor)
+	})
+}
+
+// get returns a reference to the object of the descriptor of kind, calling
+// create if it isn't cached. Objects that failed to be created aren't cached.
+func get[T releaser](p *Cache, kind string, descriptor any, create func() (T, error)) (*Ref[T], error) {
+	k, objects := hashDescriptor(kind, descriptor)
+
+	for {
+		p.mu.Lock()
+		e, ok := p.entries[k]
+		if !ok {
+			break
+		}
+		p.stats.Hits++
+		e.refs++
+		if e.elem != nil {
+			p.unused.Remove(e.elem)
+			e.elem = nil
+		}
+		p.mu.Unlock()
+
+		<-e.ready
+		if e.err != nil {
+			// the entry was removed when its creation failed.
+			p.mu.Lock()
+			e.refs--
+			p.mu.Unlock()
+			return nil, e.err
+		}
+
+		p.mu.Lock()
+		stale := e.stale
+		p.mu.Unlock()
+		if !stale {
+			return &Ref[T]{Object: e.object.(T), cache: p, entry: e}, nil
+		}
+		// the entry was invalidated while its object was being created.
+		p.release(e)
+	}
+
+	p.stats.Misses++
+	e := &entry{key: k, refs: 1, ready: make(chan struct{}), objects: objects}
+	p.entries[k] = e
+	p.mu.Unlock()
+
+	object, err := create()
+
+	p.mu.Lock()
+	if err != nil {
+		e.err = err
+		e.refs--
+		delete(p.entries, k)
+	} else {
+		e.object = object
+	}
+	close(e.ready)
+	p.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// register the callbacks without holding the lock, as they are called
+	// right away for objects already invalidated.
+	var removes []func()
+	for _, o := range objects {
+		if o, ok := o.(invalidator); ok {
+			removes = append(removes, o.OnInvalidate(func() { p.invalidate(e) }))
+		}
+	}
+	p.mu.Lock()
+	stale := e.stale
+	if !stale {
+		e.removes = removes
+	}
+	p.mu.Unlock()
+	if stale {
+		for _, remove := range removes {
+			remove()
+		}
+	}
+
+	return &Ref[T]{Object: object, cache: p, entry: e}, nil
+}
+
+func (p *Cache) release(e *entry) {
+	p.mu.Lock()
+	e.refs--
+	if e.refs > 0 {
+		p.mu.Unlock()
+		return
+	}
+	var released []*entry
+	if e.stale {
+		released = []*entry{e}
+	} else {
+		e.elem = p.unused.PushFront(e)
+		released = p.evict(p.capacity)
+	}
+	p.mu.Unlock()
+
+	releaseEntries(released)
+}
+
+// invalidate removes the entry from the cache once an object its descriptor
+// refers to is invalidated. Its object is released once it isn't referenced.
+func (p *Cache) invalidate(e *entry) {
+	p.mu.Lock()
+	if e.stale {
+		p.mu.Unlock()
+		return
+	}
+	e.stale = true
+	p.stats.Invalidations++
+	if p.entries[e.key] == e {
+		delete(p.entries, e.key)
+	}
+	var released []*entry
+	if e.elem != nil {
+		p.unused.Remove(e.elem)
+		e.elem = nil
+		released = []*entry{e}
+	}
+	removes := e.removes
+	e.removes = nil
+	p.mu.Unlock()
+
+	for _, remove := range removes {
+		remove()
+	}
+	releaseEntries(released)
+}
+
+// evict removes the least recently used unreferenced entries until at most n
+// of them are left, and returns them. Their objects must be released with
+// releaseEntries once mu is unlocked, as releasing an object invalidates the
+// entries referring to it, which locks mu.
+func (p *Cache) evict(n int) []*entry {
+	var evicted []*entry
+	for p.unused.Len() > n {
+		e := p.unused.Remove(p.unused.Back()).(*entry)
+		e.elem = nil
+		e.stale = true
+		delete(p.entries, e.key)
+		p.stats.Evictions++
+		evicted = append(evicted, e)
+	}
+	return evicted
+}
+
+// releaseEntries releases the objects of the entries removed from the cache.
+func releaseEntries(entries []*entry) {
+	for _, e := range entries {
+		for _, remove := range e.removes {
+			remove()
+		}
+		e.removes = nil
+		e.object.Release()
+	}
+}
+
+// Purge releases the objects that aren't referenced.
+func (p *Cache) Purge() {
+	p.mu.Lock()
+	evicted := p.evict(0)
+	p.mu.Unlock()
+
+	releaseEntries(evicted)
+}
+
+// Stats returns the statistics of the cache.
+func (p *Cache) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Entries = len(p.entries)
+	stats.Unused = p.unused.Len()
+	return stats
+}
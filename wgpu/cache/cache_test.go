@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// object stands in for a wgpu object, invalidating the entries referring to
+// it when released like the objects of a device do.
+type object struct {
+	mu        sync.Mutex
+	released  int
+	callbacks map[int]func()
+	next      int
+}
+
+func (o *object) Release() {
+	o.mu.Lock()
+	o.released++
+	callbacks := o.callbacks
+	o.callbacks = nil
+	o.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+func (o *object) OnInvalidate(callback func()) (remove func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.callbacks == nil {
+		o.callbacks = map[int]func(){}
+	}
+	o.next++
+	id := o.next
+	o.callbacks[id] = callback
+	return func() {
+		o.mu.Lock()
+		delete(o.callbacks, id)
+		o.mu.Unlock()
+	}
+}
+
+func (o *object) releases() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.released
+}
+
+type testDescriptor struct {
+	Label  string
+	Value  uint32
+	Object *object
+}
+
+func getObject(t *testing.T, p *Cache, descriptor *testDescriptor) *Ref[*object] {
+	t.Helper()
+
+	ref, err := get(p, "object", descriptor, func() (*object, error) { return &object{}, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ref
+}
+
+// withTimeout fails t if f doesn't return, e.g. because it deadlocks.
+func withTimeout(t *testing.T, f func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+}
+
+func TestEvictDependent(t *testing.T) {
+	p := New(nil, 1)
+	sampler := getObject(t, p, &testDescriptor{Value: 1})
+	bindGroup := getObject(t, p, &testDescriptor{Object: sampler.Object})
+
+	// the sampler is the least recently used, evicting it invalidates the
+	// bind group.
+	sampler.Release()
+	withTimeout(t, bindGroup.Release)
+
+	if n := sampler.Object.releases(); n != 1 {
+		t.Errorf("sampler released %d times, expected 1", n)
+	}
+	if n := bindGroup.Object.releases(); n != 1 {
+		t.Errorf("bind group released %d times, expected 1", n)
+	}
+	expected := Stats{Misses: 2, Evictions: 1, Invalidations: 1}
+	if stats := p.Stats(); stats != expected {
+		t.Errorf("got %+v, expected %+v", stats, expected)
+	}
+}
+
+func TestPurgeDependent(t *testing.T) {
+	p := New(nil, 8)
+	sampler := getObject(t, p, &testDescriptor{Value: 1})
+	bindGroup := getObject(t, p, &testDescriptor{Object: sampler.Object})
+	sampler.Release()
+
+	// the bind group is referenced, it is only removed from the cache.
+	withTimeout(t, p.Purge)
+	if n := bindGroup.Object.releases(); n != 0 {
+		t.Errorf("referenced bind group released %d times", n)
+	}
+	if again := getObject(t, p, &testDescriptor{Object: sampler.Object}); again.Object == bindGroup.Object {
+		t.Error("got the invalidated bind group")
+	}
+
+	bindGroup.Release()
+	if n := bindGroup.Object.releases(); n != 1 {
+		t.Errorf("bind group released %d times, expected 1", n)
+	}
+}
+
+func TestEvictDependents(t *testing.T) {
+	p := New(nil, 0)
+	sampler := getObject(t, p, &testDescriptor{Value: 1})
+	var bindGroups []*Ref[*object]
+	for i := uint32(0); i < 4; i++ {
+		bindGroups = append(bindGroups, getObject(t, p, &testDescriptor{Value: i, Object: sampler.Object}))
+	}
+	for _, ref := range bindGroups {
+		ref.Release()
+	}
+	withTimeout(t, sampler.Release)
+
+	for i, ref := range append(bindGroups, sampler) {
+		if n := ref.Object.releases(); n != 1 {
+			t.Errorf("object %d released %d times, expected 1", i, n)
+		}
+	}
+	if stats := p.Stats(); stats.Entries != 0 || stats.Evictions != 5 || stats.Invalidations != 0 {
+		t.Errorf("got %+v", stats)
+	}
+}
+
+func TestFailedCreation(t *testing.T) {
+	p := New(nil, 8)
+	descriptor := &testDescriptor{Value: 1}
+
+	failed := errors.New("failed")
+	created := make(chan struct{})
+	proceed := make(chan struct{})
+	errs := make(chan error, 2)
+	go func() {
+		_, err := get(p, "object", descriptor, func() (*object, error) {
+			close(created)
+			<-proceed
+			return nil, failed
+		})
+		errs <- err
+	}()
+	<-created
+	go func() {
+		_, err := get(p, "object", descriptor, func() (*object, error) { return &object{}, nil })
+		errs <- err
+	}()
+	// wait for the second get to find the pending entry.
+	for p.Stats().Hits == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	k, _ := hashDescriptor("object", descriptor)
+	p.mu.Lock()
+	e := p.entries[k]
+	p.mu.Unlock()
+	close(proceed)
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != failed {
+			t.Errorf("got %v, expected %v", err, failed)
+		}
+	}
+	p.mu.Lock()
+	if e.refs != 0 {
+		t.Errorf("failed entry has %d references, expected 0", e.refs)
+	}
+	p.mu.Unlock()
+
+	ref := getObject(t, p, descriptor)
+	ref.Release()
+	if stats := p.Stats(); stats.Entries != 1 || stats.Unused != 1 {
+		t.Errorf("got %+v", stats)
+	}
+}
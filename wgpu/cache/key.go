@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math"
+	"reflect"
+)
+
+// key is the hash of a descriptor graph.
+type key [sha256.Size]byte
+
+// releaser is implemented by the wgpu object types.
+type releaser interface {
+	Release()
+}
+
+var releaserType = reflect.TypeOf((*releaser)(nil)).Elem()
+
+// hasher hashes descriptors, the objects they refer to by identity.
+type hasher struct {
+	h hash.Hash
+	// objects are the objects the descriptor refers to, kept by the entry so
+	// their addresses aren't reused while it is cached.
+	objects []any
+	buf     [8]byte
+}
+
+// hashDescriptor returns the key of the descriptor of kind, and the objects
+// it refers to. Labels are not part of the key.
+func hashDescriptor(kind string, descriptor any) (key, []any) {
+	s := &hasher{h: sha256.New()}
+	s.string(kind)
+	s.value(reflect.ValueOf(descriptor))
+
+	var k key
+	s.h.Sum(k[:0])
+	return k, s.objects
+}
+
+func (s *hasher) uint(v uint64) {
+	binary.LittleEndian.PutUint64(s.buf[:], v)
+	s.h.Write(s.buf[:])
+}
+
+func (s *hasher) string(v string) {
+	s.uint(uint64(len(v)))
+	s.h.Write([]byte(v))
+}
+
+func (s *hasher) value(v reflect.Value) {
+	if v.Type().Implements(releaserType) {
+		if v.IsNil() {
+			s.uint(0)
+			return
+		}
+		s.uint(uint64(v.Pointer()))
+		s.objects = append(s.objects, v.Interface())
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			s.uint(0)
+			return
+		}
+		s.uint(1)
+		s.value(v.Elem())
+	case reflect.Slice:
+		s.uint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			s.value(v.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			s.value(v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Name == "Label" {
+				continue
+			}
+			s.value(v.Field(i))
+		}
+	case reflect.String:
+		s.string(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			s.uint(1)
+		} else {
+			s.uint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.uint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s.uint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		s.uint(math.Float64bits(v.Float()))
+	default:
+		panic("cache: can't hash a " + v.Type().String())
+	}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+func TestHashDescriptor(t *testing.T) {
+	a, b := &object{}, &object{}
+	constants := func(v float64) *wgpu.ComputePipelineDescriptor {
+		return &wgpu.ComputePipelineDescriptor{
+			Compute: wgpu.ProgrammableStageDescriptor{
+				EntryPoint: "main",
+				Constants:  []wgpu.ConstantEntry{{Key: "x", Value: v}},
+			},
+		}
+	}
+	layout := func(entries ...wgpu.BindGroupLayoutEntry) *testLayoutDescriptor {
+		return &testLayoutDescriptor{Entries: entries}
+	}
+
+	tests := []struct {
+		name  string
+		x, y  any
+		equal bool
+	}{
+		{"equal", &testDescriptor{Value: 1}, &testDescriptor{Value: 1}, true},
+		{"labels", &testDescriptor{Label: "a"}, &testDescriptor{Label: "b"}, true},
+		{"values", &testDescriptor{Value: 1}, &testDescriptor{Value: 2}, false},
+		{"same object", &testDescriptor{Object: a}, &testDescriptor{Object: a}, true},
+		{"objects by identity", &testDescriptor{Object: a}, &testDescriptor{Object: b}, false},
+		{"nil object", &testDescriptor{}, &testDescriptor{Object: a}, false},
+		{"pipeline constants", constants(1), constants(1), true},
+		{"different pipeline constants", constants(1), constants(2), false},
+		{"slices by content",
+			layout(wgpu.BindGroupLayoutEntry{Binding: 1}),
+			layout(wgpu.BindGroupLayoutEntry{Binding: 1}), true},
+		{"slice lengths",
+			layout(wgpu.BindGroupLayoutEntry{}, wgpu.BindGroupLayoutEntry{}),
+			layout(wgpu.BindGroupLayoutEntry{}), false},
+		{"slice elements",
+			layout(wgpu.BindGroupLayoutEntry{Binding: 1}),
+			layout(wgpu.BindGroupLayoutEntry{Binding: 2}), false},
+		{"nil and empty slices", layout(), &testLayoutDescriptor{Entries: []wgpu.BindGroupLayoutEntry{}}, true},
+		{"pointers by content",
+			&testPointerDescriptor{Value: &[]uint32{7}[0]},
+			&testPointerDescriptor{Value: &[]uint32{7}[0]}, true},
+		{"pointed to values",
+			&testPointerDescriptor{Value: &[]uint32{7}[0]},
+			&testPointerDescriptor{Value: &[]uint32{8}[0]}, false},
+		{"nil pointer",
+			&testPointerDescriptor{},
+			&testPointerDescriptor{Value: &[]uint32{0}[0]}, false},
+		{"nil descriptor", (*testDescriptor)(nil), &testDescriptor{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, _ := hashDescriptor("t", tt.x)
+			y, _ := hashDescriptor("t", tt.y)
+			if (x == y) != tt.equal {
+				t.Errorf("got equal keys %v, expected %v", x == y, tt.equal)
+			}
+		})
+	}
+}
+
+func TestHashDescriptorKind(t *testing.T) {
+	x, _ := hashDescriptor("Sampler", &wgpu.SamplerDescriptor{})
+	y, _ := hashDescriptor("BindGroup", &wgpu.SamplerDescriptor{})
+	if x == y {
+		t.Error("got equal keys for different kinds")
+	}
+}
+
+type testLayoutDescriptor struct {
+	Label   string
+	Entries []wgpu.BindGroupLayoutEntry
+}
+
+type testPointerDescriptor struct {
+	Value *uint32
+}
+
+func TestHashDescriptorObjects(t *testing.T) {
+	a, b := &object{}, &object{}
+	_, objects := hashDescriptor("t", &struct {
+		A, B, Nil *object
+		Value     uint32
+	}{A: a, B: b})
+	if len(objects) != 2 || objects[0] != a || objects[1] != b {
+		t.Errorf("got objects %v", objects)
+	}
+}
+
+func TestHashDescriptorUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	hashDescriptor("t", &struct{ F func() }{})
+}
@@ -8,89 +8,129 @@ package wgpu
 extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message, void * userdata);
 
 static inline void gowebgpu_command_encoder_clear_buffer(WGPUCommandEncoder commandEncoder, WGPUBuffer buffer, uint64_t offset, uint64_t size, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderClearBuffer(commandEncoder, buffer, offset, size);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_copy_buffer_to_buffer(WGPUCommandEncoder commandEncoder, WGPUBuffer source, uint64_t sourceOffset, WGPUBuffer destination, uint64_t destinationOffset, uint64_t size, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderCopyBufferToBuffer(commandEncoder, source, sourceOffset, destination, destinationOffset, size);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_copy_buffer_to_texture(WGPUCommandEncoder commandEncoder, WGPUImageCopyBuffer const * source, WGPUImageCopyTexture const * destination, WGPUExtent3D const * copySize, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderCopyBufferToTexture(commandEncoder, source, destination, copySize);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_copy_texture_to_buffer(WGPUCommandEncoder commandEncoder, WGPUImageCopyTexture const * source, WGPUImageCopyBuffer const * destination, WGPUExtent3D const * copySize, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderCopyTextureToBuffer(commandEncoder, source, destination, copySize);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_copy_texture_to_texture(WGPUCommandEncoder commandEncoder, WGPUImageCopyTexture const * source, WGPUImageCopyTexture const * destination, WGPUExtent3D const * copySize, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderCopyTextureToTexture(commandEncoder, source, destination, copySize);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline WGPUCommandBuffer gowebgpu_command_encoder_finish(WGPUCommandEncoder commandEncoder, WGPUCommandBufferDescriptor const * descriptor, WGPUDevice device, void * error_userdata) {
 	WGPUCommandBuffer ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuCommandEncoderFinish(commandEncoder, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline void gowebgpu_command_encoder_insert_debug_marker(WGPUCommandEncoder commandEncoder, char const * markerLabel, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderInsertDebugMarker(commandEncoder, markerLabel);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_pop_debug_group(WGPUCommandEncoder commandEncoder, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderPopDebugGroup(commandEncoder);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_push_debug_group(WGPUCommandEncoder commandEncoder, char const * groupLabel, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderPushDebugGroup(commandEncoder, groupLabel);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_resolve_query_set(WGPUCommandEncoder commandEncoder, WGPUQuerySet querySet, uint32_t firstQuery, uint32_t queryCount, WGPUBuffer destination, uint64_t destinationOffset, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderResolveQuerySet(commandEncoder, querySet, firstQuery, queryCount, destination, destinationOffset);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_command_encoder_write_timestamp(WGPUCommandEncoder commandEncoder, WGPUQuerySet querySet, uint32_t queryIndex, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuCommandEncoderWriteTimestamp(commandEncoder, querySet, queryIndex);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
-}
-
-static inline void gowebgpu_command_encoder_release(WGPUCommandEncoder commandEncoder, WGPUDevice device) {
-	wgpuDeviceRelease(device);
-	wgpuCommandEncoderRelease(commandEncoder);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 */
 import "C"
 import (
-	"errors"
 	"runtime/cgo"
 	"unsafe"
 )
 
 type CommandEncoder struct {
-	deviceRef C.WGPUDevice
-	ref       C.WGPUCommandEncoder
+	device *Device
+	ref    C.WGPUCommandEncoder
+	label  string
+	child  *deviceChild
 }
 
 type ComputePassDescriptor struct {
@@ -101,14 +141,18 @@ type ComputePassDescriptor struct {
 }
 
 func (p *CommandEncoder) BeginComputePass(descriptor *ComputePassDescriptor) *ComputePassEncoder {
+	p.checkReleased()
 	var desc *C.WGPUComputePassDescriptor
+	var label string
 
 	if descriptor != nil && descriptor.Label != "" {
-		label := C.CString(descriptor.Label)
-		defer C.free(unsafe.Pointer(label))
+		label = descriptor.Label
+
+		labelStr := C.CString(label)
+		defer C.free(unsafe.Pointer(labelStr))
 
 		desc = &C.WGPUComputePassDescriptor{
-			label: label,
+			label: labelStr,
 		}
 	}
 
@@ -117,8 +161,12 @@ func (p *CommandEncoder) BeginComputePass(descriptor *ComputePassDescriptor) *Co
 		panic("Failed to acquire ComputePassEncoder")
 	}
 
-	C.wgpuDeviceReference(p.deviceRef)
-	return &ComputePassEncoder{deviceRef: p.deviceRef, ref: ref}
+	C.wgpuDeviceReference(p.device.ref)
+	child := p.device.addChild(deviceChildOrderPassEncoder, func(d *Device) {
+		C.wgpuDeviceRelease(d.ref)
+		C.wgpuComputePassEncoderRelease(ref)
+	})
+	return trackObject(&ComputePassEncoder{device: p.device, ref: ref, label: label, child: child}, label, (*ComputePassEncoder).Release)
 }
 
 type RenderPassColorAttachment struct {
@@ -152,14 +200,18 @@ type RenderPassDescriptor struct {
 }
 
 func (p *CommandEncoder) BeginRenderPass(descriptor *RenderPassDescriptor) *RenderPassEncoder {
+	p.checkReleased()
 	var desc C.WGPURenderPassDescriptor
+	var label string
 
 	if descriptor != nil {
-		if descriptor.Label != "" {
-			label := C.CString(descriptor.Label)
-			defer C.free(unsafe.Pointer(label))
+		label = descriptor.Label
+
+		if label != "" {
+			labelStr := C.CString(label)
+			defer C.free(unsafe.Pointer(labelStr))
 
-			desc.label = label
+			desc.label = labelStr
 		}
 
 		colorAttachmentCount := len(descriptor.ColorAttachments)
@@ -181,9 +233,11 @@ func (p *CommandEncoder) BeginRenderPass(descriptor *RenderPassDescriptor) *Rend
 					},
 				}
 				if v.View != nil {
+					v.View.checkReleased()
 					colorAttachment.view = v.View.ref
 				}
 				if v.ResolveTarget != nil {
+					v.ResolveTarget.checkReleased()
 					colorAttachment.resolveTarget = v.ResolveTarget.ref
 				}
 
@@ -199,6 +253,7 @@ func (p *CommandEncoder) BeginRenderPass(descriptor *RenderPassDescriptor) *Rend
 			defer C.free(unsafe.Pointer(depthStencilAttachment))
 
 			if descriptor.DepthStencilAttachment.View != nil {
+				descriptor.DepthStencilAttachment.View.checkReleased()
 				depthStencilAttachment.view = descriptor.DepthStencilAttachment.View.ref
 			}
 			depthStencilAttachment.depthLoadOp = C.WGPULoadOp(descriptor.DepthStencilAttachment.DepthLoadOp)
@@ -215,35 +270,63 @@ func (p *CommandEncoder) BeginRenderPass(descriptor *RenderPassDescriptor) *Rend
 	}
 
 	ref := C.wgpuCommandEncoderBeginRenderPass(p.ref, &desc)
-	C.wgpuDeviceReference(p.deviceRef)
-	return &RenderPassEncoder{deviceRef: p.deviceRef, ref: ref}
+	C.wgpuDeviceReference(p.device.ref)
+	child := p.device.addChild(deviceChildOrderPassEncoder, func(d *Device) {
+		C.wgpuDeviceRelease(d.ref)
+		C.wgpuRenderPassEncoderRelease(ref)
+	})
+	return trackObject(&RenderPassEncoder{device: p.device, ref: ref, label: label, child: child}, label, (*RenderPassEncoder).Release)
 }
 
 func (p *CommandEncoder) ClearBuffer(buffer *Buffer, offset uint64, size uint64) (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).ClearBuffer(): " + message)
+	p.checkReleased()
+	buffer.checkReleased()
+	if err := p.device.lostError("wgpu.(*CommandEncoder).ClearBuffer()", p.label); err != nil {
+		return err
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).ClearBuffer()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).ClearBuffer()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
+	}
+
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_clear_buffer(
 		p.ref,
 		buffer.ref,
 		C.uint64_t(offset),
 		C.uint64_t(size),
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) CopyBufferToBuffer(source *Buffer, sourceOffset uint64, destination *Buffer, destinatonOffset uint64, size uint64) (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).CopyBufferToBuffer(): " + message)
+	p.checkReleased()
+	source.checkReleased()
+	destination.checkReleased()
+	if err := p.device.lostError("wgpu.(*CommandEncoder).CopyBufferToBuffer()", p.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).CopyBufferToBuffer()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).CopyBufferToBuffer()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_copy_buffer_to_buffer(
 		p.ref,
 		source.ref,
@@ -251,9 +334,10 @@ func (p *CommandEncoder) CopyBufferToBuffer(source *Buffer, sourceOffset uint64,
 		destination.ref,
 		C.uint64_t(destinatonOffset),
 		C.uint64_t(size),
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
@@ -263,9 +347,11 @@ type ImageCopyBuffer struct {
 }
 
 func (p *CommandEncoder) CopyBufferToTexture(source *ImageCopyBuffer, destination *ImageCopyTexture, copySize *Extent3D) (err error) {
+	p.checkReleased()
 	var src C.WGPUImageCopyBuffer
 	if source != nil {
 		if source.Buffer != nil {
+			source.Buffer.checkReleased()
 			src.buffer = source.Buffer.ref
 		}
 		src.layout = C.WGPUTextureDataLayout{
@@ -287,6 +373,7 @@ func (p *CommandEncoder) CopyBufferToTexture(source *ImageCopyBuffer, destinatio
 			aspect: C.WGPUTextureAspect(destination.Aspect),
 		}
 		if destination.Texture != nil {
+			destination.Texture.checkReleased()
 			dst.texture = destination.Texture.ref
 		}
 	}
@@ -300,24 +387,35 @@ func (p *CommandEncoder) CopyBufferToTexture(source *ImageCopyBuffer, destinatio
 		}
 	}
 
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).CopyBufferToTexture(): " + message)
+	if err := p.device.lostError("wgpu.(*CommandEncoder).CopyBufferToTexture()", p.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).CopyBufferToTexture()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).CopyBufferToTexture()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_copy_buffer_to_texture(
 		p.ref,
 		&src,
 		&dst,
 		&cpySize,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) CopyTextureToBuffer(source *ImageCopyTexture, destination *ImageCopyBuffer, copySize *Extent3D) (err error) {
+	p.checkReleased()
 	var src C.WGPUImageCopyTexture
 	if source != nil {
 		src = C.WGPUImageCopyTexture{
@@ -330,6 +428,7 @@ func (p *CommandEncoder) CopyTextureToBuffer(source *ImageCopyTexture, destinati
 			aspect: C.WGPUTextureAspect(source.Aspect),
 		}
 		if source.Texture != nil {
+			source.Texture.checkReleased()
 			src.texture = source.Texture.ref
 		}
 	}
@@ -337,6 +436,7 @@ func (p *CommandEncoder) CopyTextureToBuffer(source *ImageCopyTexture, destinati
 	var dst C.WGPUImageCopyBuffer
 	if destination != nil {
 		if destination.Buffer != nil {
+			destination.Buffer.checkReleased()
 			dst.buffer = destination.Buffer.ref
 		}
 		dst.layout = C.WGPUTextureDataLayout{
@@ -355,24 +455,35 @@ func (p *CommandEncoder) CopyTextureToBuffer(source *ImageCopyTexture, destinati
 		}
 	}
 
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).CopyTextureToBuffer(): " + message)
+	if err := p.device.lostError("wgpu.(*CommandEncoder).CopyTextureToBuffer()", p.label); err != nil {
+		return err
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).CopyTextureToBuffer()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).CopyTextureToBuffer()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
+	}
+
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_copy_texture_to_buffer(
 		p.ref,
 		&src,
 		&dst,
 		&cpySize,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) CopyTextureToTexture(source *ImageCopyTexture, destination *ImageCopyTexture, copySize *Extent3D) (err error) {
+	p.checkReleased()
 	var src C.WGPUImageCopyTexture
 	if source != nil {
 		src = C.WGPUImageCopyTexture{
@@ -385,6 +496,7 @@ func (p *CommandEncoder) CopyTextureToTexture(source *ImageCopyTexture, destinat
 			aspect: C.WGPUTextureAspect(source.Aspect),
 		}
 		if source.Texture != nil {
+			source.Texture.checkReleased()
 			src.texture = source.Texture.ref
 		}
 	}
@@ -401,6 +513,7 @@ func (p *CommandEncoder) CopyTextureToTexture(source *ImageCopyTexture, destinat
 			aspect: C.WGPUTextureAspect(destination.Aspect),
 		}
 		if destination.Texture != nil {
+			destination.Texture.checkReleased()
 			dst.texture = destination.Texture.ref
 		}
 	}
@@ -414,20 +527,30 @@ func (p *CommandEncoder) CopyTextureToTexture(source *ImageCopyTexture, destinat
 		}
 	}
 
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).CopyTextureToTexture(): " + message)
+	if err := p.device.lostError("wgpu.(*CommandEncoder).CopyTextureToTexture()", p.label); err != nil {
+		return err
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).CopyTextureToTexture()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).CopyTextureToTexture()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
+	}
+
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_copy_texture_to_texture(
 		p.ref,
 		&src,
 		&dst,
 		&cpySize,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
@@ -436,6 +559,7 @@ type CommandBufferDescriptor struct {
 }
 
 func (p *CommandEncoder) Finish(descriptor *CommandBufferDescriptor) (*CommandBuffer, error) {
+	p.checkReleased()
 	var desc *C.WGPUCommandBufferDescriptor
 
 	if descriptor != nil && descriptor.Label != "" {
@@ -447,87 +571,147 @@ func (p *CommandEncoder) Finish(descriptor *CommandBufferDescriptor) (*CommandBu
 		}
 	}
 
+	if err := p.device.lostError("wgpu.(*CommandEncoder).Finish()", p.label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).Finish(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).Finish()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).Finish()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_command_encoder_finish(
 		p.ref,
 		desc,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuCommandBufferRelease(ref)
 		return nil, err
 	}
 
-	return &CommandBuffer{ref}, nil
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	child := p.device.addChild(deviceChildOrderCommand, func(*Device) { C.wgpuCommandBufferRelease(ref) })
+	return trackObject(&CommandBuffer{ref: ref, label: label, child: child}, label, (*CommandBuffer).Release), nil
 }
 
 func (p *CommandEncoder) InsertDebugMarker(markerLabel string) (err error) {
+	p.checkReleased()
 	markerLabelStr := C.CString(markerLabel)
 	defer C.free(unsafe.Pointer(markerLabelStr))
 
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).InsertDebugMarker(): " + message)
+	if err := p.device.lostError("wgpu.(*CommandEncoder).InsertDebugMarker()", p.label); err != nil {
+		return err
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).InsertDebugMarker()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).InsertDebugMarker()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
+	}
+
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_insert_debug_marker(
 		p.ref,
 		markerLabelStr,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) PopDebugGroup() (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).PopDebugGroup(): " + message)
+	p.checkReleased()
+	if err := p.device.lostError("wgpu.(*CommandEncoder).PopDebugGroup()", p.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).PopDebugGroup()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).PopDebugGroup()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_pop_debug_group(
 		p.ref,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) PushDebugGroup(groupLabel string) (err error) {
+	p.checkReleased()
 	groupLabelStr := C.CString(groupLabel)
 	defer C.free(unsafe.Pointer(groupLabelStr))
 
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).PushDebugGroup(): " + message)
+	if err := p.device.lostError("wgpu.(*CommandEncoder).PushDebugGroup()", p.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).PushDebugGroup()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).PushDebugGroup()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_push_debug_group(
 		p.ref,
 		groupLabelStr,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) ResolveQuerySet(querySet *QuerySet, firstQuery uint32, queryCount uint32, destination *Buffer, destinationOffset uint64) (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).ResolveQuerySet(): " + message)
+	p.checkReleased()
+	querySet.checkReleased()
+	destination.checkReleased()
+	if err := p.device.lostError("wgpu.(*CommandEncoder).ResolveQuerySet()", p.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).ResolveQuerySet()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).ResolveQuerySet()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_resolve_query_set(
 		p.ref,
 		querySet.ref,
@@ -535,29 +719,47 @@ func (p *CommandEncoder) ResolveQuerySet(querySet *QuerySet, firstQuery uint32,
 		C.uint32_t(queryCount),
 		destination.ref,
 		C.uint64_t(destinationOffset),
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) WriteTimestamp(querySet *QuerySet, queryIndex uint32) (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*CommandEncoder).WriteTimestamp(): " + message)
+	p.checkReleased()
+	querySet.checkReleased()
+	if err := p.device.lostError("wgpu.(*CommandEncoder).WriteTimestamp()", p.label); err != nil {
+		return err
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*CommandEncoder).WriteTimestamp()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*CommandEncoder).WriteTimestamp()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
+	}
+
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_command_encoder_write_timestamp(
 		p.ref,
 		querySet.ref,
 		C.uint32_t(queryIndex),
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *CommandEncoder) Release() {
-	C.gowebgpu_command_encoder_release(p.ref, p.deviceRef)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
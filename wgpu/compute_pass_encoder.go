@@ -8,57 +8,90 @@ package wgpu
 extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message, void * userdata);
 
 static inline void gowebgpu_compute_pass_encoder_end(WGPUComputePassEncoder computePassEncoder, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuComputePassEncoderEnd(computePassEncoder);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
-}
-
-static inline void gowebgpu_compute_pass_encoder_release(WGPUComputePassEncoder computePassEncoder, WGPUDevice device) {
-	wgpuDeviceRelease(device);
-	wgpuComputePassEncoderRelease(computePassEncoder);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 */
 import "C"
 import (
-	"errors"
 	"runtime/cgo"
 	"unsafe"
 )
 
 type ComputePassEncoder struct {
-	deviceRef C.WGPUDevice
-	ref       C.WGPUComputePassEncoder
+	device *Device
+	ref    C.WGPUComputePassEncoder
+	label  string
+	child  *deviceChild
 }
 
 func (p *ComputePassEncoder) BeginPipelineStatisticsQuery(querySet *QuerySet, queryIndex uint32) {
+	p.checkReleased()
+	querySet.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuComputePassEncoderBeginPipelineStatisticsQuery(p.ref, querySet.ref, C.uint32_t(queryIndex))
 }
 
 func (p *ComputePassEncoder) DispatchWorkgroups(workgroupCountX, workgroupCountY, workgroupCountZ uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuComputePassEncoderDispatchWorkgroups(p.ref, C.uint32_t(workgroupCountX), C.uint32_t(workgroupCountY), C.uint32_t(workgroupCountZ))
 }
 
 func (p *ComputePassEncoder) DispatchWorkgroupsIndirect(indirectBuffer *Buffer, indirectOffset uint64) {
+	p.checkReleased()
+	indirectBuffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuComputePassEncoderDispatchWorkgroupsIndirect(p.ref, indirectBuffer.ref, C.uint64_t(indirectOffset))
 }
 
 func (p *ComputePassEncoder) End() (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*ComputePassEncoder).End(): " + message)
+	p.checkReleased()
+	if err := p.device.lostError("wgpu.(*ComputePassEncoder).End()", p.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*ComputePassEncoder).End()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*ComputePassEncoder).End()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
-	C.gowebgpu_compute_pass_encoder_end(p.ref, p.deviceRef, unsafe.Pointer(&errorCallbackHandle))
+	unlock := p.device.lockErrorScope(errorUserdata)
+	C.gowebgpu_compute_pass_encoder_end(p.ref, p.device.ref, errorUserdata)
+	unlock()
 	return
 }
 
 func (p *ComputePassEncoder) EndPipelineStatisticsQuery() {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuComputePassEncoderEndPipelineStatisticsQuery(p.ref)
 }
 
 func (p *ComputePassEncoder) InsertDebugMarker(markerLabel string) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	markerLabelStr := C.CString(markerLabel)
 	defer C.free(unsafe.Pointer(markerLabelStr))
 
@@ -66,10 +99,18 @@ func (p *ComputePassEncoder) InsertDebugMarker(markerLabel string) {
 }
 
 func (p *ComputePassEncoder) PopDebugGroup() {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuComputePassEncoderPopDebugGroup(p.ref)
 }
 
 func (p *ComputePassEncoder) PushDebugGroup(groupLabel string) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	groupLabelStr := C.CString(groupLabel)
 	defer C.free(unsafe.Pointer(groupLabelStr))
 
@@ -77,6 +118,11 @@ func (p *ComputePassEncoder) PushDebugGroup(groupLabel string) {
 }
 
 func (p *ComputePassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, dynamicOffsets []uint32) {
+	p.checkReleased()
+	group.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	dynamicOffsetCount := len(dynamicOffsets)
 	if dynamicOffsetCount == 0 {
 		C.wgpuComputePassEncoderSetBindGroup(p.ref, C.uint32_t(groupIndex), group.ref, 0, nil)
@@ -89,9 +135,19 @@ func (p *ComputePassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, d
 }
 
 func (p *ComputePassEncoder) SetPipeline(pipeline *ComputePipeline) {
+	p.checkReleased()
+	pipeline.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuComputePassEncoderSetPipeline(p.ref, pipeline.ref)
 }
 
 func (p *ComputePassEncoder) Release() {
-	C.gowebgpu_compute_pass_encoder_release(p.ref, p.deviceRef)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
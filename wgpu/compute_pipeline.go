@@ -9,18 +9,28 @@ package wgpu
 import "C"
 
 type ComputePipeline struct {
-	ref C.WGPUComputePipeline
+	device *Device
+	ref    C.WGPUComputePipeline
+	label  string
+	child  *deviceChild
 }
 
 func (p *ComputePipeline) GetBindGroupLayout(groupIndex uint32) *BindGroupLayout {
+	p.checkReleased()
 	ref := C.wgpuComputePipelineGetBindGroupLayout(p.ref, C.uint32_t(groupIndex))
 	if ref == nil {
 		panic("Failed to accquire BindGroupLayout")
 	}
 
-	return &BindGroupLayout{ref}
+	child := p.device.addChild(deviceChildOrderBindGroupLayout, func(*Device) { C.wgpuBindGroupLayoutRelease(ref) })
+	return trackObject(&BindGroupLayout{ref: ref, child: child}, "", (*BindGroupLayout).Release)
 }
 
 func (p *ComputePipeline) Release() {
-	C.wgpuComputePipelineRelease(p.ref)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
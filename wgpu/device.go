@@ -9,102 +9,246 @@ extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message,
 
 static inline WGPUBindGroup gowebgpu_device_create_bind_group(WGPUDevice device, WGPUBindGroupDescriptor const * descriptor, void * error_userdata) {
 	WGPUBindGroup ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateBindGroup(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUBindGroupLayout gowebgpu_device_create_bind_group_layout(WGPUDevice device, WGPUBindGroupLayoutDescriptor const * descriptor, void * error_userdata) {
 	WGPUBindGroupLayout ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateBindGroupLayout(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUBuffer gowebgpu_device_create_buffer(WGPUDevice device, WGPUBufferDescriptor const * descriptor, void * error_userdata) {
 	WGPUBuffer ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateBuffer(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUCommandEncoder gowebgpu_device_create_command_encoder(WGPUDevice device, WGPUCommandEncoderDescriptor const * descriptor, void * error_userdata) {
 	WGPUCommandEncoder ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateCommandEncoder(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUComputePipeline gowebgpu_device_create_compute_pipeline(WGPUDevice device, WGPUComputePipelineDescriptor const * descriptor, void * error_userdata) {
 	WGPUComputePipeline ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateComputePipeline(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUPipelineLayout gowebgpu_device_create_pipeline_layout(WGPUDevice device, WGPUPipelineLayoutDescriptor const * descriptor, void * error_userdata) {
 	WGPUPipelineLayout ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreatePipelineLayout(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUQuerySet gowebgpu_device_create_query_set(WGPUDevice device, WGPUQuerySetDescriptor const * descriptor, void * error_userdata) {
 	WGPUQuerySet ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateQuerySet(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPURenderPipeline gowebgpu_device_create_render_pipeline(WGPUDevice device, WGPURenderPipelineDescriptor const * descriptor, void * error_userdata) {
 	WGPURenderPipeline ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateRenderPipeline(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUSampler gowebgpu_device_create_sampler(WGPUDevice device, WGPUSamplerDescriptor const * descriptor, void * error_userdata) {
 	WGPUSampler ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateSampler(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUShaderModule gowebgpu_device_create_shader_module(WGPUDevice device, WGPUShaderModuleDescriptor const * descriptor, void * error_userdata) {
 	WGPUShaderModule ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateShaderModule(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 static inline WGPUTexture gowebgpu_device_create_texture(WGPUDevice device, WGPUTextureDescriptor const * descriptor, void * error_userdata) {
 	WGPUTexture ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuDeviceCreateTexture(device, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
 */
 import "C"
 import (
-	"errors"
+	"context"
 	"runtime/cgo"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
 type Device struct {
+	ref   C.WGPUDevice
+	label string
+	// released is set by Release. ref is kept, as the children created from
+	// the device hold their own native reference to it.
+	released atomic.Bool
+
+	queueMu sync.Mutex
+	queue   *Queue
+	// queueChild is kept apart from queue, as RecoverableDevice replaces the
+	// fields of the queue it handed out.
+	queueChild *deviceChild
+
+	pollerMu sync.Mutex
+	poller   *devicePoller
+
+	uncapturedErrorMu    sync.Mutex
+	uncapturedErrorState *uncapturedErrorState
+
+	// errorScopeMu keeps the error scopes pushed and popped by different
+	// goroutines from interleaving, as native has a single scope stack per
+	// device.
+	errorScopeMu    sync.Mutex
+	errorScopeDepth atomic.Int32
+
+	validationMu sync.Mutex
+	deferred     atomic.Pointer[deferredValidation]
+
+	children deviceChildren
+
+	lost *deviceLostState
+
+	native *deviceNative
+	// sentinel is tracked in place of the device, see trackDevice.
+	sentinel *deviceSentinel
+
+	pipelineWorkers pipelineWorkers
+}
+
+// deviceNative is the native state of a device that may outlive the Device.
+// It must not point back to the Device, so that the leak finalizer can
+// release it.
+type deviceNative struct {
 	ref C.WGPUDevice
+	// refs counts the native handles of the device, its own and the ones of
+	// its children.
+	refs atomic.Int64
+
+	mu sync.Mutex
+	// lostHandle and uncapturedErrorHandle are the userdata of the device
+	// lost and uncaptured error callbacks. They are in C memory, and freed
+	// once refs drops to zero, as native may call the callbacks until then.
+	lostHandle            *cgo.Handle
+	uncapturedErrorHandle unsafe.Pointer
+	// queue is the native queue of Device.GetQueue.
+	queue C.WGPUQueue
+}
+
+func newDevice(ref C.WGPUDevice) *Device {
+	native := &deviceNative{ref: ref}
+	native.refs.Store(1)
+	return &Device{ref: ref, native: native}
+}
+
+// release frees the device lost and uncaptured error handles once the last
+// native handle of the device is released.
+func (p *deviceNative) release() {
+	if p.refs.Add(-1) > 0 {
+		return
+	}
+
+	p.mu.Lock()
+	lostHandle, uncapturedErrorHandle := p.lostHandle, p.uncapturedErrorHandle
+	p.lostHandle, p.uncapturedErrorHandle = nil, nil
+	p.mu.Unlock()
+
+	if lostHandle != nil {
+		freeDeviceLostHandle(lostHandle)
+	}
+	if uncapturedErrorHandle != nil {
+		takeCallbackHandle(uncapturedErrorHandle)
+	}
+}
+
+// releaseLeaked releases the native handles of a device that became
+// unreachable without being released, and of its queue. The other children
+// hold the Device and are released by their own finalizers.
+func (p *deviceNative) releaseLeaked() {
+	p.mu.Lock()
+	queue := p.queue
+	p.mu.Unlock()
+
+	if queue != nil {
+		C.wgpuDeviceRelease(p.ref)
+		C.wgpuQueueRelease(queue)
+		p.release()
+	}
+	C.wgpuDeviceRelease(p.ref)
+	p.release()
 }
 
 type errorCallback func(typ ErrorType, message string)
@@ -118,7 +262,128 @@ func gowebgpu_error_callback_go(_type C.WGPUErrorType, message *C.char, userdata
 	}
 }
 
-func (p *Device) Release() { C.wgpuDeviceRelease(p.ref) }
+type UncapturedErrorCallback func(err *Error)
+
+type uncapturedErrorState struct {
+	mu       sync.Mutex
+	callback UncapturedErrorCallback
+}
+
+// SetUncapturedErrorCallback sets the callback that receives errors not
+// captured by an error scope, e.g. from commands that don't return an error.
+// A nil callback drops them.
+func (p *Device) SetUncapturedErrorCallback(callback UncapturedErrorCallback) {
+	p.checkReleased()
+	p.uncapturedErrorMu.Lock()
+	defer p.uncapturedErrorMu.Unlock()
+
+	if p.uncapturedErrorState != nil {
+		p.uncapturedErrorState.mu.Lock()
+		p.uncapturedErrorState.callback = callback
+		p.uncapturedErrorState.mu.Unlock()
+		return
+	}
+
+	state := &uncapturedErrorState{callback: callback}
+	var cb errorCallback = func(typ ErrorType, message string) {
+		state.mu.Lock()
+		callback := state.callback
+		state.mu.Unlock()
+
+		if callback != nil {
+			callback(&Error{Type: typ, Message: message})
+		}
+	}
+	// native device may report errors for as long as it lives, so the
+	// handle is only freed once the device and its children are released.
+	// Later calls change the callback of the state instead of registering
+	// a new handle.
+	handle := newCallbackHandle(cb)
+	p.uncapturedErrorState = state
+	p.native.mu.Lock()
+	p.native.uncapturedErrorHandle = handle
+	p.native.mu.Unlock()
+
+	C.wgpuDeviceSetUncapturedErrorCallback(
+		p.ref,
+		C.WGPUErrorCallback(C.gowebgpu_error_callback_c),
+		handle,
+	)
+}
+
+// PushErrorScope pushes an error scope that captures errors matching filter
+// until the matching PopErrorScope.
+func (p *Device) PushErrorScope(filter ErrorFilter) {
+	p.checkReleased()
+	if p.isLost() {
+		return
+	}
+	p.errorScopeMu.Lock()
+	defer p.errorScopeMu.Unlock()
+
+	p.errorScopeDepth.Add(1)
+	C.wgpuDevicePushErrorScope(p.ref, C.WGPUErrorFilter(filter))
+}
+
+// PopErrorScope pops the error scope pushed by the last PushErrorScope and
+// returns the first error it captured, if any.
+func (p *Device) PopErrorScope() error {
+	p.checkReleased()
+	if err := p.lostError("wgpu.(*Device).PopErrorScope()", ""); err != nil {
+		return err
+	}
+	p.errorScopeMu.Lock()
+	defer p.errorScopeMu.Unlock()
+
+	if p.errorScopeDepth.Add(-1) < 0 {
+		p.errorScopeDepth.Add(1)
+		return &Error{
+			Type:    ErrorType_Validation,
+			Op:      "wgpu.(*Device).PopErrorScope()",
+			Message: "no error scope to pop",
+		}
+	}
+
+	var err error = nil
+	var cb errorCallback = func(typ ErrorType, message string) {
+		err = &Error{Type: typ, Op: "wgpu.(*Device).PopErrorScope()", Message: message}
+	}
+	errorCallbackHandle := cgo.NewHandle(cb)
+	defer errorCallbackHandle.Delete()
+
+	C.wgpuDevicePopErrorScope(
+		p.ref,
+		C.WGPUErrorCallback(C.gowebgpu_error_callback_c),
+		unsafe.Pointer(&errorCallbackHandle),
+	)
+	return err
+}
+
+// lockErrorScope locks the error scope stack if errorUserdata is set, for
+// the scope pushed and popped around a native call to capture only the
+// errors of that call. It returns the function unlocking it.
+func (p *Device) lockErrorScope(errorUserdata unsafe.Pointer) func() {
+	if errorUserdata == nil {
+		return func() {}
+	}
+	p.errorScopeMu.Lock()
+	return p.errorScopeMu.Unlock
+}
+
+func (p *Device) Release() {
+	if !p.released.CompareAndSwap(false, true) {
+		return
+	}
+	untrackDevice(p)
+	p.StopPoller()
+	p.queueMu.Lock()
+	if p.queueChild != nil {
+		p.queueChild.Release()
+	}
+	p.queueMu.Unlock()
+	C.wgpuDeviceRelease(p.ref)
+	p.native.release()
+}
 
 type BindGroupEntry struct {
 	Binding     uint32
@@ -136,6 +401,7 @@ type BindGroupDescriptor struct {
 }
 
 func (p *Device) CreateBindGroup(descriptor *BindGroupDescriptor) (*BindGroup, error) {
+	p.checkReleased()
 	var desc C.WGPUBindGroupDescriptor
 
 	if descriptor != nil {
@@ -147,6 +413,7 @@ func (p *Device) CreateBindGroup(descriptor *BindGroupDescriptor) (*BindGroup, e
 		}
 
 		if descriptor.Layout != nil {
+			descriptor.Layout.checkReleased()
 			desc.layout = descriptor.Layout.ref
 		}
 
@@ -165,12 +432,15 @@ func (p *Device) CreateBindGroup(descriptor *BindGroupDescriptor) (*BindGroup, e
 				}
 
 				if v.Buffer != nil {
+					v.Buffer.checkReleased()
 					entry.buffer = v.Buffer.ref
 				}
 				if v.Sampler != nil {
+					v.Sampler.checkReleased()
 					entry.sampler = v.Sampler.ref
 				}
 				if v.TextureView != nil {
+					v.TextureView.checkReleased()
 					entry.textureView = v.TextureView.ref
 				}
 
@@ -182,24 +452,40 @@ func (p *Device) CreateBindGroup(descriptor *BindGroupDescriptor) (*BindGroup, e
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateBindGroup()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateBindGroup(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateBindGroup()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateBindGroup()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_bind_group(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuBindGroupRelease(ref)
 		return nil, err
 	}
 
-	return &BindGroup{ref}, nil
+	child := p.addChild(deviceChildOrderBindGroup, func(*Device) { C.wgpuBindGroupRelease(ref) })
+	return trackObject(&BindGroup{ref: ref, label: label, child: child}, label, (*BindGroup).Release), nil
 }
 
 type BufferBindingLayout struct {
@@ -239,6 +525,7 @@ type BindGroupLayoutDescriptor struct {
 }
 
 func (p *Device) CreateBindGroupLayout(descriptor *BindGroupLayoutDescriptor) (*BindGroupLayout, error) {
+	p.checkReleased()
 	var desc C.WGPUBindGroupLayoutDescriptor
 
 	if descriptor != nil {
@@ -291,24 +578,40 @@ func (p *Device) CreateBindGroupLayout(descriptor *BindGroupLayoutDescriptor) (*
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateBindGroupLayout()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateBindGroupLayout(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateBindGroupLayout()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateBindGroupLayout()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_bind_group_layout(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuBindGroupLayoutRelease(ref)
 		return nil, err
 	}
 
-	return &BindGroupLayout{ref}, nil
+	child := p.addChild(deviceChildOrderBindGroupLayout, func(*Device) { C.wgpuBindGroupLayoutRelease(ref) })
+	return trackObject(&BindGroupLayout{ref: ref, label: label, child: child}, label, (*BindGroupLayout).Release), nil
 }
 
 type BufferDescriptor struct {
@@ -319,6 +622,7 @@ type BufferDescriptor struct {
 }
 
 func (p *Device) CreateBuffer(descriptor *BufferDescriptor) (*Buffer, error) {
+	p.checkReleased()
 	var desc C.WGPUBufferDescriptor
 
 	if descriptor != nil {
@@ -334,25 +638,44 @@ func (p *Device) CreateBuffer(descriptor *BufferDescriptor) (*Buffer, error) {
 		desc.mappedAtCreation = C.bool(descriptor.MappedAtCreation)
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateBuffer()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateBuffer(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateBuffer()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateBuffer()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_buffer(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuBufferRelease(ref)
 		return nil, err
 	}
 
 	C.wgpuDeviceReference(p.ref)
-	return &Buffer{deviceRef: p.ref, ref: ref}, nil
+	child := p.addChild(deviceChildOrderResource, func(d *Device) {
+		C.wgpuDeviceRelease(d.ref)
+		C.wgpuBufferRelease(ref)
+	})
+	return trackObject(&Buffer{device: p, ref: ref, label: label, child: child}, label, (*Buffer).Release), nil
 }
 
 type CommandEncoderDescriptor struct {
@@ -360,6 +683,7 @@ type CommandEncoderDescriptor struct {
 }
 
 func (p *Device) CreateCommandEncoder(descriptor *CommandEncoderDescriptor) (*CommandEncoder, error) {
+	p.checkReleased()
 	var desc *C.WGPUCommandEncoderDescriptor
 
 	if descriptor != nil && descriptor.Label != "" {
@@ -371,25 +695,44 @@ func (p *Device) CreateCommandEncoder(descriptor *CommandEncoderDescriptor) (*Co
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateCommandEncoder()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateCommandEncoder(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateCommandEncoder()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateCommandEncoder()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_command_encoder(
 		p.ref,
 		desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuCommandEncoderRelease(ref)
 		return nil, err
 	}
 
 	C.wgpuDeviceReference(p.ref)
-	return &CommandEncoder{deviceRef: p.ref, ref: ref}, nil
+	child := p.addChild(deviceChildOrderEncoder, func(d *Device) {
+		C.wgpuDeviceRelease(d.ref)
+		C.wgpuCommandEncoderRelease(ref)
+	})
+	return trackObject(&CommandEncoder{device: p, ref: ref, label: label, child: child}, label, (*CommandEncoder).Release), nil
 }
 
 type ConstantEntry struct {
@@ -400,9 +743,30 @@ type ConstantEntry struct {
 type ProgrammableStageDescriptor struct {
 	Module     *ShaderModule
 	EntryPoint string
+	Constants  []ConstantEntry
+}
+
+// constantEntries converts entries to C, free must be called once the
+// returned entries are not used anymore.
+func constantEntries(entries []ConstantEntry) (constants *C.WGPUConstantEntry, free func()) {
+	if len(entries) == 0 {
+		return nil, func() {}
+	}
 
-	// unused in wgpu
-	// Constants  []ConstantEntry
+	constants = (*C.WGPUConstantEntry)(C.malloc(C.size_t(len(entries)) * C.size_t(unsafe.Sizeof(C.WGPUConstantEntry{}))))
+	constantsSlice := unsafe.Slice(constants, len(entries))
+	for i, v := range entries {
+		constantsSlice[i] = C.WGPUConstantEntry{
+			key:   C.CString(v.Key),
+			value: C.double(v.Value),
+		}
+	}
+	return constants, func() {
+		for _, v := range constantsSlice {
+			C.free(unsafe.Pointer(v.key))
+		}
+		C.free(unsafe.Pointer(constants))
+	}
 }
 
 type ComputePipelineDescriptor struct {
@@ -412,6 +776,7 @@ type ComputePipelineDescriptor struct {
 }
 
 func (p *Device) CreateComputePipeline(descriptor *ComputePipelineDescriptor) (*ComputePipeline, error) {
+	p.checkReleased()
 	var desc C.WGPUComputePipelineDescriptor
 
 	if descriptor != nil {
@@ -423,11 +788,13 @@ func (p *Device) CreateComputePipeline(descriptor *ComputePipelineDescriptor) (*
 		}
 
 		if descriptor.Layout != nil {
+			descriptor.Layout.checkReleased()
 			desc.layout = descriptor.Layout.ref
 		}
 
 		var compute C.WGPUProgrammableStageDescriptor
 		if descriptor.Compute.Module != nil {
+			descriptor.Compute.Module.checkReleased()
 			compute.module = descriptor.Compute.Module.ref
 		}
 		if descriptor.Compute.EntryPoint != "" {
@@ -436,27 +803,47 @@ func (p *Device) CreateComputePipeline(descriptor *ComputePipelineDescriptor) (*
 
 			compute.entryPoint = entryPoint
 		}
+		constants, free := constantEntries(descriptor.Compute.Constants)
+		defer free()
+		compute.constantCount = C.size_t(len(descriptor.Compute.Constants))
+		compute.constants = constants
 		desc.compute = compute
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateComputePipeline()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateComputePipeline(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateComputePipeline()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateComputePipeline()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_compute_pipeline(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuComputePipelineRelease(ref)
 		return nil, err
 	}
 
-	return &ComputePipeline{ref}, nil
+	child := p.addChild(deviceChildOrderPipeline, func(*Device) { C.wgpuComputePipelineRelease(ref) })
+	return trackObject(&ComputePipeline{device: p, ref: ref, label: label, child: child}, label, (*ComputePipeline).Release), nil
 }
 
 type PushConstantRange struct {
@@ -472,6 +859,7 @@ type PipelineLayoutDescriptor struct {
 }
 
 func (p *Device) CreatePipelineLayout(descriptor *PipelineLayoutDescriptor) (*PipelineLayout, error) {
+	p.checkReleased()
 	var desc C.WGPUPipelineLayoutDescriptor
 
 	if descriptor != nil {
@@ -490,6 +878,7 @@ func (p *Device) CreatePipelineLayout(descriptor *PipelineLayoutDescriptor) (*Pi
 			bindGroupLayoutsSlice := unsafe.Slice((*C.WGPUBindGroupLayout)(bindGroupLayouts), bindGroupLayoutCount)
 
 			for i, v := range descriptor.BindGroupLayouts {
+				v.checkReleased()
 				bindGroupLayoutsSlice[i] = v.ref
 			}
 
@@ -527,24 +916,40 @@ func (p *Device) CreatePipelineLayout(descriptor *PipelineLayoutDescriptor) (*Pi
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreatePipelineLayout()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreatePipelineLayout(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreatePipelineLayout()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreatePipelineLayout()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_pipeline_layout(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuPipelineLayoutRelease(ref)
 		return nil, err
 	}
 
-	return &PipelineLayout{ref}, nil
+	child := p.addChild(deviceChildOrderPipelineLayout, func(*Device) { C.wgpuPipelineLayoutRelease(ref) })
+	return trackObject(&PipelineLayout{ref: ref, label: label, child: child}, label, (*PipelineLayout).Release), nil
 }
 
 type QuerySetDescriptor struct {
@@ -555,6 +960,7 @@ type QuerySetDescriptor struct {
 }
 
 func (p *Device) CreateQuerySet(descriptor *QuerySetDescriptor) (*QuerySet, error) {
+	p.checkReleased()
 	var desc C.WGPUQuerySetDescriptor
 
 	if descriptor != nil {
@@ -580,24 +986,40 @@ func (p *Device) CreateQuerySet(descriptor *QuerySetDescriptor) (*QuerySet, erro
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateQuerySet()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateQuerySet(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateQuerySet()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateQuerySet()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_query_set(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuQuerySetRelease(ref)
 		return nil, err
 	}
 
-	return &QuerySet{ref: ref}, nil
+	child := p.addChild(deviceChildOrderResource, func(*Device) { C.wgpuQuerySetRelease(ref) })
+	return trackObject(&QuerySet{ref: ref, label: label, child: child}, label, (*QuerySet).Release), nil
 }
 
 type RenderBundleEncoderDescriptor struct {
@@ -610,6 +1032,15 @@ type RenderBundleEncoderDescriptor struct {
 }
 
 func (p *Device) CreateRenderBundleEncoder(descriptor *RenderBundleEncoderDescriptor) (*RenderBundleEncoder, error) {
+	p.checkReleased()
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	if err := p.lostError("wgpu.(*Device).CreateRenderBundleEncoder()", label); err != nil {
+		return nil, err
+	}
+
 	var desc C.WGPURenderBundleEncoderDescriptor
 
 	if descriptor != nil {
@@ -639,7 +1070,8 @@ func (p *Device) CreateRenderBundleEncoder(descriptor *RenderBundleEncoderDescri
 
 	ref := C.wgpuDeviceCreateRenderBundleEncoder(p.ref, &desc)
 
-	return &RenderBundleEncoder{ref}, nil
+	child := p.addChild(deviceChildOrderEncoder, func(*Device) { C.wgpuRenderBundleEncoderRelease(ref) })
+	return trackObject(&RenderBundleEncoder{device: p, ref: ref, label: label, child: child}, label, (*RenderBundleEncoder).Release), nil
 }
 
 type BlendComponent struct {
@@ -663,9 +1095,7 @@ type FragmentState struct {
 	Module     *ShaderModule
 	EntryPoint string
 	Targets    []ColorTargetState
-
-	// unused in wgpu
-	// Constants  []ConstantEntry
+	Constants  []ConstantEntry
 }
 
 type VertexAttribute struct {
@@ -684,9 +1114,7 @@ type VertexState struct {
 	Module     *ShaderModule
 	EntryPoint string
 	Buffers    []VertexBufferLayout
-
-	// unused in wgpu
-	// Constants  []ConstantEntry
+	Constants  []ConstantEntry
 }
 
 type PrimitiveState struct {
@@ -733,6 +1161,7 @@ type RenderPipelineDescriptor struct {
 }
 
 func (p *Device) CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (*RenderPipeline, error) {
+	p.checkReleased()
 	var desc C.WGPURenderPipelineDescriptor
 
 	if descriptor != nil {
@@ -744,6 +1173,7 @@ func (p *Device) CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (*Re
 		}
 
 		if descriptor.Layout != nil {
+			descriptor.Layout.checkReleased()
 			desc.layout = descriptor.Layout.ref
 		}
 
@@ -754,6 +1184,7 @@ func (p *Device) CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (*Re
 			var vert C.WGPUVertexState
 
 			if vertex.Module != nil {
+				vertex.Module.checkReleased()
 				vert.module = vertex.Module.ref
 			}
 
@@ -764,6 +1195,11 @@ func (p *Device) CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (*Re
 				vert.entryPoint = entryPoint
 			}
 
+			constants, free := constantEntries(vertex.Constants)
+			defer free()
+			vert.constantCount = C.size_t(len(vertex.Constants))
+			vert.constants = constants
+
 			bufferCount := len(vertex.Buffers)
 			if bufferCount > 0 {
 				buffers := C.malloc(C.size_t(bufferCount) * C.size_t(unsafe.Sizeof(C.WGPUVertexBufferLayout{})))
@@ -865,9 +1301,15 @@ func (p *Device) CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (*Re
 			}
 
 			if fragment.Module != nil {
+				fragment.Module.checkReleased()
 				frag.module = fragment.Module.ref
 			}
 
+			constants, free := constantEntries(fragment.Constants)
+			defer free()
+			frag.constantCount = C.size_t(len(fragment.Constants))
+			frag.constants = constants
+
 			targetCount := len(fragment.Targets)
 			if targetCount > 0 {
 				targets := C.malloc(C.size_t(targetCount) * C.size_t(unsafe.Sizeof(C.WGPUColorTargetState{})))
@@ -913,24 +1355,40 @@ func (p *Device) CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (*Re
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateRenderPipeline()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateRenderPipeline(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateRenderPipeline()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateRenderPipeline()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_render_pipeline(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuRenderPipelineRelease(ref)
 		return nil, err
 	}
 
-	return &RenderPipeline{ref}, nil
+	child := p.addChild(deviceChildOrderPipeline, func(*Device) { C.wgpuRenderPipelineRelease(ref) })
+	return trackObject(&RenderPipeline{device: p, ref: ref, label: label, child: child}, label, (*RenderPipeline).Release), nil
 }
 
 type SamplerDescriptor struct {
@@ -948,6 +1406,7 @@ type SamplerDescriptor struct {
 }
 
 func (p *Device) CreateSampler(descriptor *SamplerDescriptor) (*Sampler, error) {
+	p.checkReleased()
 	var desc *C.WGPUSamplerDescriptor
 
 	if descriptor != nil {
@@ -972,24 +1431,40 @@ func (p *Device) CreateSampler(descriptor *SamplerDescriptor) (*Sampler, error)
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateSampler()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateSampler(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateSampler()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateSampler()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_sampler(
 		p.ref,
 		desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuSamplerRelease(ref)
 		return nil, err
 	}
 
-	return &Sampler{ref}, nil
+	child := p.addChild(deviceChildOrderResource, func(*Device) { C.wgpuSamplerRelease(ref) })
+	return trackObject(&Sampler{ref: ref, label: label, child: child}, label, (*Sampler).Release), nil
 }
 
 type ShaderModuleSPIRVDescriptor struct {
@@ -1014,6 +1489,7 @@ type ShaderModuleDescriptor struct {
 }
 
 func (p *Device) CreateShaderModule(descriptor *ShaderModuleDescriptor) (*ShaderModule, error) {
+	p.checkReleased()
 	var desc C.WGPUShaderModuleDescriptor
 
 	if descriptor != nil {
@@ -1113,24 +1589,51 @@ func (p *Device) CreateShaderModule(descriptor *ShaderModuleDescriptor) (*Shader
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateShaderModule()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateShaderModule(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateShaderModule()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateShaderModule()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_shader_module(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuShaderModuleRelease(ref)
+		if e, ok := err.(*Error); ok && e.Type == ErrorType_Validation {
+			var code string
+			switch {
+			case descriptor == nil:
+			case descriptor.WGSLDescriptor != nil:
+				code = descriptor.WGSLDescriptor.Code
+			case descriptor.GLSLDescriptor != nil:
+				code = descriptor.GLSLDescriptor.Code
+			}
+			return nil, newShaderCompileError(e, code)
+		}
 		return nil, err
 	}
 
-	return &ShaderModule{ref}, nil
+	child := p.addChild(deviceChildOrderResource, func(*Device) { C.wgpuShaderModuleRelease(ref) })
+	return trackObject(&ShaderModule{ref: ref, label: label, child: child}, label, (*ShaderModule).Release), nil
 }
 
 type SwapChainDescriptor struct {
@@ -1144,6 +1647,11 @@ type SwapChainDescriptor struct {
 }
 
 func (p *Device) CreateSwapChain(surface *Surface, descriptor *SwapChainDescriptor) (*SwapChain, error) {
+	p.checkReleased()
+	surface.checkReleased()
+	if err := p.lostError("wgpu.(*Device).CreateSwapChain()", ""); err != nil {
+		return nil, err
+	}
 	var desc C.WGPUSwapChainDescriptor
 
 	if descriptor != nil {
@@ -1183,7 +1691,11 @@ func (p *Device) CreateSwapChain(surface *Surface, descriptor *SwapChainDescript
 
 	ref := C.wgpuDeviceCreateSwapChain(p.ref, surface.ref, &desc)
 	C.wgpuDeviceReference(p.ref)
-	return &SwapChain{deviceRef: p.ref, ref: ref}, nil
+	child := p.addChild(deviceChildOrderSwapChain, func(d *Device) {
+		C.wgpuDeviceRelease(d.ref)
+		C.wgpuSwapChainRelease(ref)
+	})
+	return trackObject(&SwapChain{device: p, ref: ref, child: child}, "", (*SwapChain).Release), nil
 }
 
 type TextureDescriptor struct {
@@ -1197,6 +1709,7 @@ type TextureDescriptor struct {
 }
 
 func (p *Device) CreateTexture(descriptor *TextureDescriptor) (*Texture, error) {
+	p.checkReleased()
 	var desc C.WGPUTextureDescriptor
 
 	if descriptor != nil {
@@ -1221,28 +1734,48 @@ func (p *Device) CreateTexture(descriptor *TextureDescriptor) (*Texture, error)
 		}
 	}
 
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+
+	if err := p.lostError("wgpu.(*Device).CreateTexture()", label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Device).CreateTexture(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.recordDeferred("wgpu.(*Device).CreateTexture()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Device).CreateTexture()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_device_create_texture(
 		p.ref,
 		&desc,
-		unsafe.Pointer(&errorCallbackHandle),
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuTextureRelease(ref)
 		return nil, err
 	}
 
 	C.wgpuDeviceReference(p.ref)
-	return &Texture{deviceRef: p.ref, ref: ref}, nil
+	child := p.addChild(deviceChildOrderResource, func(d *Device) {
+		C.wgpuDeviceRelease(d.ref)
+		C.wgpuTextureRelease(ref)
+	})
+	return trackObject(&Texture{device: p, ref: ref, label: label, child: child}, label, (*Texture).Release), nil
 }
 
 func (p *Device) EnumerateFeatures() []FeatureName {
+	p.checkReleased()
 	size := C.wgpuDeviceEnumerateFeatures(p.ref, nil)
 	if size == 0 {
 		return nil
@@ -1254,6 +1787,7 @@ func (p *Device) EnumerateFeatures() []FeatureName {
 }
 
 func (p *Device) GetLimits() SupportedLimits {
+	p.checkReleased()
 	var supportedLimits C.WGPUSupportedLimits
 
 	extras := (*C.WGPUSupportedLimitsExtras)(C.malloc(C.size_t(unsafe.Sizeof(C.WGPUSupportedLimitsExtras{}))))
@@ -1301,13 +1835,33 @@ func (p *Device) GetLimits() SupportedLimits {
 	}
 }
 
+// GetQueue returns the queue of the device. Every call returns the same
+// queue, which is released with the device.
 func (p *Device) GetQueue() *Queue {
+	p.checkReleased()
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+
+	if p.queue != nil {
+		return p.queue
+	}
 	ref := C.wgpuDeviceGetQueue(p.ref)
 	C.wgpuDeviceReference(p.ref)
-	return &Queue{deviceRef: p.ref, ref: ref}
+	child := p.addChild(deviceChildOrderQueue, func(d *Device) {
+		C.wgpuDeviceRelease(d.ref)
+		C.wgpuQueueRelease(ref)
+	})
+	// the queue isn't tracked, it is released with the device.
+	p.queue = &Queue{device: p, ref: ref, child: child}
+	p.queueChild = child
+	p.native.mu.Lock()
+	p.native.queue = ref
+	p.native.mu.Unlock()
+	return p.queue
 }
 
 func (p *Device) HasFeature(feature FeatureName) bool {
+	p.checkReleased()
 	hasFeature := C.wgpuDeviceHasFeature(p.ref, C.WGPUFeatureName(feature))
 	return bool(hasFeature)
 }
@@ -1318,8 +1872,10 @@ type WrappedSubmissionIndex struct {
 }
 
 func (p *Device) Poll(wait bool, wrappedSubmissionIndex *WrappedSubmissionIndex) (queueEmpty bool) {
+	p.checkReleased()
 	var index *C.WGPUWrappedSubmissionIndex
 	if wrappedSubmissionIndex != nil {
+		wrappedSubmissionIndex.Queue.checkReleased()
 		index = &C.WGPUWrappedSubmissionIndex{
 			queue:           wrappedSubmissionIndex.Queue.ref,
 			submissionIndex: C.WGPUSubmissionIndex(wrappedSubmissionIndex.SubmissionIndex),
@@ -1328,3 +1884,91 @@ func (p *Device) Poll(wait bool, wrappedSubmissionIndex *WrappedSubmissionIndex)
 
 	return bool(C.wgpuDevicePoll(p.ref, C.bool(wait), index))
 }
+
+type devicePoller struct {
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func (p *devicePoller) run(device C.WGPUDevice, interval time.Duration) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			C.wgpuDevicePoll(device, false, nil)
+		}
+	}
+}
+
+// StartPoller starts a goroutine that polls the device every interval, so
+// that map and work-done callbacks fire without calling Poll by hand.
+// Callbacks are called on that goroutine. It is a no-op if the poller is
+// already running.
+func (p *Device) StartPoller(interval time.Duration) {
+	p.checkReleased()
+	p.pollerMu.Lock()
+	defer p.pollerMu.Unlock()
+
+	if p.poller != nil {
+		return
+	}
+
+	p.poller = &devicePoller{
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go p.poller.run(p.ref, interval)
+}
+
+// StopPoller stops the goroutine started by StartPoller and waits for it to
+// exit. It must not be called from a callback running on the poller.
+func (p *Device) StopPoller() {
+	p.pollerMu.Lock()
+	defer p.pollerMu.Unlock()
+
+	if p.poller == nil {
+		return
+	}
+
+	close(p.poller.stop)
+	<-p.poller.done
+	p.poller = nil
+}
+
+// contextPollInterval is how often the ...Context functions poll the device
+// while waiting for a callback.
+const contextPollInterval = time.Millisecond
+
+// pollUntil polls device until a value is received from result or ctx is
+// done.
+func pollUntil[T any](ctx context.Context, device C.WGPUDevice, result <-chan T) (T, error) {
+	ticker := time.NewTicker(contextPollInterval)
+	defer ticker.Stop()
+
+	for {
+		C.wgpuDevicePoll(device, false, nil)
+
+		select {
+		case v := <-result:
+			return v, nil
+		default:
+		}
+
+		select {
+		case v := <-result:
+			return v, nil
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
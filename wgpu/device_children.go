@@ -0,0 +1,180 @@
+package wgpu
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// deviceChildOrder is the order in which Device.Close releases children,
+// objects are released before the ones they may depend on.
+type deviceChildOrder int
+
+const (
+	deviceChildOrderPassEncoder deviceChildOrder = iota
+	deviceChildOrderEncoder
+	deviceChildOrderCommand
+	deviceChildOrderBindGroup
+	deviceChildOrderPipeline
+	deviceChildOrderPipelineLayout
+	deviceChildOrderBindGroupLayout
+	deviceChildOrderTextureView
+	deviceChildOrderResource
+	deviceChildOrderSwapChain
+	deviceChildOrderQueue
+)
+
+// deviceChild is the entry of an object in its device's registry. It only
+// holds native handles, not the object itself, so that untracked objects can
+// still be garbage collected.
+type deviceChild struct {
+	device   *Device
+	order    deviceChildOrder
+	released atomic.Bool
+	release  func(device *Device)
+
+	invalidateMu sync.Mutex
+	invalidated  bool
+	// callbacks are called once the object is released or destroyed.
+	callbacks  map[uint64]func()
+	callbackID uint64
+}
+
+type deviceChildren struct {
+	mu      sync.Mutex
+	entries map[*deviceChild]struct{}
+}
+
+// addChild registers a child object, release must release its native
+// handle.
+func (p *Device) addChild(order deviceChildOrder, release func(device *Device)) *deviceChild {
+	c := &deviceChild{device: p, order: order, release: release}
+	p.native.refs.Add(1)
+
+	p.children.mu.Lock()
+	if p.children.entries == nil {
+		p.children.entries = map[*deviceChild]struct{}{}
+	}
+	p.children.entries[c] = struct{}{}
+	p.children.mu.Unlock()
+
+	return c
+}
+
+// Release releases the native handle once, whether called from the object's
+// Release or from Device.Close.
+func (c *deviceChild) Release() {
+	if !c.released.CompareAndSwap(false, true) {
+		return
+	}
+	c.invalidate()
+
+	c.device.children.mu.Lock()
+	delete(c.device.children.entries, c)
+	c.device.children.mu.Unlock()
+
+	c.release(c.device)
+	c.device.native.release()
+}
+
+// Close releases every object created from the device that isn't released
+// yet, from command encoders down to buffers and textures, and then releases
+// the device. Using the released objects afterwards panics.
+func (p *Device) Close() {
+	if p.released.Load() {
+		return
+	}
+
+	p.children.mu.Lock()
+	children := make([]*deviceChild, 0, len(p.children.entries))
+	for c := range p.children.entries {
+		children = append(children, c)
+	}
+	p.children.mu.Unlock()
+
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].order < children[j].order
+	})
+	for _, c := range children {
+		c.Release()
+	}
+
+	p.Release()
+}
+
+// onInvalidate registers callback to be called once the object is released or
+// destroyed, and returns a function unregistering it. callback is called
+// right away if the object already is.
+func (c *deviceChild) onInvalidate(callback func()) (remove func()) {
+	c.invalidateMu.Lock()
+	if c.invalidated {
+		c.invalidateMu.Unlock()
+		callback()
+		return func() {}
+	}
+	if c.callbacks == nil {
+		c.callbacks = map[uint64]func(){}
+	}
+	c.callbackID++
+	id := c.callbackID
+	c.callbacks[id] = callback
+	c.invalidateMu.Unlock()
+
+	return func() {
+		c.invalidateMu.Lock()
+		delete(c.callbacks, id)
+		c.invalidateMu.Unlock()
+	}
+}
+
+// invalidate calls the callbacks registered with onInvalidate once.
+func (c *deviceChild) invalidate() {
+	c.invalidateMu.Lock()
+	if c.invalidated {
+		c.invalidateMu.Unlock()
+		return
+	}
+	c.invalidated = true
+	callbacks := c.callbacks
+	c.callbacks = nil
+	c.invalidateMu.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// OnInvalidate registers callback to be called once the buffer is released or
+// destroyed, and returns a function unregistering it. callback is called
+// right away if the buffer already is.
+func (p *Buffer) OnInvalidate(callback func()) (remove func()) {
+	return p.child.onInvalidate(callback)
+}
+
+// OnInvalidate registers callback to be called once the texture is released
+// or destroyed, and returns a function unregistering it. callback is called
+// right away if the texture already is.
+func (p *Texture) OnInvalidate(callback func()) (remove func()) {
+	return p.child.onInvalidate(callback)
+}
+
+// OnInvalidate registers callback to be called once the view or its texture
+// is released or destroyed, and returns a function unregistering it. callback
+// is called right away if one of them already is.
+func (p *TextureView) OnInvalidate(callback func()) (remove func()) {
+	return p.child.onInvalidate(callback)
+}
+
+// OnInvalidate registers callback to be called once the sampler is released,
+// and returns a function unregistering it. callback is called right away if
+// the sampler already is.
+func (p *Sampler) OnInvalidate(callback func()) (remove func()) {
+	return p.child.onInvalidate(callback)
+}
+
+// OnInvalidate registers callback to be called once the layout is released,
+// and returns a function unregistering it. callback is called right away if
+// the layout already is.
+func (p *BindGroupLayout) OnInvalidate(callback func()) (remove func()) {
+	return p.child.onInvalidate(callback)
+}
@@ -0,0 +1,77 @@
+package wgpu
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type DeviceLostInfo struct {
+	Reason  DeviceLostReason
+	Message string
+}
+
+type deviceLostState struct {
+	info atomic.Pointer[DeviceLostInfo]
+
+	mu          sync.Mutex
+	subscribers []chan DeviceLostInfo
+}
+
+func (s *deviceLostState) lose(info DeviceLostInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.info.CompareAndSwap(nil, &info) {
+		return
+	}
+	for _, ch := range s.subscribers {
+		ch <- info
+		close(ch)
+	}
+	s.subscribers = nil
+}
+
+// Lost returns a channel that receives why the device was lost and is then
+// closed. Every call returns a new channel, so any number of goroutines can
+// wait for the loss.
+//
+// Once the device is lost, methods of the device and of the objects created
+// from it return an error matching ErrDeviceLost without calling into
+// native, or do nothing if they don't return an error.
+func (p *Device) Lost() <-chan DeviceLostInfo {
+	ch := make(chan DeviceLostInfo, 1)
+
+	p.lost.mu.Lock()
+	defer p.lost.mu.Unlock()
+
+	if info := p.lost.info.Load(); info != nil {
+		ch <- *info
+		close(ch)
+		return ch
+	}
+	p.lost.subscribers = append(p.lost.subscribers, ch)
+	return ch
+}
+
+func (p *Device) isLost() bool {
+	return p.lost.info.Load() != nil
+}
+
+// lostError returns the error of op once the device is lost, nil before.
+func (p *Device) lostError(op string, label string) error {
+	info := p.lost.info.Load()
+	if info == nil {
+		return nil
+	}
+
+	message := "device lost: " + info.Reason.String()
+	if info.Message != "" {
+		message += ": " + info.Message
+	}
+	return &Error{
+		Type:    ErrorType_DeviceLost,
+		Op:      op,
+		Label:   label,
+		Message: message,
+	}
+}
@@ -1511,6 +1511,1555 @@ func (v TextureFormat) String() string {
 	}
 }
 
+// TextureFormatInfo is the metadata of a texture format.
+type TextureFormatInfo struct {
+	// BlockWidth and BlockHeight are the size of the blocks of the format in
+	// texels, 1 for uncompressed formats.
+	BlockWidth  uint32
+	BlockHeight uint32
+	// BlockSize is the size of a block in bytes in copies between buffers and
+	// textures, zero for depth formats that can't be copied and for combined
+	// depth stencil formats, whose aspects must be copied one at a time.
+	BlockSize  uint32
+	Components uint32
+	// Color, Depth and Stencil are the aspects of the format.
+	Color   bool
+	Depth   bool
+	Stencil bool
+	// SampleType is the sample type of the format, of its depth aspect for
+	// combined depth stencil formats.
+	SampleType TextureSampleType
+	Srgb       bool
+	Compressed bool
+	// Filterable is set if the format can be sampled with a filtering
+	// sampler. 32-bit float formats can be too with FeatureName_Float32Filterable.
+	Filterable bool
+	// SrgbFormat and LinearFormat are the sRGB and linear variants of the
+	// format, SrgbFormat is TextureFormat_Undefined if it has none. Either is
+	// the format itself.
+	SrgbFormat   TextureFormat
+	LinearFormat TextureFormat
+	// RequiredFeature is the feature a device needs to create textures of the
+	// format, FeatureName_Undefined if none is.
+	RequiredFeature FeatureName
+}
+
+func (v TextureFormat) Info() TextureFormatInfo {
+	switch v {
+	case TextureFormat_R8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    1,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_R8Unorm,
+		}
+	case TextureFormat_R8Snorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    1,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_R8Snorm,
+		}
+	case TextureFormat_R8Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    1,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_R8Uint,
+		}
+	case TextureFormat_R8Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    1,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_R8Sint,
+		}
+	case TextureFormat_R16Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_R16Uint,
+		}
+	case TextureFormat_R16Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_R16Sint,
+		}
+	case TextureFormat_R16Float:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_R16Float,
+		}
+	case TextureFormat_RG8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RG8Unorm,
+		}
+	case TextureFormat_RG8Snorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RG8Snorm,
+		}
+	case TextureFormat_RG8Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RG8Uint,
+		}
+	case TextureFormat_RG8Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RG8Sint,
+		}
+	case TextureFormat_R32Float:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_UnfilterableFloat,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_R32Float,
+		}
+	case TextureFormat_R32Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_R32Uint,
+		}
+	case TextureFormat_R32Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   1,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_R32Sint,
+		}
+	case TextureFormat_RG16Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RG16Uint,
+		}
+	case TextureFormat_RG16Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RG16Sint,
+		}
+	case TextureFormat_RG16Float:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RG16Float,
+		}
+	case TextureFormat_RGBA8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			SrgbFormat:   TextureFormat_RGBA8UnormSrgb,
+			LinearFormat: TextureFormat_RGBA8Unorm,
+		}
+	case TextureFormat_RGBA8UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         true,
+			Compressed:   false,
+			Filterable:   true,
+			SrgbFormat:   TextureFormat_RGBA8UnormSrgb,
+			LinearFormat: TextureFormat_RGBA8Unorm,
+		}
+	case TextureFormat_RGBA8Snorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RGBA8Snorm,
+		}
+	case TextureFormat_RGBA8Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RGBA8Uint,
+		}
+	case TextureFormat_RGBA8Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RGBA8Sint,
+		}
+	case TextureFormat_BGRA8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			SrgbFormat:   TextureFormat_BGRA8UnormSrgb,
+			LinearFormat: TextureFormat_BGRA8Unorm,
+		}
+	case TextureFormat_BGRA8UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         true,
+			Compressed:   false,
+			Filterable:   true,
+			SrgbFormat:   TextureFormat_BGRA8UnormSrgb,
+			LinearFormat: TextureFormat_BGRA8Unorm,
+		}
+	case TextureFormat_RGB10A2Unorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RGB10A2Unorm,
+		}
+	case TextureFormat_RG11B10Ufloat:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   3,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RG11B10Ufloat,
+		}
+	case TextureFormat_RGB9E5Ufloat:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   3,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RGB9E5Ufloat,
+		}
+	case TextureFormat_RG32Float:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    8,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_UnfilterableFloat,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RG32Float,
+		}
+	case TextureFormat_RG32Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    8,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RG32Uint,
+		}
+	case TextureFormat_RG32Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    8,
+			Components:   2,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RG32Sint,
+		}
+	case TextureFormat_RGBA16Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    8,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RGBA16Uint,
+		}
+	case TextureFormat_RGBA16Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    8,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RGBA16Sint,
+		}
+	case TextureFormat_RGBA16Float:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    8,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Float,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   true,
+			LinearFormat: TextureFormat_RGBA16Float,
+		}
+	case TextureFormat_RGBA32Float:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    16,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_UnfilterableFloat,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RGBA32Float,
+		}
+	case TextureFormat_RGBA32Uint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    16,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RGBA32Uint,
+		}
+	case TextureFormat_RGBA32Sint:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    16,
+			Components:   4,
+			Color:        true,
+			Depth:        false,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Sint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_RGBA32Sint,
+		}
+	case TextureFormat_Stencil8:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    1,
+			Components:   1,
+			Color:        false,
+			Depth:        false,
+			Stencil:      true,
+			SampleType:   TextureSampleType_Uint,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_Stencil8,
+		}
+	case TextureFormat_Depth16Unorm:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    2,
+			Components:   1,
+			Color:        false,
+			Depth:        true,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Depth,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_Depth16Unorm,
+		}
+	case TextureFormat_Depth24Plus:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    0,
+			Components:   1,
+			Color:        false,
+			Depth:        true,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Depth,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_Depth24Plus,
+		}
+	case TextureFormat_Depth24PlusStencil8:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    0,
+			Components:   2,
+			Color:        false,
+			Depth:        true,
+			Stencil:      true,
+			SampleType:   TextureSampleType_Depth,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_Depth24PlusStencil8,
+		}
+	case TextureFormat_Depth32Float:
+		return TextureFormatInfo{
+			BlockWidth:   1,
+			BlockHeight:  1,
+			BlockSize:    4,
+			Components:   1,
+			Color:        false,
+			Depth:        true,
+			Stencil:      false,
+			SampleType:   TextureSampleType_Depth,
+			Srgb:         false,
+			Compressed:   false,
+			Filterable:   false,
+			LinearFormat: TextureFormat_Depth32Float,
+		}
+	case TextureFormat_Depth32FloatStencil8:
+		return TextureFormatInfo{
+			BlockWidth:      1,
+			BlockHeight:     1,
+			BlockSize:       0,
+			Components:      2,
+			Color:           false,
+			Depth:           true,
+			Stencil:         true,
+			SampleType:      TextureSampleType_Depth,
+			Srgb:            false,
+			Compressed:      false,
+			Filterable:      false,
+			LinearFormat:    TextureFormat_Depth32FloatStencil8,
+			RequiredFeature: FeatureName_Depth32FloatStencil8,
+		}
+	case TextureFormat_BC1RGBAUnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC1RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC1RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC1RGBAUnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC1RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC1RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC2RGBAUnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC2RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC2RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC2RGBAUnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC2RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC2RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC3RGBAUnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC3RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC3RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC3RGBAUnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC3RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC3RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC4RUnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      1,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_BC4RUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC4RSnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      1,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_BC4RSnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC5RGUnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      2,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_BC5RGUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC5RGSnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      2,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_BC5RGSnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC6HRGBUfloat:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      3,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_BC6HRGBUfloat,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC6HRGBFloat:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      3,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_BC6HRGBFloat,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC7RGBAUnorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC7RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC7RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_BC7RGBAUnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_BC7RGBAUnormSrgb,
+			LinearFormat:    TextureFormat_BC7RGBAUnorm,
+			RequiredFeature: FeatureName_TextureCompressionBC,
+		}
+	case TextureFormat_ETC2RGB8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      3,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ETC2RGB8UnormSrgb,
+			LinearFormat:    TextureFormat_ETC2RGB8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_ETC2RGB8UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      3,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ETC2RGB8UnormSrgb,
+			LinearFormat:    TextureFormat_ETC2RGB8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_ETC2RGB8A1Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ETC2RGB8A1UnormSrgb,
+			LinearFormat:    TextureFormat_ETC2RGB8A1Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_ETC2RGB8A1UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ETC2RGB8A1UnormSrgb,
+			LinearFormat:    TextureFormat_ETC2RGB8A1Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_ETC2RGBA8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ETC2RGBA8UnormSrgb,
+			LinearFormat:    TextureFormat_ETC2RGBA8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_ETC2RGBA8UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ETC2RGBA8UnormSrgb,
+			LinearFormat:    TextureFormat_ETC2RGBA8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_EACR11Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      1,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_EACR11Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_EACR11Snorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       8,
+			Components:      1,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_EACR11Snorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_EACRG11Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      2,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_EACRG11Unorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_EACRG11Snorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      2,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			LinearFormat:    TextureFormat_EACRG11Snorm,
+			RequiredFeature: FeatureName_TextureCompressionETC2,
+		}
+	case TextureFormat_ASTC4x4Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC4x4UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC4x4Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC4x4UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      4,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC4x4UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC4x4Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC5x4Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      5,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC5x4UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC5x4Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC5x4UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      5,
+			BlockHeight:     4,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC5x4UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC5x4Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC5x5Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      5,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC5x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC5x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC5x5UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      5,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC5x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC5x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC6x5Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      6,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC6x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC6x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC6x5UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      6,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC6x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC6x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC6x6Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      6,
+			BlockHeight:     6,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC6x6UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC6x6Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC6x6UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      6,
+			BlockHeight:     6,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC6x6UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC6x6Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC8x5Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      8,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC8x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC8x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC8x5UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      8,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC8x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC8x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC8x6Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      8,
+			BlockHeight:     6,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC8x6UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC8x6Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC8x6UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      8,
+			BlockHeight:     6,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC8x6UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC8x6Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC8x8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      8,
+			BlockHeight:     8,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC8x8UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC8x8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC8x8UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      8,
+			BlockHeight:     8,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC8x8UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC8x8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x5Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x5UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     5,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x5UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x5Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x6Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     6,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x6UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x6Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x6UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     6,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x6UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x6Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x8Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     8,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x8UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x8UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     8,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x8UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x8Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x10Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     10,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x10UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x10Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC10x10UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      10,
+			BlockHeight:     10,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC10x10UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC10x10Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC12x10Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      12,
+			BlockHeight:     10,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC12x10UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC12x10Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC12x10UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      12,
+			BlockHeight:     10,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC12x10UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC12x10Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC12x12Unorm:
+		return TextureFormatInfo{
+			BlockWidth:      12,
+			BlockHeight:     12,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            false,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC12x12UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC12x12Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	case TextureFormat_ASTC12x12UnormSrgb:
+		return TextureFormatInfo{
+			BlockWidth:      12,
+			BlockHeight:     12,
+			BlockSize:       16,
+			Components:      4,
+			Color:           true,
+			Depth:           false,
+			Stencil:         false,
+			SampleType:      TextureSampleType_Float,
+			Srgb:            true,
+			Compressed:      true,
+			Filterable:      true,
+			SrgbFormat:      TextureFormat_ASTC12x12UnormSrgb,
+			LinearFormat:    TextureFormat_ASTC12x12Unorm,
+			RequiredFeature: FeatureName_TextureCompressionASTC,
+		}
+	default:
+		return TextureFormatInfo{}
+	}
+}
+
 type TextureSampleType uint32
 
 const (
@@ -1,12 +1,55 @@
 package wgpu
 
-import "fmt"
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	ErrValidation  = errors.New("wgpu: validation error")
+	ErrOutOfMemory = errors.New("wgpu: out of memory")
+	ErrInternal    = errors.New("wgpu: internal error")
+	ErrDeviceLost  = errors.New("wgpu: device lost")
+)
 
 type Error struct {
-	Type    ErrorType
+	Type ErrorType
+	// Op is the operation that failed, e.g. "wgpu.(*Device).CreateTexture()".
+	Op string
+	// Label is the label of the object being created, or of the object the
+	// operation was called on. For queue writes it is the label of the
+	// written resource.
+	Label   string
 	Message string
+	// Recorded lists the operations recorded between two collections in
+	// ValidationMode_Deferred, any of which may have caused the error.
+	Recorded []string
 }
 
 func (v *Error) Error() string {
-	return fmt.Sprintf("%s : %s", v.Type.String(), v.Message)
+	s := ""
+	if v.Op != "" {
+		s += v.Op
+		if v.Label != "" {
+			s += " " + strconv.Quote(v.Label)
+		}
+		s += ": "
+	}
+	return s + v.Type.String() + ": " + v.Message
+}
+
+// Is reports whether v matches one of ErrValidation, ErrOutOfMemory,
+// ErrInternal or ErrDeviceLost, so it can be used with errors.Is.
+func (v *Error) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return v.Type == ErrorType_Validation
+	case ErrOutOfMemory:
+		return v.Type == ErrorType_OutOfMemory
+	case ErrInternal:
+		return v.Type == ErrorType_Internal
+	case ErrDeviceLost:
+		return v.Type == ErrorType_DeviceLost
+	}
+	return false
 }
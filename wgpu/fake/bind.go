@@ -0,0 +1,541 @@
+package fake
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+type ShaderModule struct {
+	device *Device
+	label  string
+	// wgsl is the source of a WGSL module, used to look up entry points.
+	wgsl string
+
+	released bool
+}
+
+func (p *Device) CreateShaderModule(descriptor *api.ShaderModuleDescriptor) (api.ShaderModule, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateShaderModule()"
+	if descriptor == nil {
+		descriptor = &api.ShaderModuleDescriptor{}
+	}
+	label := descriptor.Label
+
+	sources := 0
+	if descriptor.SPIRVDescriptor != nil {
+		sources++
+	}
+	if descriptor.WGSLDescriptor != nil {
+		sources++
+	}
+	if descriptor.GLSLDescriptor != nil {
+		sources++
+	}
+	if sources != 1 {
+		return nil, validationError(op, label, "exactly one of SPIRVDescriptor, WGSLDescriptor and GLSLDescriptor must be set")
+	}
+
+	module := &ShaderModule{device: p, label: label}
+	switch {
+	case descriptor.SPIRVDescriptor != nil:
+		code := descriptor.SPIRVDescriptor.Code
+		if len(code) < 4 || len(code)%4 != 0 {
+			return nil, validationError(op, label, "SPIR-V code size %d is not a non-zero multiple of 4", len(code))
+		}
+		if magic := uint32(code[0]) | uint32(code[1])<<8 | uint32(code[2])<<16 | uint32(code[3])<<24; magic != 0x07230203 {
+			return nil, validationError(op, label, "SPIR-V code has wrong magic number %#08x", magic)
+		}
+	case descriptor.WGSLDescriptor != nil:
+		if descriptor.WGSLDescriptor.Code == "" {
+			return nil, validationError(op, label, "WGSL code must not be empty")
+		}
+		module.wgsl = descriptor.WGSLDescriptor.Code
+	case descriptor.GLSLDescriptor != nil:
+		switch descriptor.GLSLDescriptor.ShaderStage {
+		case api.ShaderStage_Vertex, api.ShaderStage_Fragment, api.ShaderStage_Compute:
+		default:
+			return nil, validationError(op, label, "GLSL shader stage must be one of Vertex, Fragment and Compute")
+		}
+		if descriptor.GLSLDescriptor.Code == "" {
+			return nil, validationError(op, label, "GLSL code must not be empty")
+		}
+	}
+	return module, nil
+}
+
+// hasEntryPoint reports whether the module has an entry point named name
+// for stage. Only WGSL modules are checked.
+func (p *ShaderModule) hasEntryPoint(stage, name string) bool {
+	if p.wgsl == "" {
+		return true
+	}
+	re := regexp.MustCompile(`@` + stage + `\b[^{;]*?\bfn\s+` + regexp.QuoteMeta(name) + `\s*\(`)
+	return re.MatchString(p.wgsl)
+}
+
+func (p *ShaderModule) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "ShaderModule", Label: p.label})
+	}
+}
+
+func (p *ShaderModule) Release() {
+	p.released = true
+}
+
+type BindGroupLayout struct {
+	device  *Device
+	label   string
+	entries []api.BindGroupLayoutEntry
+	// auto is set for the layouts of pipelines created without a layout,
+	// whose entries are not known.
+	auto bool
+
+	released bool
+}
+
+// bindingCounts counts the bindings of a set of layouts, to check them
+// against the per stage and per pipeline layout limits.
+type bindingCounts struct {
+	dynamicUniformBuffers uint32
+	dynamicStorageBuffers uint32
+	perStage              [3]struct {
+		uniformBuffers  uint32
+		storageBuffers  uint32
+		sampledTextures uint32
+		samplers        uint32
+		storageTextures uint32
+	}
+}
+
+func (c *bindingCounts) add(entries []api.BindGroupLayoutEntry) {
+	for _, e := range entries {
+		if e.Buffer.HasDynamicOffset {
+			if e.Buffer.Type == api.BufferBindingType_Uniform {
+				c.dynamicUniformBuffers++
+			} else {
+				c.dynamicStorageBuffers++
+			}
+		}
+		for i, stage := range []api.ShaderStage{api.ShaderStage_Vertex, api.ShaderStage_Fragment, api.ShaderStage_Compute} {
+			if e.Visibility&stage == 0 {
+				continue
+			}
+			s := &c.perStage[i]
+			switch {
+			case e.Buffer.Type == api.BufferBindingType_Uniform:
+				s.uniformBuffers++
+			case e.Buffer.Type != api.BufferBindingType_Undefined:
+				s.storageBuffers++
+			case e.Sampler.Type != api.SamplerBindingType_Undefined:
+				s.samplers++
+			case e.Texture.SampleType != api.TextureSampleType_Undefined:
+				s.sampledTextures++
+			case e.StorageTexture.Access != api.StorageTextureAccess_Undefined:
+				s.storageTextures++
+			}
+		}
+	}
+}
+
+func (c *bindingCounts) check(op, label string, limits *api.Limits) error {
+	switch {
+	case c.dynamicUniformBuffers > limits.MaxDynamicUniformBuffersPerPipelineLayout:
+		return validationError(op, label, "%d dynamic uniform buffers exceed the MaxDynamicUniformBuffersPerPipelineLayout limit of %d", c.dynamicUniformBuffers, limits.MaxDynamicUniformBuffersPerPipelineLayout)
+	case c.dynamicStorageBuffers > limits.MaxDynamicStorageBuffersPerPipelineLayout:
+		return validationError(op, label, "%d dynamic storage buffers exceed the MaxDynamicStorageBuffersPerPipelineLayout limit of %d", c.dynamicStorageBuffers, limits.MaxDynamicStorageBuffersPerPipelineLayout)
+	}
+	for _, s := range c.perStage {
+		switch {
+		case s.uniformBuffers > limits.MaxUniformBuffersPerShaderStage:
+			return validationError(op, label, "%d uniform buffers exceed the MaxUniformBuffersPerShaderStage limit of %d", s.uniformBuffers, limits.MaxUniformBuffersPerShaderStage)
+		case s.storageBuffers > limits.MaxStorageBuffersPerShaderStage:
+			return validationError(op, label, "%d storage buffers exceed the MaxStorageBuffersPerShaderStage limit of %d", s.storageBuffers, limits.MaxStorageBuffersPerShaderStage)
+		case s.sampledTextures > limits.MaxSampledTexturesPerShaderStage:
+			return validationError(op, label, "%d sampled textures exceed the MaxSampledTexturesPerShaderStage limit of %d", s.sampledTextures, limits.MaxSampledTexturesPerShaderStage)
+		case s.samplers > limits.MaxSamplersPerShaderStage:
+			return validationError(op, label, "%d samplers exceed the MaxSamplersPerShaderStage limit of %d", s.samplers, limits.MaxSamplersPerShaderStage)
+		case s.storageTextures > limits.MaxStorageTexturesPerShaderStage:
+			return validationError(op, label, "%d storage textures exceed the MaxStorageTexturesPerShaderStage limit of %d", s.storageTextures, limits.MaxStorageTexturesPerShaderStage)
+		}
+	}
+	return nil
+}
+
+func (p *Device) CreateBindGroupLayout(descriptor *api.BindGroupLayoutDescriptor) (api.BindGroupLayout, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateBindGroupLayout()"
+	if descriptor == nil {
+		descriptor = &api.BindGroupLayoutDescriptor{}
+	}
+	label := descriptor.Label
+
+	entries := append([]api.BindGroupLayoutEntry(nil), descriptor.Entries...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Binding < entries[j].Binding })
+
+	for i, e := range entries {
+		if i > 0 && entries[i-1].Binding == e.Binding {
+			return nil, validationError(op, label, "binding %d is used more than once", e.Binding)
+		}
+		if e.Binding >= p.limits.MaxBindingsPerBindGroup {
+			return nil, validationError(op, label, "binding %d exceeds the MaxBindingsPerBindGroup limit of %d", e.Binding, p.limits.MaxBindingsPerBindGroup)
+		}
+		if e.Visibility&^(api.ShaderStage_Vertex|api.ShaderStage_Fragment|api.ShaderStage_Compute) != 0 {
+			return nil, validationError(op, label, "binding %d has unknown visibility %#x", e.Binding, uint32(e.Visibility))
+		}
+
+		types := 0
+		if e.Buffer.Type != api.BufferBindingType_Undefined {
+			types++
+		}
+		if e.Sampler.Type != api.SamplerBindingType_Undefined {
+			types++
+		}
+		if e.Texture.SampleType != api.TextureSampleType_Undefined {
+			types++
+		}
+		if e.StorageTexture.Access != api.StorageTextureAccess_Undefined {
+			types++
+		}
+		if types != 1 {
+			return nil, validationError(op, label, "binding %d must set exactly one of Buffer, Sampler, Texture and StorageTexture", e.Binding)
+		}
+
+		switch {
+		case e.Buffer.Type == api.BufferBindingType_Storage && e.Visibility&api.ShaderStage_Vertex != 0:
+			return nil, validationError(op, label, "binding %d: writable storage buffers can't be visible to the vertex stage", e.Binding)
+		case e.StorageTexture.Access != api.StorageTextureAccess_Undefined && e.Visibility&api.ShaderStage_Vertex != 0:
+			return nil, validationError(op, label, "binding %d: storage textures can't be visible to the vertex stage", e.Binding)
+		case e.StorageTexture.Access != api.StorageTextureAccess_Undefined && formats[e.StorageTexture.Format].blockSize == 0:
+			return nil, validationError(op, label, "binding %d: format %s can't be used for a storage texture", e.Binding, e.StorageTexture.Format)
+		case e.StorageTexture.ViewDimension == api.TextureViewDimension_Cube || e.StorageTexture.ViewDimension == api.TextureViewDimension_CubeArray:
+			return nil, validationError(op, label, "binding %d: storage textures can't be cube views", e.Binding)
+		case e.Texture.Multisampled && e.Texture.ViewDimension != api.TextureViewDimension_Undefined && e.Texture.ViewDimension != api.TextureViewDimension_2D:
+			return nil, validationError(op, label, "binding %d: multisampled textures must be 2D views", e.Binding)
+		}
+	}
+
+	var counts bindingCounts
+	counts.add(entries)
+	if err := counts.check(op, label, &p.limits); err != nil {
+		return nil, err
+	}
+
+	return &BindGroupLayout{device: p, label: label, entries: entries}, nil
+}
+
+// compatible reports whether bind groups created with layout can be used
+// where p is expected. Layouts created from equal descriptors are
+// compatible, pipeline created layouts only with themselves.
+func (p *BindGroupLayout) compatible(layout *BindGroupLayout) bool {
+	if p == layout {
+		return true
+	}
+	if p.auto || layout.auto {
+		return false
+	}
+	return reflect.DeepEqual(p.entries, layout.entries)
+}
+
+func (p *BindGroupLayout) entry(binding uint32) (api.BindGroupLayoutEntry, bool) {
+	i := sort.Search(len(p.entries), func(i int) bool { return p.entries[i].Binding >= binding })
+	if i < len(p.entries) && p.entries[i].Binding == binding {
+		return p.entries[i], true
+	}
+	return api.BindGroupLayoutEntry{}, false
+}
+
+func (p *BindGroupLayout) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "BindGroupLayout", Label: p.label})
+	}
+}
+
+func (p *BindGroupLayout) Release() {
+	p.released = true
+}
+
+// dynamicBinding is a buffer binding of a bind group that takes a dynamic
+// offset.
+type dynamicBinding struct {
+	binding   uint32
+	buffer    *Buffer
+	offset    uint64
+	size      uint64
+	alignment uint32
+}
+
+type BindGroup struct {
+	device  *Device
+	label   string
+	layout  *BindGroupLayout
+	dynamic []dynamicBinding
+	// buffers and textures are the resources the bind group references.
+	buffers  []*Buffer
+	textures []*Texture
+
+	released bool
+}
+
+func (p *Device) CreateBindGroup(descriptor *api.BindGroupDescriptor) (api.BindGroup, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateBindGroup()"
+	if descriptor == nil {
+		descriptor = &api.BindGroupDescriptor{}
+	}
+	label := descriptor.Label
+
+	layout := as[BindGroupLayout](descriptor.Layout, "BindGroupLayout")
+	if layout == nil {
+		return nil, validationError(op, label, "layout must be set")
+	}
+	layout.checkReleased()
+	if err := p.checkDevice(op, label, layout.device, "layout"); err != nil {
+		return nil, err
+	}
+	if !layout.auto && len(descriptor.Entries) != len(layout.entries) {
+		return nil, validationError(op, label, "%d entries don't match the %d entries of the layout", len(descriptor.Entries), len(layout.entries))
+	}
+
+	bindGroup := &BindGroup{device: p, label: label, layout: layout}
+	seen := map[uint32]bool{}
+	for _, e := range descriptor.Entries {
+		if seen[e.Binding] {
+			return nil, validationError(op, label, "binding %d is used more than once", e.Binding)
+		}
+		seen[e.Binding] = true
+
+		buffer := as[Buffer](e.Buffer, "Buffer")
+		sampler := as[Sampler](e.Sampler, "Sampler")
+		view := as[TextureView](e.TextureView, "TextureView")
+		resources := 0
+		if buffer != nil {
+			buffer.checkReleased()
+			if err := p.checkDevice(op, label, buffer.device, "buffer"); err != nil {
+				return nil, err
+			}
+			bindGroup.buffers = append(bindGroup.buffers, buffer)
+			resources++
+		}
+		if sampler != nil {
+			sampler.checkReleased()
+			if err := p.checkDevice(op, label, sampler.device, "sampler"); err != nil {
+				return nil, err
+			}
+			resources++
+		}
+		if view != nil {
+			view.checkReleased()
+			if err := p.checkDevice(op, label, view.texture.device, "texture view"); err != nil {
+				return nil, err
+			}
+			bindGroup.textures = append(bindGroup.textures, view.texture)
+			resources++
+		}
+		if resources != 1 {
+			return nil, validationError(op, label, "binding %d must set exactly one of Buffer, Sampler and TextureView", e.Binding)
+		}
+		if layout.auto {
+			continue
+		}
+
+		le, ok := layout.entry(e.Binding)
+		if !ok {
+			return nil, validationError(op, label, "binding %d is not in the layout", e.Binding)
+		}
+		switch {
+		case le.Buffer.Type != api.BufferBindingType_Undefined:
+			if buffer == nil {
+				return nil, validationError(op, label, "binding %d must be a buffer", e.Binding)
+			}
+			if err := p.checkBufferBinding(op, label, le, e, buffer, bindGroup); err != nil {
+				return nil, err
+			}
+		case le.Sampler.Type != api.SamplerBindingType_Undefined:
+			if sampler == nil {
+				return nil, validationError(op, label, "binding %d must be a sampler", e.Binding)
+			}
+			switch le.Sampler.Type {
+			case api.SamplerBindingType_Comparison:
+				if !sampler.comparison {
+					return nil, validationError(op, label, "binding %d must be a comparison sampler", e.Binding)
+				}
+			case api.SamplerBindingType_NonFiltering:
+				if sampler.comparison || sampler.filtering {
+					return nil, validationError(op, label, "binding %d must be a non-filtering sampler", e.Binding)
+				}
+			default:
+				if sampler.comparison {
+					return nil, validationError(op, label, "binding %d must not be a comparison sampler", e.Binding)
+				}
+			}
+		case le.Texture.SampleType != api.TextureSampleType_Undefined:
+			if view == nil {
+				return nil, validationError(op, label, "binding %d must be a texture view", e.Binding)
+			}
+			dimension := le.Texture.ViewDimension
+			if dimension == api.TextureViewDimension_Undefined {
+				dimension = api.TextureViewDimension_2D
+			}
+			switch {
+			case view.texture.usage&api.TextureUsage_TextureBinding == 0:
+				return nil, validationError(op, label, "binding %d: texture usage doesn't contain TextureBinding", e.Binding)
+			case view.dimension != dimension:
+				return nil, validationError(op, label, "binding %d: view dimension %s doesn't match %s", e.Binding, view.dimension, dimension)
+			case le.Texture.Multisampled != (view.texture.sampleCount > 1):
+				return nil, validationError(op, label, "binding %d: texture multisampling doesn't match the layout", e.Binding)
+			}
+		case le.StorageTexture.Access != api.StorageTextureAccess_Undefined:
+			if view == nil {
+				return nil, validationError(op, label, "binding %d must be a texture view", e.Binding)
+			}
+			dimension := le.StorageTexture.ViewDimension
+			if dimension == api.TextureViewDimension_Undefined {
+				dimension = api.TextureViewDimension_2D
+			}
+			switch {
+			case view.texture.usage&api.TextureUsage_StorageBinding == 0:
+				return nil, validationError(op, label, "binding %d: texture usage doesn't contain StorageBinding", e.Binding)
+			case view.format != le.StorageTexture.Format:
+				return nil, validationError(op, label, "binding %d: view format %s doesn't match %s", e.Binding, view.format, le.StorageTexture.Format)
+			case view.dimension != dimension:
+				return nil, validationError(op, label, "binding %d: view dimension %s doesn't match %s", e.Binding, view.dimension, dimension)
+			case view.mipLevelCount != 1:
+				return nil, validationError(op, label, "binding %d: storage texture view must have a single mip level", e.Binding)
+			}
+		}
+	}
+	sort.Slice(bindGroup.dynamic, func(i, j int) bool { return bindGroup.dynamic[i].binding < bindGroup.dynamic[j].binding })
+	return bindGroup, nil
+}
+
+func (p *Device) checkBufferBinding(op, label string, le api.BindGroupLayoutEntry, e api.BindGroupEntry, buffer *Buffer, bindGroup *BindGroup) error {
+	size := e.Size
+	if size == 0 || size == api.WholeSize {
+		if e.Offset > buffer.size {
+			return validationError(op, label, "binding %d: offset %d is out of bounds of buffer of size %d", e.Binding, e.Offset, buffer.size)
+		}
+		size = buffer.size - e.Offset
+	}
+
+	usage, alignment, maxSize := api.BufferUsage_Storage, p.limits.MinStorageBufferOffsetAlignment, p.limits.MaxStorageBufferBindingSize
+	if le.Buffer.Type == api.BufferBindingType_Uniform {
+		usage, alignment, maxSize = api.BufferUsage_Uniform, p.limits.MinUniformBufferOffsetAlignment, p.limits.MaxUniformBufferBindingSize
+	}
+	switch {
+	case buffer.usage&usage == 0:
+		return validationError(op, label, "binding %d: buffer usage doesn't contain %s", e.Binding, usage)
+	case e.Offset%uint64(alignment) != 0:
+		return validationError(op, label, "binding %d: offset %d is not a multiple of %d", e.Binding, e.Offset, alignment)
+	case size == 0:
+		return validationError(op, label, "binding %d: binding size must not be zero", e.Binding)
+	case size > maxSize:
+		return validationError(op, label, "binding %d: size %d exceeds the binding size limit of %d", e.Binding, size, maxSize)
+	case usage == api.BufferUsage_Storage && size%4 != 0:
+		return validationError(op, label, "binding %d: storage binding size %d is not a multiple of 4", e.Binding, size)
+	case size < le.Buffer.MinBindingSize:
+		return validationError(op, label, "binding %d: size %d is less than the MinBindingSize of %d", e.Binding, size, le.Buffer.MinBindingSize)
+	}
+	if err := buffer.checkRange(op, e.Offset, size); err != nil {
+		return err
+	}
+
+	if le.Buffer.HasDynamicOffset {
+		bindGroup.dynamic = append(bindGroup.dynamic, dynamicBinding{
+			binding:   e.Binding,
+			buffer:    buffer,
+			offset:    e.Offset,
+			size:      size,
+			alignment: alignment,
+		})
+	}
+	return nil
+}
+
+func (p *BindGroup) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "BindGroup", Label: p.label})
+	}
+}
+
+func (p *BindGroup) Release() {
+	p.released = true
+}
+
+type PipelineLayout struct {
+	device             *Device
+	label              string
+	bindGroupLayouts   []*BindGroupLayout
+	pushConstantRanges []api.PushConstantRange
+
+	released bool
+}
+
+func (p *Device) CreatePipelineLayout(descriptor *api.PipelineLayoutDescriptor) (api.PipelineLayout, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreatePipelineLayout()"
+	if descriptor == nil {
+		descriptor = &api.PipelineLayoutDescriptor{}
+	}
+	label := descriptor.Label
+
+	if uint32(len(descriptor.BindGroupLayouts)) > p.limits.MaxBindGroups {
+		return nil, validationError(op, label, "%d bind group layouts exceed the MaxBindGroups limit of %d", len(descriptor.BindGroupLayouts), p.limits.MaxBindGroups)
+	}
+
+	layout := &PipelineLayout{device: p, label: label}
+	var counts bindingCounts
+	for i, v := range descriptor.BindGroupLayouts {
+		bindGroupLayout := as[BindGroupLayout](v, "BindGroupLayout")
+		if bindGroupLayout == nil {
+			return nil, validationError(op, label, "bind group layout %d must be set", i)
+		}
+		bindGroupLayout.checkReleased()
+		if err := p.checkDevice(op, label, bindGroupLayout.device, "bind group layout"); err != nil {
+			return nil, err
+		}
+		counts.add(bindGroupLayout.entries)
+		layout.bindGroupLayouts = append(layout.bindGroupLayouts, bindGroupLayout)
+	}
+	if err := counts.check(op, label, &p.limits); err != nil {
+		return nil, err
+	}
+
+	var stages api.ShaderStage
+	for _, r := range descriptor.PushConstantRanges {
+		switch {
+		case r.Start%api.PushConstantAlignment != 0 || r.End%api.PushConstantAlignment != 0:
+			return nil, validationError(op, label, "push constant range %d..%d is not aligned to %d", r.Start, r.End, api.PushConstantAlignment)
+		case r.Start >= r.End:
+			return nil, validationError(op, label, "push constant range %d..%d is empty", r.Start, r.End)
+		case r.End > p.limits.MaxPushConstantSize:
+			return nil, validationError(op, label, "push constant range %d..%d exceeds the MaxPushConstantSize limit of %d", r.Start, r.End, p.limits.MaxPushConstantSize)
+		case r.Stages&stages != 0:
+			return nil, validationError(op, label, "push constant ranges overlap in stages %s", r.Stages&stages)
+		}
+		stages |= r.Stages
+	}
+	layout.pushConstantRanges = append(layout.pushConstantRanges, descriptor.PushConstantRanges...)
+	return layout, nil
+}
+
+func (p *PipelineLayout) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "PipelineLayout", Label: p.label})
+	}
+}
+
+func (p *PipelineLayout) Release() {
+	p.released = true
+}
+
+var (
+	_ api.ShaderModule    = (*ShaderModule)(nil)
+	_ api.BindGroupLayout = (*BindGroupLayout)(nil)
+	_ api.BindGroup       = (*BindGroup)(nil)
+	_ api.PipelineLayout  = (*PipelineLayout)(nil)
+)
@@ -0,0 +1,287 @@
+package fake
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+const allBufferUsages = api.BufferUsage_MapRead | api.BufferUsage_MapWrite |
+	api.BufferUsage_CopySrc | api.BufferUsage_CopyDst | api.BufferUsage_Index |
+	api.BufferUsage_Vertex | api.BufferUsage_Uniform | api.BufferUsage_Storage |
+	api.BufferUsage_Indirect | api.BufferUsage_QueryResolve
+
+type bufferMapState int
+
+const (
+	bufferUnmapped bufferMapState = iota
+	bufferMapPending
+	bufferMapped
+)
+
+type Buffer struct {
+	device *Device
+	label  string
+	usage  api.BufferUsage
+	size   uint64
+	data   []byte
+
+	mapState    bufferMapState
+	mapMode     api.MapMode
+	mapOffset   uint64
+	mapSize     uint64
+	mapCallback api.BufferMapCallback
+	// mapID tells the pending mapping apart from aborted ones.
+	mapID uint64
+
+	destroyed bool
+	released  bool
+}
+
+func (p *Device) CreateBuffer(descriptor *api.BufferDescriptor) (api.Buffer, error) {
+	p.checkReleased()
+	if descriptor == nil {
+		descriptor = &api.BufferDescriptor{}
+	}
+	buffer, err := p.createBuffer("fake.(*Device).CreateBuffer()", descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+func (p *Device) createBuffer(op string, descriptor *api.BufferDescriptor) (*Buffer, error) {
+	label := descriptor.Label
+	usage := descriptor.Usage
+	switch {
+	case usage == api.BufferUsage_None:
+		return nil, validationError(op, label, "usage must not be empty")
+	case usage&^allBufferUsages != 0:
+		return nil, validationError(op, label, "unknown usage %#x", uint32(usage&^allBufferUsages))
+	case usage&api.BufferUsage_MapRead != 0 && usage&^(api.BufferUsage_MapRead|api.BufferUsage_CopyDst) != 0:
+		return nil, validationError(op, label, "usage MapRead can only be combined with CopyDst")
+	case usage&api.BufferUsage_MapWrite != 0 && usage&^(api.BufferUsage_MapWrite|api.BufferUsage_CopySrc) != 0:
+		return nil, validationError(op, label, "usage MapWrite can only be combined with CopySrc")
+	case descriptor.Size > p.limits.MaxBufferSize:
+		return nil, validationError(op, label, "size %d exceeds the MaxBufferSize limit of %d", descriptor.Size, p.limits.MaxBufferSize)
+	case descriptor.MappedAtCreation && descriptor.Size%api.CopyBufferAlignment != 0:
+		return nil, validationError(op, label, "size %d of a buffer mapped at creation must be a multiple of %d", descriptor.Size, api.CopyBufferAlignment)
+	}
+
+	buffer := &Buffer{
+		device: p,
+		label:  label,
+		usage:  usage,
+		size:   descriptor.Size,
+		data:   make([]byte, descriptor.Size),
+	}
+	if descriptor.MappedAtCreation {
+		buffer.mapState = bufferMapped
+		buffer.mapMode = api.MapMode_Write
+		buffer.mapSize = descriptor.Size
+	}
+	return buffer, nil
+}
+
+func (p *Device) CreateBufferInit(descriptor *api.BufferInitDescriptor) (api.Buffer, error) {
+	p.checkReleased()
+	if descriptor == nil {
+		panic("got nil descriptor")
+	}
+
+	const alignMask = api.CopyBufferAlignment - 1
+	size := (uint64(len(descriptor.Contents)) + alignMask) &^ alignMask
+	buffer, err := p.createBuffer("fake.(*Device).CreateBufferInit()", &api.BufferDescriptor{
+		Label: descriptor.Label,
+		Usage: descriptor.Usage,
+		Size:  size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	copy(buffer.data, descriptor.Contents)
+	return buffer, nil
+}
+
+func (p *Buffer) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "Buffer", Label: p.label})
+	}
+}
+
+// checkUsable returns an error if the buffer can't be used by op, because
+// it is destroyed or mapped.
+func (p *Buffer) checkUsable(op string) error {
+	switch {
+	case p.destroyed:
+		return validationError(op, p.label, "buffer is destroyed")
+	case p.mapState != bufferUnmapped:
+		return validationError(op, p.label, "buffer is mapped")
+	}
+	return nil
+}
+
+// checkRange returns an error if [offset, offset+size) is not within the
+// buffer.
+func (p *Buffer) checkRange(op string, offset, size uint64) error {
+	if offset > p.size || size > p.size-offset {
+		return validationError(op, p.label, "range %d..%d is out of bounds of buffer of size %d", offset, offset+size, p.size)
+	}
+	return nil
+}
+
+func (p *Buffer) Destroy() {
+	p.checkReleased()
+	p.device.mu.Lock()
+	callback := p.abortMap(api.BufferMapAsyncStatus_DestroyedBeforeCallback)
+	p.destroyed = true
+	p.data = nil
+	p.mapState = bufferUnmapped
+	p.device.mu.Unlock()
+
+	callback()
+}
+
+// abortMap cancels a pending mapping. It returns a function that calls the
+// map callback with status, to be called once p.device.mu is unlocked.
+func (p *Buffer) abortMap(status api.BufferMapAsyncStatus) func() {
+	if p.mapState != bufferMapPending {
+		return func() {}
+	}
+	callback := p.mapCallback
+	p.mapCallback = nil
+	p.mapState = bufferUnmapped
+	return func() { callback(status) }
+}
+
+// GetMappedRange returns the mapped memory in [offset, offset+size), or nil
+// if the range is not mapped.
+func (p *Buffer) GetMappedRange(offset, size uint) []byte {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if p.mapState != bufferMapped {
+		return nil
+	}
+	if size == api.WholeMapSize {
+		if uint64(offset) > p.mapOffset+p.mapSize {
+			return nil
+		}
+		size = uint(p.mapOffset + p.mapSize - uint64(offset))
+	}
+	if offset%api.MapAlignment != 0 || size%4 != 0 {
+		return nil
+	}
+	if uint64(offset) < p.mapOffset || uint64(offset)+uint64(size) > p.mapOffset+p.mapSize {
+		return nil
+	}
+	return p.data[offset : offset+size : offset+size]
+}
+
+func (p *Buffer) GetSize() uint64 {
+	p.checkReleased()
+	return p.size
+}
+
+func (p *Buffer) GetUsage() api.BufferUsage {
+	p.checkReleased()
+	return p.usage
+}
+
+func (p *Buffer) MapAsync(mode api.MapMode, offset uint64, size uint64, callback api.BufferMapCallback) error {
+	p.checkReleased()
+	const op = "fake.(*Buffer).MapAsync()"
+
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if size == api.WholeSize {
+		if offset > p.size {
+			size = 0
+		} else {
+			size = p.size - offset
+		}
+	}
+
+	var err error
+	switch {
+	case p.destroyed:
+		err = validationError(op, p.label, "buffer is destroyed")
+	case p.mapState == bufferMapPending:
+		err = validationError(op, p.label, "buffer is already being mapped")
+	case p.mapState == bufferMapped:
+		err = validationError(op, p.label, "buffer is already mapped")
+	case mode != api.MapMode_Read && mode != api.MapMode_Write:
+		err = validationError(op, p.label, "mode must be either Read or Write")
+	case mode == api.MapMode_Read && p.usage&api.BufferUsage_MapRead == 0:
+		err = validationError(op, p.label, "buffer usage doesn't contain MapRead")
+	case mode == api.MapMode_Write && p.usage&api.BufferUsage_MapWrite == 0:
+		err = validationError(op, p.label, "buffer usage doesn't contain MapWrite")
+	case offset%api.MapAlignment != 0:
+		err = validationError(op, p.label, "offset %d is not a multiple of %d", offset, api.MapAlignment)
+	case size%4 != 0:
+		err = validationError(op, p.label, "size %d is not a multiple of 4", size)
+	default:
+		err = p.checkRange(op, offset, size)
+	}
+	if err != nil {
+		p.device.pending = append(p.device.pending, func() {
+			callback(api.BufferMapAsyncStatus_ValidationError)
+		})
+		return err
+	}
+
+	p.mapState = bufferMapPending
+	p.mapMode = mode
+	p.mapOffset = offset
+	p.mapSize = size
+	p.mapCallback = callback
+	p.mapID++
+	id := p.mapID
+	p.device.pending = append(p.device.pending, func() {
+		p.device.mu.Lock()
+		if p.mapState != bufferMapPending || p.mapID != id {
+			p.device.mu.Unlock()
+			return
+		}
+		p.mapState = bufferMapped
+		p.mapCallback = nil
+		p.device.mu.Unlock()
+
+		callback(api.BufferMapAsyncStatus_Success)
+	})
+	return nil
+}
+
+func (p *Buffer) Unmap() error {
+	p.checkReleased()
+	p.device.mu.Lock()
+	callback := p.abortMap(api.BufferMapAsyncStatus_UnmappedBeforeCallback)
+	if p.destroyed {
+		p.device.mu.Unlock()
+		return validationError("fake.(*Buffer).Unmap()", p.label, "buffer is destroyed")
+	}
+	p.mapState = bufferUnmapped
+	p.device.mu.Unlock()
+
+	callback()
+	return nil
+}
+
+// Bytes returns a copy of the contents of the buffer, whatever its usage
+// and map state, or nil once the buffer is destroyed.
+func (p *Buffer) Bytes() []byte {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if p.data == nil {
+		return nil
+	}
+	return append([]byte(nil), p.data...)
+}
+
+func (p *Buffer) Release() {
+	p.released = true
+}
+
+var _ api.Buffer = (*Buffer)(nil)
@@ -0,0 +1,158 @@
+package fake_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+func TestCreateBufferErrors(t *testing.T) {
+	device := testDevice(t)
+
+	invalid := []api.BufferDescriptor{
+		{Size: 16},
+		{Usage: 1 << 15, Size: 16},
+		{Usage: api.BufferUsage_MapRead | api.BufferUsage_CopySrc, Size: 16},
+		{Usage: api.BufferUsage_MapWrite | api.BufferUsage_Storage, Size: 16},
+		{Usage: api.BufferUsage_Storage, Size: device.GetLimits().Limits.MaxBufferSize + 1},
+		{Usage: api.BufferUsage_Storage, Size: 6, MappedAtCreation: true},
+	}
+	for _, descriptor := range invalid {
+		_, err := device.CreateBuffer(&descriptor)
+		expectValidation(t, err)
+	}
+}
+
+func TestBufferCopyAndMap(t *testing.T) {
+	device := testDevice(t)
+	queue := device.GetQueue()
+
+	src, err := device.CreateBuffer(&api.BufferDescriptor{
+		Usage:            api.BufferUsage_CopySrc | api.BufferUsage_CopyDst,
+		Size:             16,
+		MappedAtCreation: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(src.GetMappedRange(0, 16), "0123456789abcdef")
+	if err := src.Unmap(); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.WriteBuffer(src, 4, []byte("WXYZ")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_MapRead | api.BufferUsage_CopyDst, Size: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.CopyBufferToBuffer(src, 0, dst, 8, 8); err != nil {
+		t.Fatal(err)
+	}
+	commands, err := encoder.Finish(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue.Submit(commands)
+
+	var status []api.BufferMapAsyncStatus
+	if err := dst.MapAsync(api.MapMode_Read, 8, 8, func(s api.BufferMapAsyncStatus) { status = append(status, s) }); err != nil {
+		t.Fatal(err)
+	}
+	if dst.GetMappedRange(8, 8) != nil {
+		t.Error("got a mapped range before Poll")
+	}
+	device.Poll(true, nil)
+	if len(status) != 1 || status[0] != api.BufferMapAsyncStatus_Success {
+		t.Fatalf("got map statuses %v", status)
+	}
+	if got := dst.GetMappedRange(8, 8); !bytes.Equal(got, []byte("0123WXYZ")) {
+		t.Errorf("got %q", got)
+	}
+	if dst.GetMappedRange(0, 8) != nil {
+		t.Error("got a range outside of the mapping")
+	}
+	if err := dst.Unmap(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a mapping unmapped before Poll is aborted.
+	status = nil
+	if err := dst.MapAsync(api.MapMode_Read, 0, api.WholeSize, func(s api.BufferMapAsyncStatus) { status = append(status, s) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Unmap(); err != nil {
+		t.Fatal(err)
+	}
+	device.Poll(true, nil)
+	if len(status) != 1 || status[0] != api.BufferMapAsyncStatus_UnmappedBeforeCallback {
+		t.Errorf("got map statuses %v", status)
+	}
+}
+
+func TestMapAsyncErrors(t *testing.T) {
+	device := testDevice(t)
+
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_MapRead, Size: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		mode         api.MapMode
+		offset, size uint64
+	}{
+		{api.MapMode_Write, 0, 64},
+		{api.MapMode_Read | api.MapMode_Write, 0, 64},
+		{api.MapMode_Read, 4, 8},
+		{api.MapMode_Read, 8, 6},
+		{api.MapMode_Read, 32, 64},
+	}
+	for _, tt := range tests {
+		var status []api.BufferMapAsyncStatus
+		err := buffer.MapAsync(tt.mode, tt.offset, tt.size, func(s api.BufferMapAsyncStatus) { status = append(status, s) })
+		expectValidation(t, err)
+		device.Poll(true, nil)
+		if len(status) != 1 || status[0] != api.BufferMapAsyncStatus_ValidationError {
+			t.Errorf("MapAsync(%v, %d, %d) got map statuses %v", tt.mode, tt.offset, tt.size, status)
+		}
+	}
+
+	if err := buffer.MapAsync(api.MapMode_Read, 0, 64, func(api.BufferMapAsyncStatus) {}); err != nil {
+		t.Fatal(err)
+	}
+	expectValidation(t, buffer.MapAsync(api.MapMode_Read, 0, 64, func(api.BufferMapAsyncStatus) {}))
+	device.Poll(true, nil)
+}
+
+func TestWriteBufferErrors(t *testing.T) {
+	device := testDevice(t)
+	queue := device.GetQueue()
+
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopyDst, Size: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readOnly, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopySrc, Size: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := testDevice(t).CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopyDst, Size: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectValidation(t, queue.WriteBuffer(nil, 0, make([]byte, 4)))
+	expectValidation(t, queue.WriteBuffer(readOnly, 0, make([]byte, 4)))
+	expectValidation(t, queue.WriteBuffer(other, 0, make([]byte, 4)))
+	expectValidation(t, queue.WriteBuffer(buffer, 2, make([]byte, 4)))
+	expectValidation(t, queue.WriteBuffer(buffer, 0, make([]byte, 6)))
+	expectValidation(t, queue.WriteBuffer(buffer, 8, make([]byte, 12)))
+	buffer.Destroy()
+	expectValidation(t, queue.WriteBuffer(buffer, 0, make([]byte, 4)))
+}
@@ -0,0 +1,595 @@
+package fake
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+// usage collects the resources used by commands, checked when they are
+// submitted.
+type usage struct {
+	buffers  []*Buffer
+	textures []*Texture
+}
+
+func (u *usage) addBindGroup(group *BindGroup) {
+	u.buffers = append(u.buffers, group.buffers...)
+	u.textures = append(u.textures, group.textures...)
+}
+
+func (u *usage) add(other *usage) {
+	u.buffers = append(u.buffers, other.buffers...)
+	u.textures = append(u.textures, other.textures...)
+}
+
+func (u *usage) check(op, label string) error {
+	for _, b := range u.buffers {
+		if err := b.checkUsable(op); err != nil {
+			return err
+		}
+	}
+	for _, t := range u.textures {
+		if t.destroyed {
+			return validationError(op, t.label, "texture is destroyed")
+		}
+	}
+	return nil
+}
+
+type encoderState int
+
+const (
+	encoderOpen encoderState = iota
+	encoderLocked
+	encoderFinished
+)
+
+type CommandEncoder struct {
+	device     *Device
+	label      string
+	state      encoderState
+	debugDepth int
+	// err is the first error the encoder ran into, that fails Finish.
+	err      error
+	commands []func()
+	used     usage
+
+	released bool
+}
+
+func (p *Device) CreateCommandEncoder(descriptor *api.CommandEncoderDescriptor) (api.CommandEncoder, error) {
+	p.checkReleased()
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	return &CommandEncoder{device: p, label: label}, nil
+}
+
+func (p *CommandEncoder) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "CommandEncoder", Label: p.label})
+	}
+}
+
+// fail records err as the error of the encoder, if it is the first one.
+func (p *CommandEncoder) fail(err error) error {
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return err
+}
+
+func (p *CommandEncoder) checkOpen(op string) error {
+	switch p.state {
+	case encoderLocked:
+		return validationError(op, p.label, "encoder is locked by an open pass")
+	case encoderFinished:
+		return validationError(op, p.label, "encoder is finished")
+	}
+	return nil
+}
+
+func (p *CommandEncoder) buffer(op string, v api.Buffer, usage api.BufferUsage) (*Buffer, error) {
+	buffer := as[Buffer](v, "Buffer")
+	if buffer == nil {
+		return nil, validationError(op, p.label, "buffer must be set")
+	}
+	buffer.checkReleased()
+	if err := p.device.checkDevice(op, p.label, buffer.device, "buffer"); err != nil {
+		return nil, err
+	}
+	if buffer.usage&usage != usage {
+		return nil, validationError(op, buffer.label, "buffer usage doesn't contain %s", usage)
+	}
+	return buffer, nil
+}
+
+func (p *CommandEncoder) ClearBuffer(buffer api.Buffer, offset uint64, size uint64) error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).ClearBuffer()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	b, err := p.buffer(op, buffer, api.BufferUsage_CopyDst)
+	if err != nil {
+		return p.fail(err)
+	}
+	if size == api.WholeSize {
+		if offset > b.size {
+			return p.fail(b.checkRange(op, offset, 0))
+		}
+		size = b.size - offset
+	}
+	switch {
+	case offset%api.CopyBufferAlignment != 0 || size%api.CopyBufferAlignment != 0:
+		return p.fail(validationError(op, b.label, "offset %d and size %d must be multiples of %d", offset, size, api.CopyBufferAlignment))
+	default:
+		if err := b.checkRange(op, offset, size); err != nil {
+			return p.fail(err)
+		}
+	}
+
+	p.used.buffers = append(p.used.buffers, b)
+	p.commands = append(p.commands, func() {
+		clear := b.data[offset : offset+size]
+		for i := range clear {
+			clear[i] = 0
+		}
+	})
+	return nil
+}
+
+func (p *CommandEncoder) CopyBufferToBuffer(source api.Buffer, sourceOffset uint64, destination api.Buffer, destinationOffset uint64, size uint64) error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).CopyBufferToBuffer()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	src, err := p.buffer(op, source, api.BufferUsage_CopySrc)
+	if err != nil {
+		return p.fail(err)
+	}
+	dst, err := p.buffer(op, destination, api.BufferUsage_CopyDst)
+	if err != nil {
+		return p.fail(err)
+	}
+	switch {
+	case src == dst:
+		return p.fail(validationError(op, src.label, "source and destination must be different buffers"))
+	case size%api.CopyBufferAlignment != 0:
+		return p.fail(validationError(op, p.label, "size %d is not a multiple of %d", size, api.CopyBufferAlignment))
+	case sourceOffset%api.CopyBufferAlignment != 0 || destinationOffset%api.CopyBufferAlignment != 0:
+		return p.fail(validationError(op, p.label, "offsets %d and %d must be multiples of %d", sourceOffset, destinationOffset, api.CopyBufferAlignment))
+	}
+	if err := src.checkRange(op, sourceOffset, size); err != nil {
+		return p.fail(err)
+	}
+	if err := dst.checkRange(op, destinationOffset, size); err != nil {
+		return p.fail(err)
+	}
+
+	p.used.buffers = append(p.used.buffers, src, dst)
+	p.commands = append(p.commands, func() {
+		copy(dst.data[destinationOffset:destinationOffset+size], src.data[sourceOffset:sourceOffset+size])
+	})
+	return nil
+}
+
+// checkImageCopyTexture validates the texture side of a copy of size.
+func (p *Device) checkImageCopyTexture(op, label string, v *api.ImageCopyTexture, size *api.Extent3D, usage api.TextureUsage) (*Texture, error) {
+	if v == nil {
+		return nil, validationError(op, label, "texture copy must be set")
+	}
+	if size == nil {
+		return nil, validationError(op, label, "copy size must be set")
+	}
+	t := as[Texture](v.Texture, "Texture")
+	if t == nil {
+		return nil, validationError(op, label, "texture must be set")
+	}
+	t.checkReleased()
+	if err := p.checkDevice(op, label, t.device, "texture"); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case t.usage&usage == 0:
+		return nil, validationError(op, t.label, "texture usage doesn't contain %s", usage)
+	case t.sampleCount != 1:
+		return nil, validationError(op, t.label, "multisampled textures can't be copied")
+	case t.info.blockSize == 0:
+		return nil, validationError(op, t.label, "format %s can't be copied", t.format)
+	case v.MipLevel >= t.mipLevelCount:
+		return nil, validationError(op, t.label, "mip level %d is out of bounds of texture with %d levels", v.MipLevel, t.mipLevelCount)
+	case v.Aspect == api.TextureAspect_DepthOnly && !t.info.depth:
+		return nil, validationError(op, t.label, "aspect DepthOnly needs a depth format, not %s", t.format)
+	case v.Aspect == api.TextureAspect_StencilOnly && !t.info.stencil:
+		return nil, validationError(op, t.label, "aspect StencilOnly needs a stencil format, not %s", t.format)
+	case v.Origin.X%t.info.blockWidth != 0 || v.Origin.Y%t.info.blockHeight != 0:
+		return nil, validationError(op, t.label, "origin %d,%d is not aligned to the %dx%d blocks of format %s", v.Origin.X, v.Origin.Y, t.info.blockWidth, t.info.blockHeight, t.format)
+	case size.Width%t.info.blockWidth != 0 || size.Height%t.info.blockHeight != 0:
+		return nil, validationError(op, t.label, "copy size %dx%d is not a multiple of the %dx%d blocks of format %s", size.Width, size.Height, t.info.blockWidth, t.info.blockHeight, t.format)
+	}
+
+	mip := t.physicalMipSize(v.MipLevel)
+	if uint64(v.Origin.X)+uint64(size.Width) > uint64(mip.Width) ||
+		uint64(v.Origin.Y)+uint64(size.Height) > uint64(mip.Height) ||
+		uint64(v.Origin.Z)+uint64(size.DepthOrArrayLayers) > uint64(mip.DepthOrArrayLayers) {
+		return nil, validationError(op, t.label, "copy of %dx%dx%d at %d,%d,%d is out of bounds of mip level %d of size %dx%dx%d",
+			size.Width, size.Height, size.DepthOrArrayLayers, v.Origin.X, v.Origin.Y, v.Origin.Z,
+			v.MipLevel, mip.Width, mip.Height, mip.DepthOrArrayLayers)
+	}
+	return t, nil
+}
+
+// linearLayout is a TextureDataLayout with the strides resolved.
+type linearLayout struct {
+	offset       uint64
+	bytesPerRow  uint64
+	rowsPerImage uint64
+}
+
+// checkLinearLayout validates the linear side of a copy of size, from or to
+// dataSize bytes. Buffer copies need alignBytesPerRow.
+func checkLinearLayout(op, label string, layout *api.TextureDataLayout, info formatInfo, size *api.Extent3D, dataSize uint64, alignBytesPerRow bool) (linearLayout, error) {
+	if layout == nil {
+		return linearLayout{}, validationError(op, label, "data layout must be set")
+	}
+	widthBlocks := uint64(size.Width / info.blockWidth)
+	heightBlocks := uint64(size.Height / info.blockHeight)
+	depth := uint64(size.DepthOrArrayLayers)
+	bytesInRow := widthBlocks * uint64(info.blockSize)
+
+	l := linearLayout{
+		offset:       layout.Offset,
+		bytesPerRow:  uint64(layout.BytesPerRow),
+		rowsPerImage: uint64(layout.RowsPerImage),
+	}
+	if layout.BytesPerRow == 0 || layout.BytesPerRow == api.CopyStrideUndefined {
+		if heightBlocks > 1 || depth > 1 {
+			return l, validationError(op, label, "BytesPerRow must be set to copy more than one row")
+		}
+		l.bytesPerRow = bytesInRow
+	} else {
+		switch {
+		case alignBytesPerRow && l.bytesPerRow%api.CopyBytesPerRowAlignment != 0:
+			return l, validationError(op, label, "BytesPerRow %d is not a multiple of %d", l.bytesPerRow, api.CopyBytesPerRowAlignment)
+		case l.bytesPerRow < bytesInRow:
+			return l, validationError(op, label, "BytesPerRow %d is less than the %d bytes of a row", l.bytesPerRow, bytesInRow)
+		}
+	}
+	if layout.RowsPerImage == 0 || layout.RowsPerImage == api.CopyStrideUndefined {
+		if depth > 1 {
+			return l, validationError(op, label, "RowsPerImage must be set to copy more than one image")
+		}
+		l.rowsPerImage = heightBlocks
+	} else if l.rowsPerImage < heightBlocks {
+		return l, validationError(op, label, "RowsPerImage %d is less than the %d rows of an image", l.rowsPerImage, heightBlocks)
+	}
+
+	var required uint64
+	if widthBlocks > 0 && heightBlocks > 0 && depth > 0 {
+		required = l.bytesPerRow*l.rowsPerImage*(depth-1) + l.bytesPerRow*(heightBlocks-1) + bytesInRow
+	}
+	if l.offset > dataSize || required > dataSize-l.offset {
+		return l, validationError(op, label, "copy needs %d bytes at offset %d, but there are only %d", required, l.offset, dataSize)
+	}
+	return l, nil
+}
+
+// copyTexels copies size texels at origin of mip level of t from or to
+// data, laid out as l.
+func copyTexels(t *Texture, mipLevel uint32, origin api.Origin3D, size api.Extent3D, data []byte, l linearLayout, toTexture bool) {
+	info := t.info
+	level := t.levels[mipLevel]
+	textureBytesPerRow := t.levelBytesPerRow(mipLevel)
+	textureRowsPerImage := t.levelRowsPerImage(mipLevel)
+
+	rowBytes := uint64(size.Width/info.blockWidth) * uint64(info.blockSize)
+	rows := uint64(size.Height / info.blockHeight)
+	x := uint64(origin.X/info.blockWidth) * uint64(info.blockSize)
+	y := uint64(origin.Y / info.blockHeight)
+	for z := uint64(0); z < uint64(size.DepthOrArrayLayers); z++ {
+		for r := uint64(0); r < rows; r++ {
+			ti := ((uint64(origin.Z)+z)*textureRowsPerImage+y+r)*textureBytesPerRow + x
+			li := l.offset + z*l.bytesPerRow*l.rowsPerImage + r*l.bytesPerRow
+			if toTexture {
+				copy(level[ti:ti+rowBytes], data[li:li+rowBytes])
+			} else {
+				copy(data[li:li+rowBytes], level[ti:ti+rowBytes])
+			}
+		}
+	}
+}
+
+func (p *CommandEncoder) CopyBufferToTexture(source *api.ImageCopyBuffer, destination *api.ImageCopyTexture, copySize *api.Extent3D) error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).CopyBufferToTexture()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	if source == nil {
+		return p.fail(validationError(op, p.label, "source must be set"))
+	}
+	src, err := p.buffer(op, source.Buffer, api.BufferUsage_CopySrc)
+	if err != nil {
+		return p.fail(err)
+	}
+	dst, err := p.device.checkImageCopyTexture(op, p.label, destination, copySize, api.TextureUsage_CopyDst)
+	if err != nil {
+		return p.fail(err)
+	}
+	if source.Layout.Offset%uint64(dst.info.blockSize) != 0 {
+		return p.fail(validationError(op, src.label, "offset %d is not a multiple of the block size %d", source.Layout.Offset, dst.info.blockSize))
+	}
+	l, err := checkLinearLayout(op, src.label, &source.Layout, dst.info, copySize, src.size, true)
+	if err != nil {
+		return p.fail(err)
+	}
+
+	mipLevel, origin, size := destination.MipLevel, destination.Origin, *copySize
+	p.used.buffers = append(p.used.buffers, src)
+	p.used.textures = append(p.used.textures, dst)
+	p.commands = append(p.commands, func() {
+		copyTexels(dst, mipLevel, origin, size, src.data, l, true)
+	})
+	return nil
+}
+
+func (p *CommandEncoder) CopyTextureToBuffer(source *api.ImageCopyTexture, destination *api.ImageCopyBuffer, copySize *api.Extent3D) error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).CopyTextureToBuffer()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	src, err := p.device.checkImageCopyTexture(op, p.label, source, copySize, api.TextureUsage_CopySrc)
+	if err != nil {
+		return p.fail(err)
+	}
+	if destination == nil {
+		return p.fail(validationError(op, p.label, "destination must be set"))
+	}
+	dst, err := p.buffer(op, destination.Buffer, api.BufferUsage_CopyDst)
+	if err != nil {
+		return p.fail(err)
+	}
+	if destination.Layout.Offset%uint64(src.info.blockSize) != 0 {
+		return p.fail(validationError(op, dst.label, "offset %d is not a multiple of the block size %d", destination.Layout.Offset, src.info.blockSize))
+	}
+	l, err := checkLinearLayout(op, dst.label, &destination.Layout, src.info, copySize, dst.size, true)
+	if err != nil {
+		return p.fail(err)
+	}
+
+	mipLevel, origin, size := source.MipLevel, source.Origin, *copySize
+	p.used.buffers = append(p.used.buffers, dst)
+	p.used.textures = append(p.used.textures, src)
+	p.commands = append(p.commands, func() {
+		copyTexels(src, mipLevel, origin, size, dst.data, l, false)
+	})
+	return nil
+}
+
+func (p *CommandEncoder) CopyTextureToTexture(source *api.ImageCopyTexture, destination *api.ImageCopyTexture, copySize *api.Extent3D) error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).CopyTextureToTexture()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	src, err := p.device.checkImageCopyTexture(op, p.label, source, copySize, api.TextureUsage_CopySrc)
+	if err != nil {
+		return p.fail(err)
+	}
+	dst, err := p.device.checkImageCopyTexture(op, p.label, destination, copySize, api.TextureUsage_CopyDst)
+	if err != nil {
+		return p.fail(err)
+	}
+	switch {
+	case src.format != dst.format:
+		return p.fail(validationError(op, p.label, "source format %s differs from destination format %s", src.format, dst.format))
+	case src == dst && source.MipLevel == destination.MipLevel &&
+		source.Origin.Z < destination.Origin.Z+copySize.DepthOrArrayLayers &&
+		destination.Origin.Z < source.Origin.Z+copySize.DepthOrArrayLayers:
+		return p.fail(validationError(op, src.label, "source and destination subresources overlap"))
+	}
+
+	srcMip, srcOrigin := source.MipLevel, source.Origin
+	dstMip, dstOrigin := destination.MipLevel, destination.Origin
+	size := *copySize
+	p.used.textures = append(p.used.textures, src, dst)
+	p.commands = append(p.commands, func() {
+		// stage through a tightly packed copy, as both sides are texture
+		// subresources with their own strides.
+		info := src.info
+		l := linearLayout{
+			bytesPerRow:  uint64(size.Width/info.blockWidth) * uint64(info.blockSize),
+			rowsPerImage: uint64(size.Height / info.blockHeight),
+		}
+		data := make([]byte, l.bytesPerRow*l.rowsPerImage*uint64(size.DepthOrArrayLayers))
+		copyTexels(src, srcMip, srcOrigin, size, data, l, false)
+		copyTexels(dst, dstMip, dstOrigin, size, data, l, true)
+	})
+	return nil
+}
+
+func (p *CommandEncoder) Finish(descriptor *api.CommandBufferDescriptor) (api.CommandBuffer, error) {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).Finish()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	if err := p.checkOpen(op); err != nil {
+		return nil, p.fail(err)
+	}
+	p.state = encoderFinished
+	switch {
+	case p.err != nil:
+		return nil, validationError(op, p.label, "encoder is invalid: %v", p.err)
+	case p.debugDepth != 0:
+		return nil, validationError(op, p.label, "%d debug groups are not popped", p.debugDepth)
+	}
+
+	return &CommandBuffer{
+		device:   p.device,
+		label:    label,
+		commands: p.commands,
+		used:     p.used,
+	}, nil
+}
+
+func (p *CommandEncoder) InsertDebugMarker(markerLabel string) error {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	return p.fail(p.checkOpen("fake.(*CommandEncoder).InsertDebugMarker()"))
+}
+
+func (p *CommandEncoder) PopDebugGroup() error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).PopDebugGroup()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	if p.debugDepth == 0 {
+		return p.fail(validationError(op, p.label, "no debug group to pop"))
+	}
+	p.debugDepth--
+	return nil
+}
+
+func (p *CommandEncoder) PushDebugGroup(groupLabel string) error {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen("fake.(*CommandEncoder).PushDebugGroup()"); err != nil {
+		return p.fail(err)
+	}
+	p.debugDepth++
+	return nil
+}
+
+func (p *CommandEncoder) querySet(op string, v api.QuerySet) (*QuerySet, error) {
+	querySet := as[QuerySet](v, "QuerySet")
+	if querySet == nil {
+		return nil, validationError(op, p.label, "query set must be set")
+	}
+	querySet.checkReleased()
+	if err := p.device.checkDevice(op, p.label, querySet.device, "query set"); err != nil {
+		return nil, err
+	}
+	return querySet, nil
+}
+
+func (p *CommandEncoder) ResolveQuerySet(querySet api.QuerySet, firstQuery uint32, queryCount uint32, destination api.Buffer, destinationOffset uint64) error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).ResolveQuerySet()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	qs, err := p.querySet(op, querySet)
+	if err != nil {
+		return p.fail(err)
+	}
+	dst, err := p.buffer(op, destination, api.BufferUsage_QueryResolve)
+	if err != nil {
+		return p.fail(err)
+	}
+	switch {
+	case uint64(firstQuery)+uint64(queryCount) > uint64(qs.count):
+		return p.fail(validationError(op, qs.label, "queries %d..%d are out of bounds of query set with %d queries", firstQuery, uint64(firstQuery)+uint64(queryCount), qs.count))
+	case destinationOffset%api.QueryResolveBufferAlignment != 0:
+		return p.fail(validationError(op, dst.label, "offset %d is not a multiple of %d", destinationOffset, api.QueryResolveBufferAlignment))
+	}
+	size := uint64(queryCount) * api.QuerySize
+	if err := dst.checkRange(op, destinationOffset, size); err != nil {
+		return p.fail(err)
+	}
+
+	// there is no GPU to measure, so every query resolves to zero.
+	p.used.buffers = append(p.used.buffers, dst)
+	p.commands = append(p.commands, func() {
+		clear := dst.data[destinationOffset : destinationOffset+size]
+		for i := range clear {
+			clear[i] = 0
+		}
+	})
+	return nil
+}
+
+func (p *CommandEncoder) WriteTimestamp(querySet api.QuerySet, queryIndex uint32) error {
+	p.checkReleased()
+	const op = "fake.(*CommandEncoder).WriteTimestamp()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if err := p.checkOpen(op); err != nil {
+		return p.fail(err)
+	}
+	qs, err := p.querySet(op, querySet)
+	if err != nil {
+		return p.fail(err)
+	}
+	switch {
+	case qs.typ != api.QueryType_Timestamp:
+		return p.fail(validationError(op, qs.label, "query set type %s is not Timestamp", qs.typ))
+	case queryIndex >= qs.count:
+		return p.fail(validationError(op, qs.label, "query %d is out of bounds of query set with %d queries", queryIndex, qs.count))
+	}
+	return nil
+}
+
+func (p *CommandEncoder) Release() {
+	p.released = true
+}
+
+type CommandBuffer struct {
+	device    *Device
+	label     string
+	commands  []func()
+	used      usage
+	submitted bool
+
+	released bool
+}
+
+func (p *CommandBuffer) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "CommandBuffer", Label: p.label})
+	}
+}
+
+func (p *CommandBuffer) Release() {
+	p.released = true
+}
+
+var (
+	_ api.CommandEncoder = (*CommandEncoder)(nil)
+	_ api.CommandBuffer  = (*CommandBuffer)(nil)
+)
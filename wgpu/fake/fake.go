@@ -0,0 +1,424 @@
+// Package fake implements the interfaces of package api in memory, without
+// a GPU or cgo.
+//
+// Buffers and textures hold their contents in Go memory, and writes and
+// copies really move bytes, so the results can be read back with a buffer
+// mapping or Buffer.Bytes and Texture.Bytes. Descriptors, usage flags,
+// alignments and limits are validated the way the native implementation
+// does. Shaders are not executed: draws and dispatches are only validated,
+// and render pass load and store ops don't change texture contents.
+//
+// Like the native implementation, map and work-done callbacks are called
+// from Device.Poll, and methods panic with a *api.ForeignHandleError when
+// passed a handle of another implementation.
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+var (
+	ErrValidation  = errors.New("fake: validation error")
+	ErrOutOfMemory = errors.New("fake: out of memory")
+)
+
+type Error struct {
+	Type api.ErrorType
+	// Op is the operation that failed, e.g. "fake.(*Device).CreateTexture()".
+	Op string
+	// Label is the label of the object being created, or of the object the
+	// operation was called on.
+	Label   string
+	Message string
+}
+
+func (v *Error) Error() string {
+	s := ""
+	if v.Op != "" {
+		s += v.Op
+		if v.Label != "" {
+			s += " " + strconv.Quote(v.Label)
+		}
+		s += ": "
+	}
+	return s + v.Type.String() + ": " + v.Message
+}
+
+// Is reports whether v matches ErrValidation or ErrOutOfMemory, so it can be
+// used with errors.Is.
+func (v *Error) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return v.Type == api.ErrorType_Validation
+	case ErrOutOfMemory:
+		return v.Type == api.ErrorType_OutOfMemory
+	}
+	return false
+}
+
+func validationError(op, label string, format string, args ...any) *Error {
+	return &Error{
+		Type:    api.ErrorType_Validation,
+		Op:      op,
+		Label:   label,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// ReleasedError is the panic value when a released object is used.
+type ReleasedError struct {
+	// Type is the name of the released type, e.g. "Buffer".
+	Type  string
+	Label string
+}
+
+func (v *ReleasedError) Error() string {
+	s := "use of released fake." + v.Type
+	if v.Label != "" {
+		s += " '" + v.Label + "'"
+	}
+	return s
+}
+
+// as returns the object behind v, or nil if v is nil. It panics with a
+// *api.ForeignHandleError if v was created by another implementation of
+// package api.
+func as[T any](v any, typ string) *T {
+	if v == nil {
+		return nil
+	}
+	t, ok := v.(*T)
+	if !ok {
+		panic(&api.ForeignHandleError{Type: typ, Package: "fake"})
+	}
+	return t
+}
+
+// DefaultLimits returns the limits of every fake adapter, the WebGPU
+// defaults with room for push constants.
+func DefaultLimits() api.Limits {
+	return api.Limits{
+		MaxTextureDimension1D:                     8192,
+		MaxTextureDimension2D:                     8192,
+		MaxTextureDimension3D:                     2048,
+		MaxTextureArrayLayers:                     256,
+		MaxBindGroups:                             4,
+		MaxBindingsPerBindGroup:                   1000,
+		MaxDynamicUniformBuffersPerPipelineLayout: 8,
+		MaxDynamicStorageBuffersPerPipelineLayout: 4,
+		MaxSampledTexturesPerShaderStage:          16,
+		MaxSamplersPerShaderStage:                 16,
+		MaxStorageBuffersPerShaderStage:           8,
+		MaxStorageTexturesPerShaderStage:          4,
+		MaxUniformBuffersPerShaderStage:           12,
+		MaxUniformBufferBindingSize:               64 << 10,
+		MaxStorageBufferBindingSize:               128 << 20,
+		MinUniformBufferOffsetAlignment:           256,
+		MinStorageBufferOffsetAlignment:           256,
+		MaxVertexBuffers:                          8,
+		MaxBufferSize:                             256 << 20,
+		MaxVertexAttributes:                       16,
+		MaxVertexBufferArrayStride:                2048,
+		MaxInterStageShaderComponents:             60,
+		MaxInterStageShaderVariables:              16,
+		MaxColorAttachments:                       8,
+		MaxColorAttachmentBytesPerSample:          32,
+		MaxComputeWorkgroupStorageSize:            16384,
+		MaxComputeInvocationsPerWorkgroup:         256,
+		MaxComputeWorkgroupSizeX:                  256,
+		MaxComputeWorkgroupSizeY:                  256,
+		MaxComputeWorkgroupSizeZ:                  64,
+		MaxComputeWorkgroupsPerDimension:          65535,
+		MaxPushConstantSize:                       128,
+	}
+}
+
+// features lists the features every fake adapter supports.
+var features = []api.FeatureName{
+	api.FeatureName_DepthClipControl,
+	api.FeatureName_Depth32FloatStencil8,
+	api.FeatureName_TimestampQuery,
+	api.FeatureName_PipelineStatisticsQuery,
+	api.FeatureName_TextureCompressionBC,
+	api.FeatureName_TextureCompressionETC2,
+	api.FeatureName_TextureCompressionASTC,
+	api.FeatureName_IndirectFirstInstance,
+	api.FeatureName_ShaderF16,
+	api.FeatureName_RG11B10UfloatRenderable,
+	api.FeatureName_BGRA8UnormStorage,
+	api.FeatureName_Float32Filterable,
+}
+
+func hasFeature(features []api.FeatureName, feature api.FeatureName) bool {
+	for _, v := range features {
+		if v == feature {
+			return true
+		}
+	}
+	return false
+}
+
+type Instance struct {
+	released bool
+}
+
+func NewInstance() *Instance {
+	return &Instance{}
+}
+
+func (p *Instance) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "Instance"})
+	}
+}
+
+func (p *Instance) RequestAdapter(options *api.RequestAdapterOptions) (api.Adapter, error) {
+	p.checkReleased()
+	return &Adapter{}, nil
+}
+
+func (p *Instance) Release() {
+	p.released = true
+}
+
+type Adapter struct {
+	released bool
+}
+
+func (p *Adapter) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "Adapter"})
+	}
+}
+
+func (p *Adapter) EnumerateFeatures() []api.FeatureName {
+	p.checkReleased()
+	return append([]api.FeatureName(nil), features...)
+}
+
+func (p *Adapter) GetLimits() api.SupportedLimits {
+	p.checkReleased()
+	return api.SupportedLimits{Limits: DefaultLimits()}
+}
+
+func (p *Adapter) GetProperties() api.AdapterProperties {
+	p.checkReleased()
+	return api.AdapterProperties{
+		VendorName:  "fake",
+		Name:        "fake",
+		AdapterType: api.AdapterType_CPU,
+		BackendType: api.BackendType_Null,
+	}
+}
+
+func (p *Adapter) HasFeature(feature api.FeatureName) bool {
+	p.checkReleased()
+	return hasFeature(features, feature)
+}
+
+func (p *Adapter) RequestDevice(descriptor *api.DeviceDescriptor) (api.Device, error) {
+	p.checkReleased()
+	const op = "fake.(*Adapter).RequestDevice()"
+	if descriptor == nil {
+		descriptor = &api.DeviceDescriptor{}
+	}
+
+	for _, v := range descriptor.RequiredFeatures {
+		if !hasFeature(features, v) {
+			return nil, validationError(op, descriptor.Label, "unsupported feature %s", v)
+		}
+	}
+
+	limits := DefaultLimits()
+	if descriptor.RequiredLimits != nil {
+		var err error
+		limits, err = resolveLimits(limits, descriptor.RequiredLimits.Limits)
+		if err != nil {
+			return nil, validationError(op, descriptor.Label, "%v", err)
+		}
+	}
+
+	return newDevice(descriptor.Label, append([]api.FeatureName(nil), descriptor.RequiredFeatures...), limits), nil
+}
+
+func (p *Adapter) Release() {
+	p.released = true
+}
+
+// NewDevice returns a device with the default limits and no features, as
+// requested from a new instance.
+func NewDevice() *Device {
+	return newDevice("", nil, DefaultLimits())
+}
+
+type errorScope struct {
+	filter api.ErrorFilter
+	err    error
+}
+
+type Device struct {
+	label    string
+	features []api.FeatureName
+	limits   api.Limits
+	queue    *Queue
+
+	// mu guards the state of the device and of every object created from it.
+	mu         sync.Mutex
+	scopes     []errorScope
+	uncaptured func(err error)
+	// pending holds the callbacks to call on the next Poll.
+	pending  []func()
+	released bool
+}
+
+func newDevice(label string, features []api.FeatureName, limits api.Limits) *Device {
+	d := &Device{label: label, features: features, limits: limits}
+	d.queue = &Queue{device: d}
+	return d
+}
+
+func (p *Device) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "Device", Label: p.label})
+	}
+}
+
+// report hands err to the innermost matching error scope, or to the
+// uncaptured error callback. It returns the callback to call once p.mu is
+// unlocked, if any.
+func (p *Device) report(err *Error) func() {
+	filter := api.ErrorFilter_Validation
+	if err.Type == api.ErrorType_OutOfMemory {
+		filter = api.ErrorFilter_OutOfMemory
+	}
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if p.scopes[i].filter == filter {
+			if p.scopes[i].err == nil {
+				p.scopes[i].err = err
+			}
+			return func() {}
+		}
+	}
+	callback := p.uncaptured
+	if callback == nil {
+		return func() {}
+	}
+	return func() { callback(err) }
+}
+
+func (p *Device) checkDevice(op, label string, device *Device, typ string) error {
+	if device != p {
+		return validationError(op, label, "%s was created by another device", typ)
+	}
+	return nil
+}
+
+func (p *Device) EnumerateFeatures() []api.FeatureName {
+	p.checkReleased()
+	return append([]api.FeatureName(nil), p.features...)
+}
+
+func (p *Device) GetLimits() api.SupportedLimits {
+	p.checkReleased()
+	return api.SupportedLimits{Limits: p.limits}
+}
+
+func (p *Device) GetQueue() api.Queue {
+	p.checkReleased()
+	return p.queue
+}
+
+func (p *Device) HasFeature(feature api.FeatureName) bool {
+	p.checkReleased()
+	return hasFeature(p.features, feature)
+}
+
+// Poll calls the map and work-done callbacks that became ready. Work is
+// done as soon as it is submitted, so the queue is always empty.
+func (p *Device) Poll(wait bool, wrappedSubmissionIndex *api.WrappedSubmissionIndex) (queueEmpty bool) {
+	p.checkReleased()
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	for _, f := range pending {
+		f()
+	}
+	return true
+}
+
+func (p *Device) PushErrorScope(filter api.ErrorFilter) {
+	p.checkReleased()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.scopes = append(p.scopes, errorScope{filter: filter})
+}
+
+func (p *Device) PopErrorScope() error {
+	p.checkReleased()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.scopes) == 0 {
+		return validationError("fake.(*Device).PopErrorScope()", "", "no error scope to pop")
+	}
+	scope := p.scopes[len(p.scopes)-1]
+	p.scopes = p.scopes[:len(p.scopes)-1]
+	return scope.err
+}
+
+func (p *Device) SetUncapturedErrorCallback(callback func(err error)) {
+	p.checkReleased()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.uncaptured = callback
+}
+
+func (p *Device) Release() {
+	p.released = true
+}
+
+var (
+	_ api.Instance = (*Instance)(nil)
+	_ api.Adapter  = (*Adapter)(nil)
+	_ api.Device   = (*Device)(nil)
+)
+
+// resolveLimits returns supported with the defined limits of required
+// applied, or an error if required asks for more than supported.
+func resolveLimits(supported, required api.Limits) (api.Limits, error) {
+	s := reflect.ValueOf(&supported).Elem()
+	r := reflect.ValueOf(required)
+	for i := 0; i < r.NumField(); i++ {
+		name := r.Type().Field(i).Name
+		v := r.Field(i).Uint()
+		undefined := uint64(api.LimitU32Undefined)
+		if r.Field(i).Kind() == reflect.Uint64 {
+			undefined = api.LimitU64Undefined
+		}
+		if v == undefined {
+			continue
+		}
+
+		max := s.Field(i).Uint()
+		if strings.HasPrefix(name, "Min") {
+			if v < max || v&(v-1) != 0 {
+				return supported, fmt.Errorf("limit %s of %d is not a power of two of at least %d", name, v, max)
+			}
+		} else if v > max {
+			return supported, fmt.Errorf("limit %s of %d exceeds the supported %d", name, v, max)
+		}
+		s.Field(i).SetUint(v)
+	}
+	return supported, nil
+}
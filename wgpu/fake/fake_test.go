@@ -0,0 +1,192 @@
+package fake_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+	"github.com/rajveermalviya/go-webgpu/wgpu/fake"
+)
+
+// testDevice returns a device requested through the api interfaces.
+func testDevice(t *testing.T) api.Device {
+	t.Helper()
+
+	var instance api.Instance = fake.NewInstance()
+	t.Cleanup(instance.Release)
+
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(adapter.Release)
+
+	device, err := adapter.RequestDevice(&api.DeviceDescriptor{
+		RequiredFeatures: []api.FeatureName{api.FeatureName_TimestampQuery},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(device.Release)
+	return device
+}
+
+// expectValidation fails t unless err is a validation error.
+func expectValidation(t *testing.T, err error) {
+	t.Helper()
+
+	var e *fake.Error
+	switch {
+	case err == nil:
+		t.Error("expected a validation error")
+	case !errors.As(err, &e) || !errors.Is(err, fake.ErrValidation):
+		t.Errorf("got %v, expected a validation error", err)
+	}
+}
+
+// expectPanic fails t unless f panics with a value of type T, and returns
+// the value.
+func expectPanic[T any](t *testing.T, f func()) (v T) {
+	t.Helper()
+
+	defer func() {
+		t.Helper()
+		r := recover()
+		var ok bool
+		if v, ok = r.(T); !ok {
+			t.Errorf("got panic %v, expected a %T", r, v)
+		}
+	}()
+	f()
+	return v
+}
+
+func TestRequestDevice(t *testing.T) {
+	adapter, err := fake.NewInstance().RequestAdapter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limits := func(f func(l *api.Limits)) *api.RequiredLimits {
+		l := fake.DefaultLimits()
+		f(&l)
+		return &api.RequiredLimits{Limits: l}
+	}
+	invalid := []*api.DeviceDescriptor{
+		{RequiredFeatures: []api.FeatureName{api.NativeFeature_PushConstants}},
+		{RequiredLimits: limits(func(l *api.Limits) { l.MaxBindGroups++ })},
+		{RequiredLimits: limits(func(l *api.Limits) { l.MinUniformBufferOffsetAlignment = 128 })},
+		{RequiredLimits: limits(func(l *api.Limits) { l.MinStorageBufferOffsetAlignment = 384 })},
+	}
+	for _, descriptor := range invalid {
+		_, err := adapter.RequestDevice(descriptor)
+		expectValidation(t, err)
+	}
+
+	device, err := adapter.RequestDevice(&api.DeviceDescriptor{
+		RequiredFeatures: []api.FeatureName{api.FeatureName_TextureCompressionBC},
+		RequiredLimits:   limits(func(l *api.Limits) { l.MaxBindGroups = 2 }),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := device.GetLimits().Limits.MaxBindGroups; got != 2 {
+		t.Errorf("got MaxBindGroups %d, expected 2", got)
+	}
+	if !device.HasFeature(api.FeatureName_TextureCompressionBC) || device.HasFeature(api.FeatureName_ShaderF16) {
+		t.Errorf("got features %v", device.EnumerateFeatures())
+	}
+
+	// a lower limit is enforced.
+	layouts := make([]api.BindGroupLayout, 3)
+	for i := range layouts {
+		if layouts[i], err = device.CreateBindGroupLayout(nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = device.CreatePipelineLayout(&api.PipelineLayoutDescriptor{BindGroupLayouts: layouts})
+	expectValidation(t, err)
+}
+
+// invalidCommandBuffer returns a command buffer that fails to submit, as it
+// uses a destroyed buffer.
+func invalidCommandBuffer(t *testing.T, device api.Device) api.CommandBuffer {
+	t.Helper()
+
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopyDst, Size: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.ClearBuffer(buffer, 0, api.WholeSize); err != nil {
+		t.Fatal(err)
+	}
+	commands, err := encoder.Finish(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer.Destroy()
+	return commands
+}
+
+func TestErrorScopes(t *testing.T) {
+	device := testDevice(t)
+	queue := device.GetQueue()
+
+	var uncaptured []error
+	device.SetUncapturedErrorCallback(func(err error) { uncaptured = append(uncaptured, err) })
+
+	device.PushErrorScope(api.ErrorFilter_Validation)
+	device.PushErrorScope(api.ErrorFilter_OutOfMemory)
+	queue.Submit(invalidCommandBuffer(t, device))
+	if err := device.PopErrorScope(); err != nil {
+		t.Errorf("out of memory scope got %v", err)
+	}
+	expectValidation(t, device.PopErrorScope())
+	expectValidation(t, device.PopErrorScope())
+
+	queue.Submit(invalidCommandBuffer(t, device))
+	if len(uncaptured) != 1 {
+		t.Fatalf("got %d uncaptured errors, expected 1", len(uncaptured))
+	}
+	expectValidation(t, uncaptured[0])
+
+	// a failed submission doesn't advance the index.
+	if index := queue.Submit(); index != 1 {
+		t.Errorf("got submission index %d, expected 1", index)
+	}
+}
+
+// foreignBuffer is an api.Buffer of another implementation.
+type foreignBuffer struct{ api.Buffer }
+
+func TestForeignHandle(t *testing.T) {
+	device := testDevice(t)
+
+	err := expectPanic[*api.ForeignHandleError](t, func() {
+		device.GetQueue().WriteBuffer(foreignBuffer{}, 0, make([]byte, 4))
+	})
+	if err != nil && (err.Type != "Buffer" || err.Package != "fake") {
+		t.Errorf("got %+v", err)
+	}
+}
+
+func TestReleased(t *testing.T) {
+	device := testDevice(t)
+
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Label: "released", Usage: api.BufferUsage_CopyDst, Size: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer.Release()
+	released := expectPanic[*fake.ReleasedError](t, func() { buffer.GetSize() })
+	if released != nil && (released.Type != "Buffer" || released.Label != "released") {
+		t.Errorf("got %+v", released)
+	}
+	expectPanic[*fake.ReleasedError](t, func() {
+		device.GetQueue().WriteBuffer(buffer, 0, make([]byte, 4))
+	})
+}
@@ -0,0 +1,158 @@
+package fake
+
+import "github.com/rajveermalviya/go-webgpu/wgpu/api"
+
+type formatInfo struct {
+	// blockSize is the size in bytes of a texel block, zero if the format
+	// can't be copied as a whole.
+	blockSize   uint32
+	blockWidth  uint32
+	blockHeight uint32
+	renderable  bool
+	depth       bool
+	stencil     bool
+	// feature is the feature the format needs, if any.
+	feature api.FeatureName
+}
+
+func color(blockSize uint32, renderable bool) formatInfo {
+	return formatInfo{blockSize: blockSize, blockWidth: 1, blockHeight: 1, renderable: renderable}
+}
+
+func compressed(blockSize, blockWidth, blockHeight uint32, feature api.FeatureName) formatInfo {
+	return formatInfo{blockSize: blockSize, blockWidth: blockWidth, blockHeight: blockHeight, feature: feature}
+}
+
+var formats = map[api.TextureFormat]formatInfo{
+	api.TextureFormat_R8Unorm:        color(1, true),
+	api.TextureFormat_R8Snorm:        color(1, false),
+	api.TextureFormat_R8Uint:         color(1, true),
+	api.TextureFormat_R8Sint:         color(1, true),
+	api.TextureFormat_R16Uint:        color(2, true),
+	api.TextureFormat_R16Sint:        color(2, true),
+	api.TextureFormat_R16Float:       color(2, true),
+	api.TextureFormat_RG8Unorm:       color(2, true),
+	api.TextureFormat_RG8Snorm:       color(2, false),
+	api.TextureFormat_RG8Uint:        color(2, true),
+	api.TextureFormat_RG8Sint:        color(2, true),
+	api.TextureFormat_R32Float:       color(4, true),
+	api.TextureFormat_R32Uint:        color(4, true),
+	api.TextureFormat_R32Sint:        color(4, true),
+	api.TextureFormat_RG16Uint:       color(4, true),
+	api.TextureFormat_RG16Sint:       color(4, true),
+	api.TextureFormat_RG16Float:      color(4, true),
+	api.TextureFormat_RGBA8Unorm:     color(4, true),
+	api.TextureFormat_RGBA8UnormSrgb: color(4, true),
+	api.TextureFormat_RGBA8Snorm:     color(4, false),
+	api.TextureFormat_RGBA8Uint:      color(4, true),
+	api.TextureFormat_RGBA8Sint:      color(4, true),
+	api.TextureFormat_BGRA8Unorm:     color(4, true),
+	api.TextureFormat_BGRA8UnormSrgb: color(4, true),
+	api.TextureFormat_RGB10A2Unorm:   color(4, true),
+	api.TextureFormat_RG11B10Ufloat:  color(4, false),
+	api.TextureFormat_RGB9E5Ufloat:   color(4, false),
+	api.TextureFormat_RG32Float:      color(8, true),
+	api.TextureFormat_RG32Uint:       color(8, true),
+	api.TextureFormat_RG32Sint:       color(8, true),
+	api.TextureFormat_RGBA16Uint:     color(8, true),
+	api.TextureFormat_RGBA16Sint:     color(8, true),
+	api.TextureFormat_RGBA16Float:    color(8, true),
+	api.TextureFormat_RGBA32Float:    color(16, true),
+	api.TextureFormat_RGBA32Uint:     color(16, true),
+	api.TextureFormat_RGBA32Sint:     color(16, true),
+
+	api.TextureFormat_Stencil8:             {blockSize: 1, blockWidth: 1, blockHeight: 1, renderable: true, stencil: true},
+	api.TextureFormat_Depth16Unorm:         {blockSize: 2, blockWidth: 1, blockHeight: 1, renderable: true, depth: true},
+	api.TextureFormat_Depth24Plus:          {blockWidth: 1, blockHeight: 1, renderable: true, depth: true},
+	api.TextureFormat_Depth24PlusStencil8:  {blockWidth: 1, blockHeight: 1, renderable: true, depth: true, stencil: true},
+	api.TextureFormat_Depth32Float:         {blockSize: 4, blockWidth: 1, blockHeight: 1, renderable: true, depth: true},
+	api.TextureFormat_Depth32FloatStencil8: {blockWidth: 1, blockHeight: 1, renderable: true, depth: true, stencil: true, feature: api.FeatureName_Depth32FloatStencil8},
+
+	api.TextureFormat_BC1RGBAUnorm:     compressed(8, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC1RGBAUnormSrgb: compressed(8, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC2RGBAUnorm:     compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC2RGBAUnormSrgb: compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC3RGBAUnorm:     compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC3RGBAUnormSrgb: compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC4RUnorm:        compressed(8, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC4RSnorm:        compressed(8, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC5RGUnorm:       compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC5RGSnorm:       compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC6HRGBUfloat:    compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC6HRGBFloat:     compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC7RGBAUnorm:     compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+	api.TextureFormat_BC7RGBAUnormSrgb: compressed(16, 4, 4, api.FeatureName_TextureCompressionBC),
+
+	api.TextureFormat_ETC2RGB8Unorm:       compressed(8, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_ETC2RGB8UnormSrgb:   compressed(8, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_ETC2RGB8A1Unorm:     compressed(8, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_ETC2RGB8A1UnormSrgb: compressed(8, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_ETC2RGBA8Unorm:      compressed(16, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_ETC2RGBA8UnormSrgb:  compressed(16, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_EACR11Unorm:         compressed(8, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_EACR11Snorm:         compressed(8, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_EACRG11Unorm:        compressed(16, 4, 4, api.FeatureName_TextureCompressionETC2),
+	api.TextureFormat_EACRG11Snorm:        compressed(16, 4, 4, api.FeatureName_TextureCompressionETC2),
+
+	api.TextureFormat_ASTC4x4Unorm:       compressed(16, 4, 4, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC4x4UnormSrgb:   compressed(16, 4, 4, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC5x4Unorm:       compressed(16, 5, 4, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC5x4UnormSrgb:   compressed(16, 5, 4, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC5x5Unorm:       compressed(16, 5, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC5x5UnormSrgb:   compressed(16, 5, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC6x5Unorm:       compressed(16, 6, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC6x5UnormSrgb:   compressed(16, 6, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC6x6Unorm:       compressed(16, 6, 6, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC6x6UnormSrgb:   compressed(16, 6, 6, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC8x5Unorm:       compressed(16, 8, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC8x5UnormSrgb:   compressed(16, 8, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC8x6Unorm:       compressed(16, 8, 6, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC8x6UnormSrgb:   compressed(16, 8, 6, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC8x8Unorm:       compressed(16, 8, 8, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC8x8UnormSrgb:   compressed(16, 8, 8, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x5Unorm:      compressed(16, 10, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x5UnormSrgb:  compressed(16, 10, 5, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x6Unorm:      compressed(16, 10, 6, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x6UnormSrgb:  compressed(16, 10, 6, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x8Unorm:      compressed(16, 10, 8, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x8UnormSrgb:  compressed(16, 10, 8, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x10Unorm:     compressed(16, 10, 10, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC10x10UnormSrgb: compressed(16, 10, 10, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC12x10Unorm:     compressed(16, 12, 10, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC12x10UnormSrgb: compressed(16, 12, 10, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC12x12Unorm:     compressed(16, 12, 12, api.FeatureName_TextureCompressionASTC),
+	api.TextureFormat_ASTC12x12UnormSrgb: compressed(16, 12, 12, api.FeatureName_TextureCompressionASTC),
+}
+
+var vertexFormatSizes = map[api.VertexFormat]uint64{
+	api.VertexFormat_Uint8x2:   2,
+	api.VertexFormat_Uint8x4:   4,
+	api.VertexFormat_Sint8x2:   2,
+	api.VertexFormat_Sint8x4:   4,
+	api.VertexFormat_Unorm8x2:  2,
+	api.VertexFormat_Unorm8x4:  4,
+	api.VertexFormat_Snorm8x2:  2,
+	api.VertexFormat_Snorm8x4:  4,
+	api.VertexFormat_Uint16x2:  4,
+	api.VertexFormat_Uint16x4:  8,
+	api.VertexFormat_Sint16x2:  4,
+	api.VertexFormat_Sint16x4:  8,
+	api.VertexFormat_Unorm16x2: 4,
+	api.VertexFormat_Unorm16x4: 8,
+	api.VertexFormat_Snorm16x2: 4,
+	api.VertexFormat_Snorm16x4: 8,
+	api.VertexFormat_Float16x2: 4,
+	api.VertexFormat_Float16x4: 8,
+	api.VertexFormat_Float32:   4,
+	api.VertexFormat_Float32x2: 8,
+	api.VertexFormat_Float32x3: 12,
+	api.VertexFormat_Float32x4: 16,
+	api.VertexFormat_Uint32:    4,
+	api.VertexFormat_Uint32x2:  8,
+	api.VertexFormat_Uint32x3:  12,
+	api.VertexFormat_Uint32x4:  16,
+	api.VertexFormat_Sint32:    4,
+	api.VertexFormat_Sint32x2:  8,
+	api.VertexFormat_Sint32x3:  12,
+	api.VertexFormat_Sint32x4:  16,
+}
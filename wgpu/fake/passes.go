@@ -0,0 +1,1026 @@
+package fake
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+// boundGroup is a bind group set on a pass or bundle encoder.
+type boundGroup struct {
+	group   *BindGroup
+	offsets []uint32
+}
+
+// setBindGroup validates group with dynamicOffsets and binds it at
+// groupIndex of groups.
+func (p *Device) setBindGroup(op, label string, groups []*boundGroup, used *usage, groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) error {
+	if groupIndex >= uint32(len(groups)) {
+		return validationError(op, label, "bind group index %d exceeds the MaxBindGroups limit of %d", groupIndex, len(groups))
+	}
+	g := as[BindGroup](group, "BindGroup")
+	if g == nil {
+		return validationError(op, label, "bind group must be set")
+	}
+	g.checkReleased()
+	if err := p.checkDevice(op, label, g.device, "bind group"); err != nil {
+		return err
+	}
+	if len(dynamicOffsets) != len(g.dynamic) {
+		return validationError(op, label, "bind group needs %d dynamic offsets, got %d", len(g.dynamic), len(dynamicOffsets))
+	}
+	for i, d := range g.dynamic {
+		offset := uint64(dynamicOffsets[i])
+		switch {
+		case offset%uint64(d.alignment) != 0:
+			return validationError(op, label, "dynamic offset %d of binding %d is not a multiple of %d", offset, d.binding, d.alignment)
+		case d.offset+offset > d.buffer.size || d.size > d.buffer.size-d.offset-offset:
+			return validationError(op, label, "dynamic offset %d of binding %d is out of bounds of buffer of size %d", offset, d.binding, d.buffer.size)
+		}
+	}
+
+	groups[groupIndex] = &boundGroup{
+		group:   g,
+		offsets: append([]uint32(nil), dynamicOffsets...),
+	}
+	used.addBindGroup(g)
+	return nil
+}
+
+// indirectBuffer validates an indirect buffer holding size bytes of
+// arguments at offset.
+func (p *Device) indirectBuffer(op, label string, v api.Buffer, offset, size uint64) (*Buffer, error) {
+	buffer := as[Buffer](v, "Buffer")
+	if buffer == nil {
+		return nil, validationError(op, label, "indirect buffer must be set")
+	}
+	buffer.checkReleased()
+	if err := p.checkDevice(op, label, buffer.device, "indirect buffer"); err != nil {
+		return nil, err
+	}
+	switch {
+	case buffer.usage&api.BufferUsage_Indirect == 0:
+		return nil, validationError(op, buffer.label, "buffer usage doesn't contain Indirect")
+	case offset%4 != 0:
+		return nil, validationError(op, buffer.label, "indirect offset %d is not a multiple of 4", offset)
+	}
+	if err := buffer.checkRange(op, offset, size); err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+type ComputePassEncoder struct {
+	encoder *CommandEncoder
+	label   string
+	// locked reports whether the pass locks the encoder, which it does
+	// unless the encoder couldn't begin a pass.
+	locked bool
+
+	pipeline   *ComputePipeline
+	groups     []*boundGroup
+	statistics bool
+	debugDepth int
+	err        error
+	ended      bool
+
+	released bool
+}
+
+func (p *CommandEncoder) BeginComputePass(descriptor *api.ComputePassDescriptor) api.ComputePassEncoder {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	pass := &ComputePassEncoder{
+		encoder: p,
+		groups:  make([]*boundGroup, p.device.limits.MaxBindGroups),
+	}
+	if descriptor != nil {
+		pass.label = descriptor.Label
+	}
+	if err := p.checkOpen("fake.(*CommandEncoder).BeginComputePass()"); err != nil {
+		pass.err = p.fail(err)
+		return pass
+	}
+	p.state = encoderLocked
+	pass.locked = true
+	return pass
+}
+
+func (p *ComputePassEncoder) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "ComputePassEncoder", Label: p.label})
+	}
+}
+
+// begin locks the device for op. It returns false if the pass can't record
+// commands anymore.
+func (p *ComputePassEncoder) begin(op string) bool {
+	p.encoder.device.mu.Lock()
+	p.checkReleased()
+	if p.ended {
+		p.encoder.fail(validationError(op, p.label, "pass is ended"))
+		return false
+	}
+	return p.err == nil
+}
+
+func (p *ComputePassEncoder) end() {
+	p.encoder.device.mu.Unlock()
+}
+
+// fail records err as the error of the pass, if it is the first one.
+func (p *ComputePassEncoder) fail(err error) {
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+}
+
+func (p *ComputePassEncoder) BeginPipelineStatisticsQuery(querySet api.QuerySet, queryIndex uint32) {
+	const op = "fake.(*ComputePassEncoder).BeginPipelineStatisticsQuery()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	qs, err := p.encoder.querySet(op, querySet)
+	switch {
+	case err != nil:
+		p.fail(err)
+	case qs.typ != api.QueryType_PipelineStatistics:
+		p.fail(validationError(op, qs.label, "query set type %s is not PipelineStatistics", qs.typ))
+	case queryIndex >= qs.count:
+		p.fail(validationError(op, qs.label, "query %d is out of bounds of query set with %d queries", queryIndex, qs.count))
+	case p.statistics:
+		p.fail(validationError(op, p.label, "a pipeline statistics query is already active"))
+	default:
+		p.statistics = true
+	}
+}
+
+func (p *ComputePassEncoder) checkDispatch(op string) error {
+	if p.pipeline == nil {
+		return validationError(op, p.label, "no pipeline is set")
+	}
+	return p.pipeline.layouts.checkBindGroups(op, p.label, p.groups)
+}
+
+func (p *ComputePassEncoder) DispatchWorkgroups(workgroupCountX, workgroupCountY, workgroupCountZ uint32) {
+	const op = "fake.(*ComputePassEncoder).DispatchWorkgroups()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	if err := p.checkDispatch(op); err != nil {
+		p.fail(err)
+		return
+	}
+	limit := p.encoder.device.limits.MaxComputeWorkgroupsPerDimension
+	if workgroupCountX > limit || workgroupCountY > limit || workgroupCountZ > limit {
+		p.fail(validationError(op, p.label, "workgroup count %d,%d,%d exceeds the MaxComputeWorkgroupsPerDimension limit of %d", workgroupCountX, workgroupCountY, workgroupCountZ, limit))
+	}
+}
+
+func (p *ComputePassEncoder) DispatchWorkgroupsIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	const op = "fake.(*ComputePassEncoder).DispatchWorkgroupsIndirect()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	if err := p.checkDispatch(op); err != nil {
+		p.fail(err)
+		return
+	}
+	buffer, err := p.encoder.device.indirectBuffer(op, p.label, indirectBuffer, indirectOffset, 12)
+	if err != nil {
+		p.fail(err)
+		return
+	}
+	p.encoder.used.buffers = append(p.encoder.used.buffers, buffer)
+}
+
+func (p *ComputePassEncoder) End() error {
+	p.checkReleased()
+	const op = "fake.(*ComputePassEncoder).End()"
+	p.encoder.device.mu.Lock()
+	defer p.encoder.device.mu.Unlock()
+
+	if p.ended {
+		return validationError(op, p.label, "pass is already ended")
+	}
+	p.ended = true
+	if p.locked {
+		p.encoder.state = encoderOpen
+	}
+	switch {
+	case p.debugDepth != 0:
+		p.fail(validationError(op, p.label, "%d debug groups are not popped", p.debugDepth))
+	case p.statistics:
+		p.fail(validationError(op, p.label, "pipeline statistics query is not ended"))
+	}
+	if p.err != nil {
+		return p.encoder.fail(p.err)
+	}
+	return nil
+}
+
+func (p *ComputePassEncoder) EndPipelineStatisticsQuery() {
+	const op = "fake.(*ComputePassEncoder).EndPipelineStatisticsQuery()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	if !p.statistics {
+		p.fail(validationError(op, p.label, "no pipeline statistics query is active"))
+	}
+	p.statistics = false
+}
+
+func (p *ComputePassEncoder) InsertDebugMarker(markerLabel string) {
+	defer p.end()
+	p.begin("fake.(*ComputePassEncoder).InsertDebugMarker()")
+}
+
+func (p *ComputePassEncoder) PopDebugGroup() {
+	const op = "fake.(*ComputePassEncoder).PopDebugGroup()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	if p.debugDepth == 0 {
+		p.fail(validationError(op, p.label, "no debug group to pop"))
+		return
+	}
+	p.debugDepth--
+}
+
+func (p *ComputePassEncoder) PushDebugGroup(groupLabel string) {
+	defer p.end()
+	if !p.begin("fake.(*ComputePassEncoder).PushDebugGroup()") {
+		return
+	}
+	p.debugDepth++
+}
+
+func (p *ComputePassEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	const op = "fake.(*ComputePassEncoder).SetBindGroup()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	p.fail(p.encoder.device.setBindGroup(op, p.label, p.groups, &p.encoder.used, groupIndex, group, dynamicOffsets))
+}
+
+func (p *ComputePassEncoder) SetPipeline(pipeline api.ComputePipeline) {
+	const op = "fake.(*ComputePassEncoder).SetPipeline()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	cp := as[ComputePipeline](pipeline, "ComputePipeline")
+	if cp == nil {
+		p.fail(validationError(op, p.label, "pipeline must be set"))
+		return
+	}
+	cp.checkReleased()
+	if err := p.encoder.device.checkDevice(op, p.label, cp.device, "pipeline"); err != nil {
+		p.fail(err)
+		return
+	}
+	p.pipeline = cp
+}
+
+func (p *ComputePassEncoder) Release() {
+	p.released = true
+}
+
+// vertexBinding is a vertex or index buffer range set on a render pass or
+// bundle encoder.
+type vertexBinding struct {
+	buffer *Buffer
+	offset uint64
+	size   uint64
+}
+
+// renderState is the state shared by render pass and bundle encoders.
+type renderState struct {
+	device *Device
+	label  string
+	used   *usage
+
+	colorFormats       []api.TextureFormat
+	depthStencilFormat api.TextureFormat
+	sampleCount        uint32
+	depthReadOnly      bool
+	stencilReadOnly    bool
+
+	pipeline      *RenderPipeline
+	groups        []*boundGroup
+	vertexBuffers []*vertexBinding
+	index         *vertexBinding
+	indexFormat   api.IndexFormat
+}
+
+func newRenderState(device *Device, label string, used *usage) renderState {
+	return renderState{
+		device:        device,
+		label:         label,
+		used:          used,
+		groups:        make([]*boundGroup, device.limits.MaxBindGroups),
+		vertexBuffers: make([]*vertexBinding, device.limits.MaxVertexBuffers),
+	}
+}
+
+// reset clears the bindings, as executing bundles does.
+func (s *renderState) reset() {
+	s.pipeline = nil
+	s.groups = make([]*boundGroup, len(s.groups))
+	s.vertexBuffers = make([]*vertexBinding, len(s.vertexBuffers))
+	s.index = nil
+	s.indexFormat = api.IndexFormat_Undefined
+}
+
+// compatibleFormats reports whether the attachments match colorFormats,
+// depthStencilFormat and sampleCount.
+func (s *renderState) compatibleFormats(colorFormats []api.TextureFormat, depthStencilFormat api.TextureFormat, sampleCount uint32) bool {
+	n := len(s.colorFormats)
+	if len(colorFormats) > n {
+		n = len(colorFormats)
+	}
+	for i := 0; i < n; i++ {
+		var x, y api.TextureFormat
+		if i < len(s.colorFormats) {
+			x = s.colorFormats[i]
+		}
+		if i < len(colorFormats) {
+			y = colorFormats[i]
+		}
+		if x != y {
+			return false
+		}
+	}
+	return s.depthStencilFormat == depthStencilFormat && s.sampleCount == sampleCount
+}
+
+func (s *renderState) setPipeline(op string, pipeline api.RenderPipeline) error {
+	rp := as[RenderPipeline](pipeline, "RenderPipeline")
+	if rp == nil {
+		return validationError(op, s.label, "pipeline must be set")
+	}
+	rp.checkReleased()
+	if err := s.device.checkDevice(op, s.label, rp.device, "pipeline"); err != nil {
+		return err
+	}
+	switch {
+	case !s.compatibleFormats(rp.colorFormats, rp.depthStencilFormat, rp.sampleCount):
+		return validationError(op, s.label, "pipeline %q targets are not compatible with the attachments", rp.label)
+	case rp.writesDepth && s.depthReadOnly:
+		return validationError(op, s.label, "pipeline %q writes to read-only depth", rp.label)
+	case rp.writesStencil && s.stencilReadOnly:
+		return validationError(op, s.label, "pipeline %q writes to read-only stencil", rp.label)
+	}
+	s.pipeline = rp
+	return nil
+}
+
+func (s *renderState) setBindGroup(op string, groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) error {
+	return s.device.setBindGroup(op, s.label, s.groups, s.used, groupIndex, group, dynamicOffsets)
+}
+
+// bufferRange validates a buffer bound with usage, and resolves its size.
+func (s *renderState) bufferRange(op string, v api.Buffer, usage api.BufferUsage, offset, size uint64) (*vertexBinding, error) {
+	buffer := as[Buffer](v, "Buffer")
+	if buffer == nil {
+		return nil, validationError(op, s.label, "buffer must be set")
+	}
+	buffer.checkReleased()
+	if err := s.device.checkDevice(op, s.label, buffer.device, "buffer"); err != nil {
+		return nil, err
+	}
+	if buffer.usage&usage == 0 {
+		return nil, validationError(op, buffer.label, "buffer usage doesn't contain %s", usage)
+	}
+	if size == 0 || size == api.WholeSize {
+		if offset > buffer.size {
+			return nil, buffer.checkRange(op, offset, 0)
+		}
+		size = buffer.size - offset
+	}
+	if err := buffer.checkRange(op, offset, size); err != nil {
+		return nil, err
+	}
+	return &vertexBinding{buffer: buffer, offset: offset, size: size}, nil
+}
+
+func (s *renderState) setVertexBuffer(op string, slot uint32, buffer api.Buffer, offset, size uint64) error {
+	if slot >= uint32(len(s.vertexBuffers)) {
+		return validationError(op, s.label, "slot %d exceeds the MaxVertexBuffers limit of %d", slot, len(s.vertexBuffers))
+	}
+	if offset%4 != 0 {
+		return validationError(op, s.label, "offset %d is not a multiple of 4", offset)
+	}
+	b, err := s.bufferRange(op, buffer, api.BufferUsage_Vertex, offset, size)
+	if err != nil {
+		return err
+	}
+	s.vertexBuffers[slot] = b
+	s.used.buffers = append(s.used.buffers, b.buffer)
+	return nil
+}
+
+func indexFormatSize(format api.IndexFormat) uint64 {
+	switch format {
+	case api.IndexFormat_Uint16:
+		return 2
+	case api.IndexFormat_Uint32:
+		return 4
+	}
+	return 0
+}
+
+func (s *renderState) setIndexBuffer(op string, buffer api.Buffer, format api.IndexFormat, offset, size uint64) error {
+	indexSize := indexFormatSize(format)
+	switch {
+	case indexSize == 0:
+		return validationError(op, s.label, "index format must be Uint16 or Uint32")
+	case offset%indexSize != 0:
+		return validationError(op, s.label, "offset %d is not a multiple of the index size %d", offset, indexSize)
+	}
+	b, err := s.bufferRange(op, buffer, api.BufferUsage_Index, offset, size)
+	if err != nil {
+		return err
+	}
+	s.index = b
+	s.indexFormat = format
+	s.used.buffers = append(s.used.buffers, b.buffer)
+	return nil
+}
+
+func (s *renderState) checkDraw(op string) error {
+	if s.pipeline == nil {
+		return validationError(op, s.label, "no pipeline is set")
+	}
+	if err := s.pipeline.layouts.checkBindGroups(op, s.label, s.groups); err != nil {
+		return err
+	}
+	for i := range s.pipeline.vertexBuffers {
+		if s.vertexBuffers[i] == nil {
+			return validationError(op, s.label, "no vertex buffer is set at slot %d", i)
+		}
+	}
+	return nil
+}
+
+// checkVertexRanges returns an error if drawing vertexCount vertices or
+// instanceCount instances reads past the end of a vertex buffer. A step mode
+// is skipped if its count is 0.
+func (s *renderState) checkVertexRanges(op string, vertexCount, instanceCount uint64) error {
+	for i, layout := range s.pipeline.vertexBuffers {
+		count := vertexCount
+		if layout.StepMode == api.VertexStepMode_Instance {
+			count = instanceCount
+		}
+		var end uint64
+		for _, a := range layout.Attributes {
+			if e := a.Offset + vertexFormatSizes[a.Format]; e > end {
+				end = e
+			}
+		}
+		if count == 0 || end == 0 {
+			continue
+		}
+		if required := (count-1)*layout.ArrayStride + end; required > s.vertexBuffers[i].size {
+			return validationError(op, s.label, "vertex buffer at slot %d needs %d bytes, but only %d are bound", i, required, s.vertexBuffers[i].size)
+		}
+	}
+	return nil
+}
+
+func (s *renderState) draw(op string, vertexCount, instanceCount, firstVertex, firstInstance uint32) error {
+	if err := s.checkDraw(op); err != nil {
+		return err
+	}
+	if vertexCount == 0 || instanceCount == 0 {
+		return nil
+	}
+	return s.checkVertexRanges(op, uint64(firstVertex)+uint64(vertexCount), uint64(firstInstance)+uint64(instanceCount))
+}
+
+func (s *renderState) drawIndexed(op string, indexCount, instanceCount, firstIndex, firstInstance uint32) error {
+	if err := s.checkDraw(op); err != nil {
+		return err
+	}
+	if s.index == nil {
+		return validationError(op, s.label, "no index buffer is set")
+	}
+	if required := (uint64(firstIndex) + uint64(indexCount)) * indexFormatSize(s.indexFormat); required > s.index.size {
+		return validationError(op, s.label, "indices %d..%d are out of bounds of the %d bound bytes", firstIndex, uint64(firstIndex)+uint64(indexCount), s.index.size)
+	}
+	if indexCount == 0 || instanceCount == 0 {
+		return nil
+	}
+	// vertices are read by index, which is only known on the GPU.
+	return s.checkVertexRanges(op, 0, uint64(firstInstance)+uint64(instanceCount))
+}
+
+func (s *renderState) drawIndirect(op string, indexed bool, indirectBuffer api.Buffer, indirectOffset uint64) error {
+	if err := s.checkDraw(op); err != nil {
+		return err
+	}
+	size := uint64(16)
+	if indexed {
+		if s.index == nil {
+			return validationError(op, s.label, "no index buffer is set")
+		}
+		size = 20
+	}
+	buffer, err := s.device.indirectBuffer(op, s.label, indirectBuffer, indirectOffset, size)
+	if err != nil {
+		return err
+	}
+	s.used.buffers = append(s.used.buffers, buffer)
+	return nil
+}
+
+type RenderPassEncoder struct {
+	encoder *CommandEncoder
+	locked  bool
+	render  renderState
+
+	width, height uint32
+	occlusion     bool
+	statistics    bool
+	debugDepth    int
+	err           error
+	ended         bool
+
+	released bool
+}
+
+func (p *CommandEncoder) BeginRenderPass(descriptor *api.RenderPassDescriptor) api.RenderPassEncoder {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if descriptor == nil {
+		descriptor = &api.RenderPassDescriptor{}
+	}
+	pass := &RenderPassEncoder{
+		encoder: p,
+		render:  newRenderState(p.device, descriptor.Label, &p.used),
+	}
+	if err := p.checkOpen("fake.(*CommandEncoder).BeginRenderPass()"); err != nil {
+		pass.err = p.fail(err)
+		return pass
+	}
+	p.state = encoderLocked
+	pass.locked = true
+	pass.fail(pass.attach(descriptor))
+	return pass
+}
+
+// attachment validates an attachment view, checking that all attachments
+// have the same size and sample count.
+func (p *RenderPassEncoder) attachment(op string, v api.TextureView, name string) (*TextureView, error) {
+	label := p.render.label
+	view := as[TextureView](v, "TextureView")
+	if view == nil {
+		return nil, validationError(op, label, "%s view must be set", name)
+	}
+	view.checkReleased()
+	t := view.texture
+	if err := p.encoder.device.checkDevice(op, label, t.device, name+" view"); err != nil {
+		return nil, err
+	}
+	switch {
+	case t.usage&api.TextureUsage_RenderAttachment == 0:
+		return nil, validationError(op, label, "%s texture usage doesn't contain RenderAttachment", name)
+	case view.mipLevelCount != 1 || view.layerCount != 1:
+		return nil, validationError(op, label, "%s view must have a single mip level and array layer", name)
+	}
+	size := t.mipSize(view.baseMipLevel)
+	if p.width == 0 {
+		p.width, p.height = size.Width, size.Height
+	} else if size.Width != p.width || size.Height != p.height {
+		return nil, validationError(op, label, "%s size %dx%d differs from the attachment size %dx%d", name, size.Width, size.Height, p.width, p.height)
+	}
+	p.render.used.textures = append(p.render.used.textures, t)
+	return view, nil
+}
+
+func (p *RenderPassEncoder) attach(descriptor *api.RenderPassDescriptor) error {
+	const op = "fake.(*CommandEncoder).BeginRenderPass()"
+	label := p.render.label
+	limits := p.encoder.device.limits
+
+	if uint32(len(descriptor.ColorAttachments)) > limits.MaxColorAttachments {
+		return validationError(op, label, "%d color attachments exceed the MaxColorAttachments limit of %d", len(descriptor.ColorAttachments), limits.MaxColorAttachments)
+	}
+	for i, a := range descriptor.ColorAttachments {
+		if a.View == nil {
+			p.render.colorFormats = append(p.render.colorFormats, api.TextureFormat_Undefined)
+			continue
+		}
+		view, err := p.attachment(op, a.View, "color attachment")
+		if err != nil {
+			return err
+		}
+		info := formats[view.format]
+		switch {
+		case !info.renderable || info.depth || info.stencil:
+			return validationError(op, label, "color attachment %d: format %s is not a renderable color format", i, view.format)
+		case a.LoadOp == api.LoadOp_Undefined || a.StoreOp == api.StoreOp_Undefined:
+			return validationError(op, label, "color attachment %d: load and store ops must be set", i)
+		case p.render.sampleCount != 0 && view.texture.sampleCount != p.render.sampleCount:
+			return validationError(op, label, "color attachment %d: sample count %d differs from %d", i, view.texture.sampleCount, p.render.sampleCount)
+		}
+		p.render.sampleCount = view.texture.sampleCount
+		p.render.colorFormats = append(p.render.colorFormats, view.format)
+
+		if a.ResolveTarget != nil {
+			resolve, err := p.attachment(op, a.ResolveTarget, "resolve target")
+			if err != nil {
+				return err
+			}
+			switch {
+			case view.texture.sampleCount == 1:
+				return validationError(op, label, "color attachment %d: resolve target needs a multisampled attachment", i)
+			case resolve.texture.sampleCount != 1:
+				return validationError(op, label, "color attachment %d: resolve target must not be multisampled", i)
+			case resolve.format != view.format:
+				return validationError(op, label, "color attachment %d: resolve target format %s differs from %s", i, resolve.format, view.format)
+			}
+		}
+	}
+
+	if a := descriptor.DepthStencilAttachment; a != nil {
+		view, err := p.attachment(op, a.View, "depth stencil attachment")
+		if err != nil {
+			return err
+		}
+		info := formats[view.format]
+		switch {
+		case !info.depth && !info.stencil:
+			return validationError(op, label, "depth stencil attachment format %s is not a depth or stencil format", view.format)
+		case info.depth && !a.DepthReadOnly && (a.DepthLoadOp == api.LoadOp_Undefined || a.DepthStoreOp == api.StoreOp_Undefined):
+			return validationError(op, label, "depth load and store ops must be set")
+		case info.stencil && !a.StencilReadOnly && (a.StencilLoadOp == api.LoadOp_Undefined || a.StencilStoreOp == api.StoreOp_Undefined):
+			return validationError(op, label, "stencil load and store ops must be set")
+		case p.render.sampleCount != 0 && view.texture.sampleCount != p.render.sampleCount:
+			return validationError(op, label, "depth stencil attachment sample count %d differs from %d", view.texture.sampleCount, p.render.sampleCount)
+		}
+		p.render.sampleCount = view.texture.sampleCount
+		p.render.depthStencilFormat = view.format
+		p.render.depthReadOnly = a.DepthReadOnly
+		p.render.stencilReadOnly = a.StencilReadOnly
+	}
+
+	if p.width == 0 {
+		return validationError(op, label, "render pass has no attachments")
+	}
+	return nil
+}
+
+func (p *RenderPassEncoder) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "RenderPassEncoder", Label: p.render.label})
+	}
+}
+
+// begin locks the device for op. It returns false if the pass can't record
+// commands anymore.
+func (p *RenderPassEncoder) begin(op string) bool {
+	p.encoder.device.mu.Lock()
+	p.checkReleased()
+	if p.ended {
+		p.encoder.fail(validationError(op, p.render.label, "pass is ended"))
+		return false
+	}
+	return p.err == nil
+}
+
+func (p *RenderPassEncoder) end() {
+	p.encoder.device.mu.Unlock()
+}
+
+// fail records err as the error of the pass, if it is the first one.
+func (p *RenderPassEncoder) fail(err error) {
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+}
+
+func (p *RenderPassEncoder) BeginOcclusionQuery(queryIndex uint32) {
+	const op = "fake.(*RenderPassEncoder).BeginOcclusionQuery()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	// RenderPassDescriptor has no occlusion query set to write to.
+	p.fail(validationError(op, p.render.label, "render pass has no occlusion query set"))
+}
+
+func (p *RenderPassEncoder) BeginPipelineStatisticsQuery(querySet api.QuerySet, queryIndex uint32) {
+	const op = "fake.(*RenderPassEncoder).BeginPipelineStatisticsQuery()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	qs, err := p.encoder.querySet(op, querySet)
+	switch {
+	case err != nil:
+		p.fail(err)
+	case qs.typ != api.QueryType_PipelineStatistics:
+		p.fail(validationError(op, qs.label, "query set type %s is not PipelineStatistics", qs.typ))
+	case queryIndex >= qs.count:
+		p.fail(validationError(op, qs.label, "query %d is out of bounds of query set with %d queries", queryIndex, qs.count))
+	case p.statistics:
+		p.fail(validationError(op, p.render.label, "a pipeline statistics query is already active"))
+	default:
+		p.statistics = true
+	}
+}
+
+func (p *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	const op = "fake.(*RenderPassEncoder).Draw()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.draw(op, vertexCount, instanceCount, firstVertex, firstInstance))
+}
+
+func (p *RenderPassEncoder) DrawIndexed(indexCount uint32, instanceCount uint32, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	const op = "fake.(*RenderPassEncoder).DrawIndexed()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.drawIndexed(op, indexCount, instanceCount, firstIndex, firstInstance))
+}
+
+func (p *RenderPassEncoder) DrawIndexedIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	const op = "fake.(*RenderPassEncoder).DrawIndexedIndirect()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.drawIndirect(op, true, indirectBuffer, indirectOffset))
+}
+
+func (p *RenderPassEncoder) DrawIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	const op = "fake.(*RenderPassEncoder).DrawIndirect()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.drawIndirect(op, false, indirectBuffer, indirectOffset))
+}
+
+func (p *RenderPassEncoder) End() error {
+	p.checkReleased()
+	const op = "fake.(*RenderPassEncoder).End()"
+	p.encoder.device.mu.Lock()
+	defer p.encoder.device.mu.Unlock()
+
+	if p.ended {
+		return validationError(op, p.render.label, "pass is already ended")
+	}
+	p.ended = true
+	if p.locked {
+		p.encoder.state = encoderOpen
+	}
+	switch {
+	case p.debugDepth != 0:
+		p.fail(validationError(op, p.render.label, "%d debug groups are not popped", p.debugDepth))
+	case p.statistics:
+		p.fail(validationError(op, p.render.label, "pipeline statistics query is not ended"))
+	}
+	if p.err != nil {
+		return p.encoder.fail(p.err)
+	}
+	return nil
+}
+
+func (p *RenderPassEncoder) EndOcclusionQuery() {
+	const op = "fake.(*RenderPassEncoder).EndOcclusionQuery()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(validationError(op, p.render.label, "no occlusion query is active"))
+}
+
+func (p *RenderPassEncoder) EndPipelineStatisticsQuery() {
+	const op = "fake.(*RenderPassEncoder).EndPipelineStatisticsQuery()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	if !p.statistics {
+		p.fail(validationError(op, p.render.label, "no pipeline statistics query is active"))
+	}
+	p.statistics = false
+}
+
+func (p *RenderPassEncoder) ExecuteBundles(bundles ...api.RenderBundle) {
+	const op = "fake.(*RenderPassEncoder).ExecuteBundles()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	label := p.render.label
+	for i, v := range bundles {
+		bundle := as[RenderBundle](v, "RenderBundle")
+		if bundle == nil {
+			p.fail(validationError(op, label, "bundle %d must be set", i))
+			return
+		}
+		bundle.checkReleased()
+		if err := p.encoder.device.checkDevice(op, label, bundle.device, "bundle"); err != nil {
+			p.fail(err)
+			return
+		}
+		switch {
+		case bundle.err != nil:
+			p.fail(validationError(op, label, "bundle %q is invalid: %v", bundle.label, bundle.err))
+			return
+		case !p.render.compatibleFormats(bundle.colorFormats, bundle.depthStencilFormat, bundle.sampleCount):
+			p.fail(validationError(op, label, "bundle %q formats are not compatible with the attachments", bundle.label))
+			return
+		case p.render.depthReadOnly && !bundle.depthReadOnly:
+			p.fail(validationError(op, label, "bundle %q may write to read-only depth", bundle.label))
+			return
+		case p.render.stencilReadOnly && !bundle.stencilReadOnly:
+			p.fail(validationError(op, label, "bundle %q may write to read-only stencil", bundle.label))
+			return
+		}
+		p.render.used.add(&bundle.used)
+	}
+	p.render.reset()
+}
+
+func (p *RenderPassEncoder) InsertDebugMarker(markerLabel string) {
+	defer p.end()
+	p.begin("fake.(*RenderPassEncoder).InsertDebugMarker()")
+}
+
+func (p *RenderPassEncoder) PopDebugGroup() {
+	const op = "fake.(*RenderPassEncoder).PopDebugGroup()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	if p.debugDepth == 0 {
+		p.fail(validationError(op, p.render.label, "no debug group to pop"))
+		return
+	}
+	p.debugDepth--
+}
+
+func (p *RenderPassEncoder) PushDebugGroup(groupLabel string) {
+	defer p.end()
+	if !p.begin("fake.(*RenderPassEncoder).PushDebugGroup()") {
+		return
+	}
+	p.debugDepth++
+}
+
+func (p *RenderPassEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	const op = "fake.(*RenderPassEncoder).SetBindGroup()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setBindGroup(op, groupIndex, group, dynamicOffsets))
+}
+
+func (p *RenderPassEncoder) SetBlendConstant(color *api.Color) {
+	const op = "fake.(*RenderPassEncoder).SetBlendConstant()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	if color == nil {
+		p.fail(validationError(op, p.render.label, "color must be set"))
+	}
+}
+
+func (p *RenderPassEncoder) SetIndexBuffer(buffer api.Buffer, format api.IndexFormat, offset uint64, size uint64) {
+	const op = "fake.(*RenderPassEncoder).SetIndexBuffer()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setIndexBuffer(op, buffer, format, offset, size))
+}
+
+func (p *RenderPassEncoder) SetPipeline(pipeline api.RenderPipeline) {
+	const op = "fake.(*RenderPassEncoder).SetPipeline()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setPipeline(op, pipeline))
+}
+
+func (p *RenderPassEncoder) SetPushConstants(stages api.ShaderStage, offset uint32, data []byte) {
+	const op = "fake.(*RenderPassEncoder).SetPushConstants()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	label := p.render.label
+	end := uint64(offset) + uint64(len(data))
+	switch {
+	case p.render.pipeline == nil:
+		p.fail(validationError(op, label, "no pipeline is set"))
+		return
+	case offset%api.PushConstantAlignment != 0 || len(data)%api.PushConstantAlignment != 0:
+		p.fail(validationError(op, label, "offset %d and size %d must be multiples of %d", offset, len(data), api.PushConstantAlignment))
+		return
+	}
+	var ranges []api.PushConstantRange
+	if layout := p.render.pipeline.layouts.layout; layout != nil {
+		ranges = layout.pushConstantRanges
+	}
+	// every stage in stages must be covered by a range holding the data,
+	// and every range overlapping the data must be included in stages.
+	var covered api.ShaderStage
+	for _, r := range ranges {
+		if uint64(r.Start) >= end || uint64(r.End) <= uint64(offset) {
+			continue
+		}
+		if r.Stages&^stages != 0 {
+			p.fail(validationError(op, label, "push constant range %d..%d for stages %s is not included in %s", r.Start, r.End, r.Stages, stages))
+			return
+		}
+		if uint64(r.Start) <= uint64(offset) && end <= uint64(r.End) {
+			covered |= r.Stages
+		}
+	}
+	if stages&^covered != 0 || stages == api.ShaderStage_None {
+		p.fail(validationError(op, label, "push constants %d..%d for stages %s are not in the pipeline layout", offset, end, stages))
+	}
+}
+
+func (p *RenderPassEncoder) SetScissorRect(x, y, width, height uint32) {
+	const op = "fake.(*RenderPassEncoder).SetScissorRect()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	if uint64(x)+uint64(width) > uint64(p.width) || uint64(y)+uint64(height) > uint64(p.height) {
+		p.fail(validationError(op, p.render.label, "scissor rect %d,%d %dx%d is out of bounds of the %dx%d attachments", x, y, width, height, p.width, p.height))
+	}
+}
+
+func (p *RenderPassEncoder) SetStencilReference(reference uint32) {
+	defer p.end()
+	p.begin("fake.(*RenderPassEncoder).SetStencilReference()")
+}
+
+func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer api.Buffer, offset uint64, size uint64) {
+	const op = "fake.(*RenderPassEncoder).SetVertexBuffer()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setVertexBuffer(op, slot, buffer, offset, size))
+}
+
+func (p *RenderPassEncoder) SetViewport(x, y, width, height, minDepth, maxDepth float32) {
+	const op = "fake.(*RenderPassEncoder).SetViewport()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	switch {
+	case width < 0 || height < 0:
+		p.fail(validationError(op, p.render.label, "viewport size %gx%g must not be negative", width, height))
+	case x < 0 || y < 0 || x+width > float32(p.width) || y+height > float32(p.height):
+		p.fail(validationError(op, p.render.label, "viewport %g,%g %gx%g is out of bounds of the %dx%d attachments", x, y, width, height, p.width, p.height))
+	case minDepth < 0 || minDepth > 1 || maxDepth < 0 || maxDepth > 1 || minDepth > maxDepth:
+		p.fail(validationError(op, p.render.label, "viewport depth range %g..%g must be within 0..1", minDepth, maxDepth))
+	}
+}
+
+func (p *RenderPassEncoder) Release() {
+	p.released = true
+}
+
+var (
+	_ api.ComputePassEncoder = (*ComputePassEncoder)(nil)
+	_ api.RenderPassEncoder  = (*RenderPassEncoder)(nil)
+)
@@ -0,0 +1,371 @@
+package fake_test
+
+import (
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+const shader = `
+@group(0) @binding(0) var<storage, read_write> data: array<u32>;
+
+@compute @workgroup_size(64)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+	data[id.x] = id.x;
+}
+
+@vertex
+fn vs_main(@builtin(vertex_index) i: u32) -> @builtin(position) vec4<f32> {
+	return vec4<f32>(0.0, 0.0, 0.0, 1.0);
+}
+
+@fragment
+fn fs_main() -> @location(0) vec4<f32> {
+	return vec4<f32>(1.0, 0.0, 0.0, 1.0);
+}
+`
+
+func testModule(t *testing.T, device api.Device) api.ShaderModule {
+	t.Helper()
+
+	module, err := device.CreateShaderModule(&api.ShaderModuleDescriptor{
+		WGSLDescriptor: &api.ShaderModuleWGSLDescriptor{Code: shader},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return module
+}
+
+func TestCreateShaderModuleErrors(t *testing.T) {
+	device := testDevice(t)
+
+	invalid := []api.ShaderModuleDescriptor{
+		{},
+		{
+			WGSLDescriptor:  &api.ShaderModuleWGSLDescriptor{Code: shader},
+			SPIRVDescriptor: &api.ShaderModuleSPIRVDescriptor{Code: []byte{0x03, 0x02, 0x23, 0x07}},
+		},
+		{WGSLDescriptor: &api.ShaderModuleWGSLDescriptor{}},
+		{SPIRVDescriptor: &api.ShaderModuleSPIRVDescriptor{Code: []byte{0x03, 0x02, 0x23}}},
+		{SPIRVDescriptor: &api.ShaderModuleSPIRVDescriptor{Code: []byte{0x07, 0x23, 0x02, 0x03}}},
+	}
+	for _, descriptor := range invalid {
+		_, err := device.CreateShaderModule(&descriptor)
+		expectValidation(t, err)
+	}
+}
+
+func TestComputePass(t *testing.T) {
+	device := testDevice(t)
+	module := testModule(t, device)
+
+	layout, err := device.CreateBindGroupLayout(&api.BindGroupLayoutDescriptor{
+		Entries: []api.BindGroupLayoutEntry{{
+			Binding:    0,
+			Visibility: api.ShaderStage_Compute,
+			Buffer:     api.BufferBindingLayout{Type: api.BufferBindingType_Storage},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipelineLayout, err := device.CreatePipelineLayout(&api.PipelineLayoutDescriptor{
+		BindGroupLayouts: []api.BindGroupLayout{layout},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = device.CreateComputePipeline(&api.ComputePipelineDescriptor{
+		Layout:  pipelineLayout,
+		Compute: api.ProgrammableStageDescriptor{Module: module, EntryPoint: "vs_main"},
+	})
+	expectValidation(t, err)
+	pipeline, err := device.CreateComputePipeline(&api.ComputePipelineDescriptor{
+		Layout:  pipelineLayout,
+		Compute: api.ProgrammableStageDescriptor{Module: module, EntryPoint: "main"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_Storage | api.BufferUsage_Indirect, Size: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uniform, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_Uniform, Size: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bindGroup, err := device.CreateBindGroup(&api.BindGroupDescriptor{
+		Layout:  layout,
+		Entries: []api.BindGroupEntry{{Binding: 0, Buffer: buffer, Size: api.WholeSize}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := [][]api.BindGroupEntry{
+		nil,
+		{{Binding: 1, Buffer: buffer, Size: api.WholeSize}},
+		{{Binding: 0, Buffer: buffer, Offset: 128, Size: api.WholeSize}},
+		{{Binding: 0, Buffer: buffer, Offset: 256, Size: 1024}},
+		{{Binding: 0, Buffer: buffer, Size: 6}},
+		{{Binding: 0, Buffer: uniform, Size: api.WholeSize}},
+	}
+	for _, entries := range invalid {
+		_, err := device.CreateBindGroup(&api.BindGroupDescriptor{Layout: layout, Entries: entries})
+		expectValidation(t, err)
+	}
+
+	// bind groups of pipeline created layouts only match that pipeline.
+	autoPipeline, err := device.CreateComputePipeline(&api.ComputePipelineDescriptor{
+		Compute: api.ProgrammableStageDescriptor{Module: module, EntryPoint: "main"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	autoBindGroup, err := device.CreateBindGroup(&api.BindGroupDescriptor{
+		Layout:  autoPipeline.GetBindGroupLayout(0),
+		Entries: []api.BindGroupEntry{{Binding: 0, Buffer: buffer, Size: api.WholeSize}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		record func(pass api.ComputePassEncoder)
+		valid  bool
+	}{
+		{"dispatch", func(pass api.ComputePassEncoder) {
+			pass.SetPipeline(pipeline)
+			pass.SetBindGroup(0, bindGroup, nil)
+			pass.DispatchWorkgroups(16, 1, 1)
+			pass.DispatchWorkgroupsIndirect(buffer, 4)
+		}, true},
+		{"no pipeline", func(pass api.ComputePassEncoder) {
+			pass.DispatchWorkgroups(1, 1, 1)
+		}, false},
+		{"auto layout", func(pass api.ComputePassEncoder) {
+			pass.SetPipeline(autoPipeline)
+			pass.SetBindGroup(0, autoBindGroup, nil)
+			pass.DispatchWorkgroups(1, 1, 1)
+		}, true},
+		{"no bind group", func(pass api.ComputePassEncoder) {
+			pass.SetPipeline(pipeline)
+			pass.DispatchWorkgroups(1, 1, 1)
+		}, false},
+		{"incompatible bind group", func(pass api.ComputePassEncoder) {
+			pass.SetPipeline(pipeline)
+			pass.SetBindGroup(0, autoBindGroup, nil)
+			pass.DispatchWorkgroups(1, 1, 1)
+		}, false},
+		{"workgroup count", func(pass api.ComputePassEncoder) {
+			pass.SetPipeline(pipeline)
+			pass.SetBindGroup(0, bindGroup, nil)
+			pass.DispatchWorkgroups(1, 65536, 1)
+		}, false},
+		{"indirect offset", func(pass api.ComputePassEncoder) {
+			pass.SetPipeline(pipeline)
+			pass.SetBindGroup(0, bindGroup, nil)
+			pass.DispatchWorkgroupsIndirect(buffer, 2)
+		}, false},
+		{"dynamic offsets", func(pass api.ComputePassEncoder) {
+			pass.SetBindGroup(0, bindGroup, []uint32{0})
+		}, false},
+		{"debug group", func(pass api.ComputePassEncoder) {
+			pass.PushDebugGroup("group")
+		}, false},
+		{"pop debug group", func(pass api.ComputePassEncoder) {
+			pass.PopDebugGroup()
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder, err := device.CreateCommandEncoder(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pass := encoder.BeginComputePass(nil)
+			tt.record(pass)
+			err = pass.End()
+			_, finishErr := encoder.Finish(nil)
+			if tt.valid {
+				if err != nil || finishErr != nil {
+					t.Errorf("got errors %v and %v", err, finishErr)
+				}
+				return
+			}
+			expectValidation(t, err)
+			expectValidation(t, finishErr)
+		})
+	}
+}
+
+func TestEncoderState(t *testing.T) {
+	device := testDevice(t)
+
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopyDst, Size: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an open pass locks the encoder.
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pass := encoder.BeginComputePass(nil)
+	expectValidation(t, encoder.ClearBuffer(buffer, 0, 16))
+	if err := pass.End(); err != nil {
+		t.Fatal(err)
+	}
+	expectValidation(t, pass.End())
+	_, err = encoder.Finish(nil)
+	expectValidation(t, err)
+
+	// a debug group must be popped before Finish.
+	encoder, err = device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.PushDebugGroup("group"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = encoder.Finish(nil)
+	expectValidation(t, err)
+	expectValidation(t, encoder.ClearBuffer(buffer, 0, 16))
+
+	// a command buffer is only submitted once.
+	encoder, err = device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, err := encoder.Finish(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	device.SetUncapturedErrorCallback(func(err error) { errs = append(errs, err) })
+	device.GetQueue().Submit(commands)
+	device.GetQueue().Submit(commands)
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, expected 1", len(errs))
+	}
+	expectValidation(t, errs[0])
+}
+
+func TestRenderPass(t *testing.T) {
+	device := testDevice(t)
+	module := testModule(t, device)
+
+	texture := func(format api.TextureFormat, width uint32) api.TextureView {
+		t.Helper()
+		texture, err := device.CreateTexture(&api.TextureDescriptor{
+			Usage:         api.TextureUsage_RenderAttachment,
+			Dimension:     api.TextureDimension_2D,
+			Size:          api.Extent3D{Width: width, Height: 16, DepthOrArrayLayers: 1},
+			Format:        format,
+			MipLevelCount: 1,
+			SampleCount:   1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		view, err := texture.CreateView(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return view
+	}
+	color := texture(api.TextureFormat_RGBA8Unorm, 16)
+	depth := texture(api.TextureFormat_Depth32Float, 16)
+	small := texture(api.TextureFormat_RGBA8Unorm, 8)
+
+	pipeline, err := device.CreateRenderPipeline(&api.RenderPipelineDescriptor{
+		Vertex:      api.VertexState{Module: module, EntryPoint: "vs_main"},
+		Primitive:   api.PrimitiveState{Topology: api.PrimitiveTopology_TriangleList},
+		Multisample: api.MultisampleState{Count: 1, Mask: ^uint32(0)},
+		Fragment: &api.FragmentState{
+			Module:     module,
+			EntryPoint: "fs_main",
+			Targets:    []api.ColorTargetState{{Format: api.TextureFormat_RGBA8Unorm, WriteMask: api.ColorWriteMask_All}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = device.CreateRenderPipeline(&api.RenderPipelineDescriptor{
+		Vertex:      api.VertexState{Module: module, EntryPoint: "vs_main"},
+		Primitive:   api.PrimitiveState{Topology: api.PrimitiveTopology_TriangleList, StripIndexFormat: api.IndexFormat_Uint16},
+		Multisample: api.MultisampleState{Count: 1},
+	})
+	expectValidation(t, err)
+
+	colorAttachment := func(view api.TextureView) api.RenderPassColorAttachment {
+		return api.RenderPassColorAttachment{View: view, LoadOp: api.LoadOp_Clear, StoreOp: api.StoreOp_Store}
+	}
+	tests := []struct {
+		name       string
+		descriptor api.RenderPassDescriptor
+		record     func(pass api.RenderPassEncoder)
+		valid      bool
+	}{
+		{"draw", api.RenderPassDescriptor{
+			ColorAttachments: []api.RenderPassColorAttachment{colorAttachment(color)},
+		}, func(pass api.RenderPassEncoder) {
+			pass.SetPipeline(pipeline)
+			pass.SetViewport(0, 0, 16, 16, 0, 1)
+			pass.Draw(3, 1, 0, 0)
+		}, true},
+		{"no attachments", api.RenderPassDescriptor{}, func(pass api.RenderPassEncoder) {}, false},
+		{"attachment size", api.RenderPassDescriptor{
+			ColorAttachments: []api.RenderPassColorAttachment{colorAttachment(color), colorAttachment(small)},
+		}, func(pass api.RenderPassEncoder) {}, false},
+		{"depth ops", api.RenderPassDescriptor{
+			ColorAttachments:       []api.RenderPassColorAttachment{colorAttachment(color)},
+			DepthStencilAttachment: &api.RenderPassDepthStencilAttachment{View: depth},
+		}, func(pass api.RenderPassEncoder) {}, false},
+		{"incompatible pipeline", api.RenderPassDescriptor{
+			DepthStencilAttachment: &api.RenderPassDepthStencilAttachment{View: depth, DepthReadOnly: true},
+		}, func(pass api.RenderPassEncoder) {
+			pass.SetPipeline(pipeline)
+		}, false},
+		{"no pipeline", api.RenderPassDescriptor{
+			ColorAttachments: []api.RenderPassColorAttachment{colorAttachment(color)},
+		}, func(pass api.RenderPassEncoder) {
+			pass.Draw(3, 1, 0, 0)
+		}, false},
+		{"viewport", api.RenderPassDescriptor{
+			ColorAttachments: []api.RenderPassColorAttachment{colorAttachment(color)},
+		}, func(pass api.RenderPassEncoder) {
+			pass.SetViewport(0, 0, 32, 16, 0, 1)
+		}, false},
+		{"scissor rect", api.RenderPassDescriptor{
+			ColorAttachments: []api.RenderPassColorAttachment{colorAttachment(color)},
+		}, func(pass api.RenderPassEncoder) {
+			pass.SetScissorRect(8, 0, 16, 16)
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder, err := device.CreateCommandEncoder(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pass := encoder.BeginRenderPass(&tt.descriptor)
+			tt.record(pass)
+			err = pass.End()
+			_, finishErr := encoder.Finish(nil)
+			if tt.valid {
+				if err != nil || finishErr != nil {
+					t.Errorf("got errors %v and %v", err, finishErr)
+				}
+				return
+			}
+			expectValidation(t, err)
+			expectValidation(t, finishErr)
+		})
+	}
+}
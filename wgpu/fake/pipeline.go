@@ -0,0 +1,277 @@
+package fake
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+// pipelineLayouts holds the bind group layouts of a pipeline, explicit or
+// created on demand for pipelines without a layout.
+type pipelineLayouts struct {
+	device *Device
+	layout *PipelineLayout
+	auto   map[uint32]*BindGroupLayout
+}
+
+func (p *pipelineLayouts) get(groupIndex uint32) *BindGroupLayout {
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if p.layout != nil {
+		if groupIndex >= uint32(len(p.layout.bindGroupLayouts)) {
+			panic("Failed to accquire BindGroupLayout")
+		}
+		return p.layout.bindGroupLayouts[groupIndex]
+	}
+	if groupIndex >= p.device.limits.MaxBindGroups {
+		panic("Failed to accquire BindGroupLayout")
+	}
+	if p.auto == nil {
+		p.auto = map[uint32]*BindGroupLayout{}
+	}
+	layout, ok := p.auto[groupIndex]
+	if !ok {
+		layout = &BindGroupLayout{device: p.device, auto: true}
+		p.auto[groupIndex] = layout
+	}
+	return layout
+}
+
+// checkBindGroups returns an error if groups doesn't hold a compatible bind
+// group for every bind group layout of the pipeline.
+func (p *pipelineLayouts) checkBindGroups(op, label string, groups []*boundGroup) error {
+	count := len(groups)
+	if p.layout != nil {
+		count = len(p.layout.bindGroupLayouts)
+	}
+	for i := 0; i < count; i++ {
+		var layout *BindGroupLayout
+		if p.layout != nil {
+			layout = p.layout.bindGroupLayouts[i]
+		} else if layout = p.auto[uint32(i)]; layout == nil {
+			continue
+		}
+		if i >= len(groups) || groups[i] == nil {
+			return validationError(op, label, "no bind group is set at index %d", i)
+		}
+		if !layout.compatible(groups[i].group.layout) {
+			return validationError(op, label, "bind group at index %d is not compatible with the pipeline layout", i)
+		}
+	}
+	return nil
+}
+
+func (p *Device) pipelineLayout(op, label string, v api.PipelineLayout) (*PipelineLayout, error) {
+	layout := as[PipelineLayout](v, "PipelineLayout")
+	if layout == nil {
+		return nil, nil
+	}
+	layout.checkReleased()
+	if err := p.checkDevice(op, label, layout.device, "layout"); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+func (p *Device) shaderStage(op, label string, module api.ShaderModule, stage, entryPoint string) error {
+	m := as[ShaderModule](module, "ShaderModule")
+	if m == nil {
+		return validationError(op, label, "%s module must be set", stage)
+	}
+	m.checkReleased()
+	if err := p.checkDevice(op, label, m.device, "shader module"); err != nil {
+		return err
+	}
+	if !m.hasEntryPoint(stage, entryPoint) {
+		return validationError(op, label, "module has no @%s entry point %q", stage, entryPoint)
+	}
+	return nil
+}
+
+type ComputePipeline struct {
+	device  *Device
+	label   string
+	layouts pipelineLayouts
+
+	released bool
+}
+
+func (p *Device) CreateComputePipeline(descriptor *api.ComputePipelineDescriptor) (api.ComputePipeline, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateComputePipeline()"
+	if descriptor == nil {
+		descriptor = &api.ComputePipelineDescriptor{}
+	}
+	label := descriptor.Label
+
+	layout, err := p.pipelineLayout(op, label, descriptor.Layout)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.shaderStage(op, label, descriptor.Compute.Module, "compute", descriptor.Compute.EntryPoint); err != nil {
+		return nil, err
+	}
+
+	return &ComputePipeline{
+		device:  p,
+		label:   label,
+		layouts: pipelineLayouts{device: p, layout: layout},
+	}, nil
+}
+
+func (p *ComputePipeline) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "ComputePipeline", Label: p.label})
+	}
+}
+
+func (p *ComputePipeline) GetBindGroupLayout(groupIndex uint32) api.BindGroupLayout {
+	p.checkReleased()
+	return p.layouts.get(groupIndex)
+}
+
+func (p *ComputePipeline) Release() {
+	p.released = true
+}
+
+type RenderPipeline struct {
+	device  *Device
+	label   string
+	layouts pipelineLayouts
+
+	vertexBuffers      []api.VertexBufferLayout
+	topology           api.PrimitiveTopology
+	colorFormats       []api.TextureFormat
+	depthStencilFormat api.TextureFormat
+	sampleCount        uint32
+	writesDepth        bool
+	writesStencil      bool
+
+	released bool
+}
+
+func (p *Device) CreateRenderPipeline(descriptor *api.RenderPipelineDescriptor) (api.RenderPipeline, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateRenderPipeline()"
+	if descriptor == nil {
+		descriptor = &api.RenderPipelineDescriptor{}
+	}
+	label := descriptor.Label
+
+	layout, err := p.pipelineLayout(op, label, descriptor.Layout)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.shaderStage(op, label, descriptor.Vertex.Module, "vertex", descriptor.Vertex.EntryPoint); err != nil {
+		return nil, err
+	}
+
+	if uint32(len(descriptor.Vertex.Buffers)) > p.limits.MaxVertexBuffers {
+		return nil, validationError(op, label, "%d vertex buffers exceed the MaxVertexBuffers limit of %d", len(descriptor.Vertex.Buffers), p.limits.MaxVertexBuffers)
+	}
+	locations := map[uint32]bool{}
+	for i, b := range descriptor.Vertex.Buffers {
+		switch {
+		case b.ArrayStride > uint64(p.limits.MaxVertexBufferArrayStride):
+			return nil, validationError(op, label, "vertex buffer %d: stride %d exceeds the MaxVertexBufferArrayStride limit of %d", i, b.ArrayStride, p.limits.MaxVertexBufferArrayStride)
+		case b.ArrayStride%api.VertexStrideAlignment != 0:
+			return nil, validationError(op, label, "vertex buffer %d: stride %d is not a multiple of %d", i, b.ArrayStride, api.VertexStrideAlignment)
+		}
+		for _, a := range b.Attributes {
+			size, ok := vertexFormatSizes[a.Format]
+			end := b.ArrayStride
+			if end == 0 {
+				end = uint64(p.limits.MaxVertexBufferArrayStride)
+			}
+			switch {
+			case !ok:
+				return nil, validationError(op, label, "vertex buffer %d: unknown attribute format %s", i, a.Format)
+			case a.Offset%4 != 0 && a.Offset%size != 0:
+				return nil, validationError(op, label, "vertex buffer %d: attribute offset %d is not aligned", i, a.Offset)
+			case a.Offset+size > end:
+				return nil, validationError(op, label, "vertex buffer %d: attribute at offset %d doesn't fit in stride %d", i, a.Offset, end)
+			case a.ShaderLocation >= p.limits.MaxVertexAttributes:
+				return nil, validationError(op, label, "vertex buffer %d: shader location %d exceeds the MaxVertexAttributes limit of %d", i, a.ShaderLocation, p.limits.MaxVertexAttributes)
+			case locations[a.ShaderLocation]:
+				return nil, validationError(op, label, "shader location %d is used more than once", a.ShaderLocation)
+			}
+			locations[a.ShaderLocation] = true
+		}
+	}
+
+	primitive := descriptor.Primitive
+	if primitive.StripIndexFormat != api.IndexFormat_Undefined &&
+		primitive.Topology != api.PrimitiveTopology_LineStrip &&
+		primitive.Topology != api.PrimitiveTopology_TriangleStrip {
+		return nil, validationError(op, label, "strip index format needs a strip topology, not %s", primitive.Topology)
+	}
+
+	pipeline := &RenderPipeline{
+		device:      p,
+		label:       label,
+		layouts:     pipelineLayouts{device: p, layout: layout},
+		topology:    primitive.Topology,
+		sampleCount: descriptor.Multisample.Count,
+	}
+	for _, b := range descriptor.Vertex.Buffers {
+		b.Attributes = append([]api.VertexAttribute(nil), b.Attributes...)
+		pipeline.vertexBuffers = append(pipeline.vertexBuffers, b)
+	}
+
+	if f := descriptor.Fragment; f != nil {
+		if err := p.shaderStage(op, label, f.Module, "fragment", f.EntryPoint); err != nil {
+			return nil, err
+		}
+		if uint32(len(f.Targets)) > p.limits.MaxColorAttachments {
+			return nil, validationError(op, label, "%d color targets exceed the MaxColorAttachments limit of %d", len(f.Targets), p.limits.MaxColorAttachments)
+		}
+		for i, t := range f.Targets {
+			if t.Format != api.TextureFormat_Undefined {
+				info, ok := formats[t.Format]
+				if !ok || !info.renderable || info.depth || info.stencil {
+					return nil, validationError(op, label, "color target %d: format %s is not a renderable color format", i, t.Format)
+				}
+			}
+			pipeline.colorFormats = append(pipeline.colorFormats, t.Format)
+		}
+	}
+
+	if ds := descriptor.DepthStencil; ds != nil {
+		info := formats[ds.Format]
+		if !info.depth && !info.stencil {
+			return nil, validationError(op, label, "depth stencil format %s is not a depth or stencil format", ds.Format)
+		}
+		if ds.DepthWriteEnabled && !info.depth {
+			return nil, validationError(op, label, "depth writes need a format with depth, not %s", ds.Format)
+		}
+		pipeline.depthStencilFormat = ds.Format
+		pipeline.writesDepth = ds.DepthWriteEnabled
+		pipeline.writesStencil = ds.StencilWriteMask != 0 && info.stencil
+	}
+
+	switch descriptor.Multisample.Count {
+	case 1, 4:
+	default:
+		return nil, validationError(op, label, "sample count %d must be 1 or 4", descriptor.Multisample.Count)
+	}
+	return pipeline, nil
+}
+
+func (p *RenderPipeline) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "RenderPipeline", Label: p.label})
+	}
+}
+
+func (p *RenderPipeline) GetBindGroupLayout(groupIndex uint32) api.BindGroupLayout {
+	p.checkReleased()
+	return p.layouts.get(groupIndex)
+}
+
+func (p *RenderPipeline) Release() {
+	p.released = true
+}
+
+var (
+	_ api.ComputePipeline = (*ComputePipeline)(nil)
+	_ api.RenderPipeline  = (*RenderPipeline)(nil)
+)
@@ -0,0 +1,138 @@
+package fake
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+type Queue struct {
+	device      *Device
+	submissions api.SubmissionIndex
+
+	released bool
+}
+
+func (p *Queue) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "Queue"})
+	}
+}
+
+// OnSubmittedWorkDone calls callback on the next Device.Poll, as submitted
+// work is done once Submit returns.
+func (p *Queue) OnSubmittedWorkDone(callback api.QueueWorkDoneCallback) {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	p.device.pending = append(p.device.pending, func() {
+		callback(api.QueueWorkDoneStatus_Success)
+	})
+}
+
+// Submit runs the commands of the command buffers. If any of them can't be
+// submitted, the error is reported to the device and none of them run.
+func (p *Queue) Submit(commands ...api.CommandBuffer) api.SubmissionIndex {
+	p.checkReleased()
+	const op = "fake.(*Queue).Submit()"
+	p.device.mu.Lock()
+
+	buffers := make([]*CommandBuffer, 0, len(commands))
+	var err *Error
+	for _, v := range commands {
+		cb := as[CommandBuffer](v, "CommandBuffer")
+		if cb == nil {
+			err = validationError(op, "", "command buffer must be set")
+			break
+		}
+		cb.checkReleased()
+		if cb.device != p.device {
+			err = validationError(op, cb.label, "command buffer was created by another device")
+			break
+		}
+		if cb.submitted {
+			err = validationError(op, cb.label, "command buffer is already submitted")
+			break
+		}
+		if e := cb.used.check(op, cb.label); e != nil {
+			err = e.(*Error)
+			break
+		}
+		buffers = append(buffers, cb)
+	}
+	if err != nil {
+		callback := p.device.report(err)
+		index := p.submissions
+		p.device.mu.Unlock()
+
+		callback()
+		return index
+	}
+
+	for _, cb := range buffers {
+		cb.submitted = true
+		for _, command := range cb.commands {
+			command()
+		}
+	}
+	p.submissions++
+	index := p.submissions
+	p.device.mu.Unlock()
+	return index
+}
+
+func (p *Queue) WriteBuffer(buffer api.Buffer, bufferOffset uint64, data []byte) error {
+	p.checkReleased()
+	const op = "fake.(*Queue).WriteBuffer()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	b := as[Buffer](buffer, "Buffer")
+	if b == nil {
+		return validationError(op, "", "buffer must be set")
+	}
+	b.checkReleased()
+	size := uint64(len(data))
+	switch {
+	case b.device != p.device:
+		return validationError(op, b.label, "buffer was created by another device")
+	case b.usage&api.BufferUsage_CopyDst == 0:
+		return validationError(op, b.label, "buffer usage doesn't contain CopyDst")
+	case bufferOffset%api.CopyBufferAlignment != 0 || size%api.CopyBufferAlignment != 0:
+		return validationError(op, b.label, "offset %d and size %d must be multiples of %d", bufferOffset, size, api.CopyBufferAlignment)
+	}
+	if err := b.checkUsable(op); err != nil {
+		return err
+	}
+	if err := b.checkRange(op, bufferOffset, size); err != nil {
+		return err
+	}
+	copy(b.data[bufferOffset:], data)
+	return nil
+}
+
+func (p *Queue) WriteTexture(destination *api.ImageCopyTexture, data []byte, dataLayout *api.TextureDataLayout, writeSize *api.Extent3D) error {
+	p.checkReleased()
+	const op = "fake.(*Queue).WriteTexture()"
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	t, err := p.device.checkImageCopyTexture(op, "", destination, writeSize, api.TextureUsage_CopyDst)
+	if err != nil {
+		return err
+	}
+	if t.destroyed {
+		return validationError(op, t.label, "texture is destroyed")
+	}
+	l, err := checkLinearLayout(op, t.label, dataLayout, t.info, writeSize, uint64(len(data)), false)
+	if err != nil {
+		return err
+	}
+	copyTexels(t, destination.MipLevel, destination.Origin, *writeSize, data, l, true)
+	return nil
+}
+
+func (p *Queue) Release() {
+	p.released = true
+}
+
+var _ api.Queue = (*Queue)(nil)
@@ -0,0 +1,263 @@
+package fake
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+type RenderBundleEncoder struct {
+	device     *Device
+	render     renderState
+	used       usage
+	debugDepth int
+	err        error
+	finished   bool
+
+	released bool
+}
+
+func (p *Device) CreateRenderBundleEncoder(descriptor *api.RenderBundleEncoderDescriptor) (api.RenderBundleEncoder, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateRenderBundleEncoder()"
+	if descriptor == nil {
+		descriptor = &api.RenderBundleEncoderDescriptor{}
+	}
+	label := descriptor.Label
+
+	if uint32(len(descriptor.ColorFormats)) > p.limits.MaxColorAttachments {
+		return nil, validationError(op, label, "%d color formats exceed the MaxColorAttachments limit of %d", len(descriptor.ColorFormats), p.limits.MaxColorAttachments)
+	}
+	empty := true
+	for i, format := range descriptor.ColorFormats {
+		if format == api.TextureFormat_Undefined {
+			continue
+		}
+		if info, ok := formats[format]; !ok || !info.renderable || info.depth || info.stencil {
+			return nil, validationError(op, label, "color format %d: %s is not a renderable color format", i, format)
+		}
+		empty = false
+	}
+	if format := descriptor.DepthStencilFormat; format != api.TextureFormat_Undefined {
+		if info := formats[format]; !info.depth && !info.stencil {
+			return nil, validationError(op, label, "depth stencil format %s is not a depth or stencil format", format)
+		}
+		empty = false
+	}
+	switch {
+	case empty:
+		return nil, validationError(op, label, "bundle must have at least one attachment format")
+	case descriptor.SampleCount != 1 && descriptor.SampleCount != 4:
+		return nil, validationError(op, label, "sample count %d must be 1 or 4", descriptor.SampleCount)
+	}
+
+	encoder := &RenderBundleEncoder{device: p}
+	encoder.render = newRenderState(p, label, &encoder.used)
+	encoder.render.colorFormats = append([]api.TextureFormat(nil), descriptor.ColorFormats...)
+	encoder.render.depthStencilFormat = descriptor.DepthStencilFormat
+	encoder.render.sampleCount = descriptor.SampleCount
+	encoder.render.depthReadOnly = descriptor.DepthReadOnly
+	encoder.render.stencilReadOnly = descriptor.StencilReadOnly
+	return encoder, nil
+}
+
+func (p *RenderBundleEncoder) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "RenderBundleEncoder", Label: p.render.label})
+	}
+}
+
+// begin locks the device for op. It returns false if the encoder can't
+// record commands anymore.
+func (p *RenderBundleEncoder) begin(op string) bool {
+	p.device.mu.Lock()
+	p.checkReleased()
+	if p.finished {
+		p.fail(validationError(op, p.render.label, "encoder is finished"))
+		return false
+	}
+	return p.err == nil
+}
+
+func (p *RenderBundleEncoder) end() {
+	p.device.mu.Unlock()
+}
+
+// fail records err as the error of the encoder, if it is the first one.
+func (p *RenderBundleEncoder) fail(err error) {
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+}
+
+func (p *RenderBundleEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	const op = "fake.(*RenderBundleEncoder).Draw()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.draw(op, vertexCount, instanceCount, firstVertex, firstInstance))
+}
+
+func (p *RenderBundleEncoder) DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance uint32) {
+	const op = "fake.(*RenderBundleEncoder).DrawIndexed()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.drawIndexed(op, indexCount, instanceCount, firstIndex, firstInstance))
+}
+
+func (p *RenderBundleEncoder) DrawIndexedIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	const op = "fake.(*RenderBundleEncoder).DrawIndexedIndirect()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.drawIndirect(op, true, indirectBuffer, indirectOffset))
+}
+
+func (p *RenderBundleEncoder) DrawIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	const op = "fake.(*RenderBundleEncoder).DrawIndirect()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.drawIndirect(op, false, indirectBuffer, indirectOffset))
+}
+
+// Finish returns the recorded bundle. Like the native implementation,
+// errors are reported to the device, and an invalid bundle is returned that
+// fails the passes executing it.
+func (p *RenderBundleEncoder) Finish(descriptor *api.RenderBundleDescriptor) api.RenderBundle {
+	p.checkReleased()
+	const op = "fake.(*RenderBundleEncoder).Finish()"
+	p.device.mu.Lock()
+
+	label := p.render.label
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	switch {
+	case p.finished:
+		p.fail(validationError(op, p.render.label, "encoder is already finished"))
+	case p.debugDepth != 0:
+		p.fail(validationError(op, p.render.label, "%d debug groups are not popped", p.debugDepth))
+	}
+	p.finished = true
+
+	bundle := &RenderBundle{
+		device:             p.device,
+		label:              label,
+		colorFormats:       p.render.colorFormats,
+		depthStencilFormat: p.render.depthStencilFormat,
+		sampleCount:        p.render.sampleCount,
+		depthReadOnly:      p.render.depthReadOnly,
+		stencilReadOnly:    p.render.stencilReadOnly,
+		used:               p.used,
+		err:                p.err,
+	}
+	callback := func() {}
+	if err, ok := p.err.(*Error); ok {
+		callback = p.device.report(err)
+	}
+	p.device.mu.Unlock()
+
+	callback()
+	return bundle
+}
+
+func (p *RenderBundleEncoder) InsertDebugMarker(markerLabel string) {
+	defer p.end()
+	p.begin("fake.(*RenderBundleEncoder).InsertDebugMarker()")
+}
+
+func (p *RenderBundleEncoder) PopDebugGroup() {
+	const op = "fake.(*RenderBundleEncoder).PopDebugGroup()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+
+	if p.debugDepth == 0 {
+		p.fail(validationError(op, p.render.label, "no debug group to pop"))
+		return
+	}
+	p.debugDepth--
+}
+
+func (p *RenderBundleEncoder) PushDebugGroup(groupLabel string) {
+	defer p.end()
+	if !p.begin("fake.(*RenderBundleEncoder).PushDebugGroup()") {
+		return
+	}
+	p.debugDepth++
+}
+
+func (p *RenderBundleEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	const op = "fake.(*RenderBundleEncoder).SetBindGroup()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setBindGroup(op, groupIndex, group, dynamicOffsets))
+}
+
+func (p *RenderBundleEncoder) SetIndexBuffer(buffer api.Buffer, format api.IndexFormat, offset uint64, size uint64) {
+	const op = "fake.(*RenderBundleEncoder).SetIndexBuffer()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setIndexBuffer(op, buffer, format, offset, size))
+}
+
+func (p *RenderBundleEncoder) SetPipeline(pipeline api.RenderPipeline) {
+	const op = "fake.(*RenderBundleEncoder).SetPipeline()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setPipeline(op, pipeline))
+}
+
+func (p *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer api.Buffer, offset uint64, size uint64) {
+	const op = "fake.(*RenderBundleEncoder).SetVertexBuffer()"
+	defer p.end()
+	if !p.begin(op) {
+		return
+	}
+	p.fail(p.render.setVertexBuffer(op, slot, buffer, offset, size))
+}
+
+func (p *RenderBundleEncoder) Release() {
+	p.released = true
+}
+
+type RenderBundle struct {
+	device             *Device
+	label              string
+	colorFormats       []api.TextureFormat
+	depthStencilFormat api.TextureFormat
+	sampleCount        uint32
+	depthReadOnly      bool
+	stencilReadOnly    bool
+	used               usage
+	// err is the error the bundle was recorded with, if any.
+	err error
+
+	released bool
+}
+
+func (p *RenderBundle) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "RenderBundle", Label: p.label})
+	}
+}
+
+func (p *RenderBundle) Release() {
+	p.released = true
+}
+
+var (
+	_ api.RenderBundleEncoder = (*RenderBundleEncoder)(nil)
+	_ api.RenderBundle        = (*RenderBundle)(nil)
+)
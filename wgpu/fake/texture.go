@@ -0,0 +1,482 @@
+package fake
+
+import (
+	"math/bits"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+const allTextureUsages = api.TextureUsage_CopySrc | api.TextureUsage_CopyDst |
+	api.TextureUsage_TextureBinding | api.TextureUsage_StorageBinding |
+	api.TextureUsage_RenderAttachment
+
+type Texture struct {
+	device        *Device
+	label         string
+	usage         api.TextureUsage
+	dimension     api.TextureDimension
+	size          api.Extent3D
+	format        api.TextureFormat
+	info          formatInfo
+	mipLevelCount uint32
+	sampleCount   uint32
+	// levels holds the tightly packed texel blocks of every mip level, nil
+	// if the texture can't be copied.
+	levels [][]byte
+
+	destroyed bool
+	released  bool
+}
+
+func maxMipLevelCount(dimension api.TextureDimension, size api.Extent3D) uint32 {
+	m := size.Width
+	switch dimension {
+	case api.TextureDimension_1D:
+		return 1
+	case api.TextureDimension_2D:
+		if size.Height > m {
+			m = size.Height
+		}
+	case api.TextureDimension_3D:
+		if size.Height > m {
+			m = size.Height
+		}
+		if size.DepthOrArrayLayers > m {
+			m = size.DepthOrArrayLayers
+		}
+	}
+	return uint32(bits.Len32(m))
+}
+
+func (p *Device) CreateTexture(descriptor *api.TextureDescriptor) (api.Texture, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateTexture()"
+	if descriptor == nil {
+		descriptor = &api.TextureDescriptor{}
+	}
+	label := descriptor.Label
+	size := descriptor.Size
+	info, ok := formats[descriptor.Format]
+
+	switch {
+	case descriptor.Usage == api.TextureUsage_None:
+		return nil, validationError(op, label, "usage must not be empty")
+	case descriptor.Usage&^allTextureUsages != 0:
+		return nil, validationError(op, label, "unknown usage %#x", uint32(descriptor.Usage&^allTextureUsages))
+	case !ok:
+		return nil, validationError(op, label, "unknown format %s", descriptor.Format)
+	case info.feature != api.FeatureName_Undefined && !hasFeature(p.features, info.feature):
+		return nil, validationError(op, label, "format %s needs feature %s", descriptor.Format, info.feature)
+	case size.Width == 0 || size.Height == 0 || size.DepthOrArrayLayers == 0:
+		return nil, validationError(op, label, "size %dx%dx%d must not be empty", size.Width, size.Height, size.DepthOrArrayLayers)
+	case size.Width%info.blockWidth != 0 || size.Height%info.blockHeight != 0:
+		return nil, validationError(op, label, "size %dx%d is not a multiple of the %dx%d blocks of format %s", size.Width, size.Height, info.blockWidth, info.blockHeight, descriptor.Format)
+	}
+
+	switch descriptor.Dimension {
+	case api.TextureDimension_1D:
+		switch {
+		case size.Width > p.limits.MaxTextureDimension1D:
+			return nil, validationError(op, label, "width %d exceeds the MaxTextureDimension1D limit of %d", size.Width, p.limits.MaxTextureDimension1D)
+		case size.Height != 1 || size.DepthOrArrayLayers != 1:
+			return nil, validationError(op, label, "1D texture must have a height and depth of 1")
+		case info.blockWidth != 1 || info.depth || info.stencil:
+			return nil, validationError(op, label, "format %s can't be used with a 1D texture", descriptor.Format)
+		}
+	case api.TextureDimension_2D:
+		switch {
+		case size.Width > p.limits.MaxTextureDimension2D || size.Height > p.limits.MaxTextureDimension2D:
+			return nil, validationError(op, label, "size %dx%d exceeds the MaxTextureDimension2D limit of %d", size.Width, size.Height, p.limits.MaxTextureDimension2D)
+		case size.DepthOrArrayLayers > p.limits.MaxTextureArrayLayers:
+			return nil, validationError(op, label, "%d array layers exceed the MaxTextureArrayLayers limit of %d", size.DepthOrArrayLayers, p.limits.MaxTextureArrayLayers)
+		}
+	case api.TextureDimension_3D:
+		switch {
+		case size.Width > p.limits.MaxTextureDimension3D || size.Height > p.limits.MaxTextureDimension3D || size.DepthOrArrayLayers > p.limits.MaxTextureDimension3D:
+			return nil, validationError(op, label, "size %dx%dx%d exceeds the MaxTextureDimension3D limit of %d", size.Width, size.Height, size.DepthOrArrayLayers, p.limits.MaxTextureDimension3D)
+		case info.depth || info.stencil:
+			return nil, validationError(op, label, "format %s can't be used with a 3D texture", descriptor.Format)
+		}
+	default:
+		return nil, validationError(op, label, "unknown dimension %d", descriptor.Dimension)
+	}
+
+	mipLevelCount := descriptor.MipLevelCount
+	if max := maxMipLevelCount(descriptor.Dimension, size); mipLevelCount == 0 || mipLevelCount > max {
+		return nil, validationError(op, label, "mip level count %d must be within 1..%d", mipLevelCount, max)
+	}
+
+	sampleCount := descriptor.SampleCount
+	switch sampleCount {
+	case 1:
+	case 4:
+		switch {
+		case descriptor.Dimension != api.TextureDimension_2D || mipLevelCount != 1 || size.DepthOrArrayLayers != 1:
+			return nil, validationError(op, label, "multisampled texture must be 2D with a single mip level and array layer")
+		case descriptor.Usage&api.TextureUsage_RenderAttachment == 0:
+			return nil, validationError(op, label, "multisampled texture usage must contain RenderAttachment")
+		case descriptor.Usage&api.TextureUsage_StorageBinding != 0:
+			return nil, validationError(op, label, "multisampled texture usage must not contain StorageBinding")
+		}
+	default:
+		return nil, validationError(op, label, "sample count %d must be 1 or 4", sampleCount)
+	}
+
+	if descriptor.Usage&api.TextureUsage_RenderAttachment != 0 {
+		switch {
+		case !info.renderable:
+			return nil, validationError(op, label, "format %s is not renderable", descriptor.Format)
+		case descriptor.Dimension != api.TextureDimension_2D:
+			return nil, validationError(op, label, "render attachment must be a 2D texture")
+		}
+	}
+
+	texture := &Texture{
+		device:        p,
+		label:         label,
+		usage:         descriptor.Usage,
+		dimension:     descriptor.Dimension,
+		size:          size,
+		format:        descriptor.Format,
+		info:          info,
+		mipLevelCount: mipLevelCount,
+		sampleCount:   sampleCount,
+	}
+	if info.blockSize != 0 && sampleCount == 1 {
+		texture.levels = make([][]byte, mipLevelCount)
+		for i := range texture.levels {
+			texture.levels[i] = make([]byte, texture.levelSize(uint32(i)))
+		}
+	}
+	return texture, nil
+}
+
+// mipSize returns the size of mip level in texels, with the array layers of
+// a 2D texture as the depth.
+func (p *Texture) mipSize(level uint32) api.Extent3D {
+	size := api.Extent3D{
+		Width:              max(p.size.Width>>level, 1),
+		Height:             max(p.size.Height>>level, 1),
+		DepthOrArrayLayers: p.size.DepthOrArrayLayers,
+	}
+	if p.dimension == api.TextureDimension_1D {
+		size.Height = 1
+	}
+	if p.dimension == api.TextureDimension_3D {
+		size.DepthOrArrayLayers = max(p.size.DepthOrArrayLayers>>level, 1)
+	}
+	return size
+}
+
+// physicalMipSize is mipSize rounded up to whole texel blocks.
+func (p *Texture) physicalMipSize(level uint32) api.Extent3D {
+	size := p.mipSize(level)
+	size.Width = (size.Width + p.info.blockWidth - 1) / p.info.blockWidth * p.info.blockWidth
+	size.Height = (size.Height + p.info.blockHeight - 1) / p.info.blockHeight * p.info.blockHeight
+	return size
+}
+
+func (p *Texture) levelBytesPerRow(level uint32) uint64 {
+	return uint64(p.physicalMipSize(level).Width/p.info.blockWidth) * uint64(p.info.blockSize)
+}
+
+func (p *Texture) levelRowsPerImage(level uint32) uint64 {
+	return uint64(p.physicalMipSize(level).Height / p.info.blockHeight)
+}
+
+func (p *Texture) levelSize(level uint32) uint64 {
+	return p.levelBytesPerRow(level) * p.levelRowsPerImage(level) * uint64(p.mipSize(level).DepthOrArrayLayers)
+}
+
+func max(x, y uint32) uint32 {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func (p *Texture) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "Texture", Label: p.label})
+	}
+}
+
+func (p *Texture) CreateView(descriptor *api.TextureViewDescriptor) (api.TextureView, error) {
+	p.checkReleased()
+	const op = "fake.(*Texture).CreateView()"
+	if descriptor == nil {
+		descriptor = &api.TextureViewDescriptor{}
+	}
+	label := descriptor.Label
+
+	view := &TextureView{
+		texture:        p,
+		label:          label,
+		format:         descriptor.Format,
+		dimension:      descriptor.Dimension,
+		baseMipLevel:   descriptor.BaseMipLevel,
+		mipLevelCount:  descriptor.MipLevelCount,
+		baseArrayLayer: descriptor.BaseArrayLayer,
+		layerCount:     descriptor.ArrayLayerCount,
+		aspect:         descriptor.Aspect,
+	}
+	if view.format == api.TextureFormat_Undefined {
+		view.format = p.format
+	}
+	if view.dimension == api.TextureViewDimension_Undefined {
+		switch {
+		case p.dimension == api.TextureDimension_1D:
+			view.dimension = api.TextureViewDimension_1D
+		case p.dimension == api.TextureDimension_3D:
+			view.dimension = api.TextureViewDimension_3D
+		case p.size.DepthOrArrayLayers > 1 && (view.layerCount == 0 || view.layerCount == api.ArrayLayerCountUndefined || view.layerCount > 1):
+			view.dimension = api.TextureViewDimension_2DArray
+		default:
+			view.dimension = api.TextureViewDimension_2D
+		}
+	}
+
+	layers := p.size.DepthOrArrayLayers
+	if p.dimension == api.TextureDimension_3D {
+		layers = 1
+	}
+	if view.mipLevelCount == 0 || view.mipLevelCount == api.MipLevelCountUndefined {
+		if view.baseMipLevel < p.mipLevelCount {
+			view.mipLevelCount = p.mipLevelCount - view.baseMipLevel
+		}
+	}
+	if view.layerCount == 0 || view.layerCount == api.ArrayLayerCountUndefined {
+		if view.baseArrayLayer < layers {
+			view.layerCount = layers - view.baseArrayLayer
+		}
+		if view.dimension == api.TextureViewDimension_2D || view.dimension == api.TextureViewDimension_1D {
+			view.layerCount = 1
+		}
+	}
+
+	switch {
+	case view.format != p.format:
+		return nil, validationError(op, label, "format %s differs from the texture format %s", view.format, p.format)
+	case view.mipLevelCount == 0 || uint64(view.baseMipLevel)+uint64(view.mipLevelCount) > uint64(p.mipLevelCount):
+		return nil, validationError(op, label, "mip levels %d..%d are out of bounds of texture with %d levels", view.baseMipLevel, uint64(view.baseMipLevel)+uint64(view.mipLevelCount), p.mipLevelCount)
+	case view.layerCount == 0 || uint64(view.baseArrayLayer)+uint64(view.layerCount) > uint64(layers):
+		return nil, validationError(op, label, "array layers %d..%d are out of bounds of texture with %d layers", view.baseArrayLayer, uint64(view.baseArrayLayer)+uint64(view.layerCount), layers)
+	case view.aspect == api.TextureAspect_DepthOnly && !p.info.depth:
+		return nil, validationError(op, label, "aspect DepthOnly needs a depth format, not %s", p.format)
+	case view.aspect == api.TextureAspect_StencilOnly && !p.info.stencil:
+		return nil, validationError(op, label, "aspect StencilOnly needs a stencil format, not %s", p.format)
+	}
+
+	var compatible bool
+	switch view.dimension {
+	case api.TextureViewDimension_1D:
+		compatible = p.dimension == api.TextureDimension_1D
+	case api.TextureViewDimension_2D:
+		compatible = p.dimension == api.TextureDimension_2D && view.layerCount == 1
+	case api.TextureViewDimension_2DArray:
+		compatible = p.dimension == api.TextureDimension_2D
+	case api.TextureViewDimension_Cube:
+		compatible = p.dimension == api.TextureDimension_2D && view.layerCount == 6 && p.size.Width == p.size.Height
+	case api.TextureViewDimension_CubeArray:
+		compatible = p.dimension == api.TextureDimension_2D && view.layerCount%6 == 0 && p.size.Width == p.size.Height
+	case api.TextureViewDimension_3D:
+		compatible = p.dimension == api.TextureDimension_3D
+	}
+	if !compatible {
+		return nil, validationError(op, label, "view dimension %s is not compatible with the texture", view.dimension)
+	}
+	return view, nil
+}
+
+func (p *Texture) Destroy() {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	p.destroyed = true
+	p.levels = nil
+}
+
+func (p *Texture) GetDepthOrArrayLayers() uint32 {
+	p.checkReleased()
+	return p.size.DepthOrArrayLayers
+}
+
+func (p *Texture) GetDimension() api.TextureDimension {
+	p.checkReleased()
+	return p.dimension
+}
+
+func (p *Texture) GetFormat() api.TextureFormat {
+	p.checkReleased()
+	return p.format
+}
+
+func (p *Texture) GetHeight() uint32 {
+	p.checkReleased()
+	return p.size.Height
+}
+
+func (p *Texture) GetMipLevelCount() uint32 {
+	p.checkReleased()
+	return p.mipLevelCount
+}
+
+func (p *Texture) GetSampleCount() uint32 {
+	p.checkReleased()
+	return p.sampleCount
+}
+
+func (p *Texture) GetUsage() api.TextureUsage {
+	p.checkReleased()
+	return p.usage
+}
+
+func (p *Texture) GetWidth() uint32 {
+	p.checkReleased()
+	return p.size.Width
+}
+
+// Bytes returns a copy of the contents of mipLevel, as tightly packed rows
+// of texel blocks for every array layer or depth slice. It returns nil if
+// the texture is destroyed or its format can't be copied.
+func (p *Texture) Bytes(mipLevel uint32) []byte {
+	p.checkReleased()
+	p.device.mu.Lock()
+	defer p.device.mu.Unlock()
+
+	if p.levels == nil || mipLevel >= p.mipLevelCount {
+		return nil
+	}
+	return append([]byte(nil), p.levels[mipLevel]...)
+}
+
+func (p *Texture) Release() {
+	p.released = true
+}
+
+type TextureView struct {
+	texture        *Texture
+	label          string
+	format         api.TextureFormat
+	dimension      api.TextureViewDimension
+	baseMipLevel   uint32
+	mipLevelCount  uint32
+	baseArrayLayer uint32
+	layerCount     uint32
+	aspect         api.TextureAspect
+
+	released bool
+}
+
+func (p *TextureView) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "TextureView", Label: p.label})
+	}
+}
+
+func (p *TextureView) Release() {
+	p.released = true
+}
+
+type Sampler struct {
+	device     *Device
+	label      string
+	comparison bool
+	filtering  bool
+
+	released bool
+}
+
+func (p *Device) CreateSampler(descriptor *api.SamplerDescriptor) (api.Sampler, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateSampler()"
+	if descriptor == nil {
+		descriptor = &api.SamplerDescriptor{LodMaxClamp: 32, MaxAnisotrophy: 1}
+	}
+	label := descriptor.Label
+	filtering := descriptor.MagFilter == api.FilterMode_Linear ||
+		descriptor.MinFilter == api.FilterMode_Linear ||
+		descriptor.MipmapFilter == api.MipmapFilterMode_Linear
+
+	switch {
+	case descriptor.LodMinClamp < 0:
+		return nil, validationError(op, label, "LodMinClamp %v must not be negative", descriptor.LodMinClamp)
+	case descriptor.LodMaxClamp < descriptor.LodMinClamp:
+		return nil, validationError(op, label, "LodMaxClamp %v must not be less than LodMinClamp %v", descriptor.LodMaxClamp, descriptor.LodMinClamp)
+	case descriptor.MaxAnisotrophy > 1 && (descriptor.MagFilter != api.FilterMode_Linear ||
+		descriptor.MinFilter != api.FilterMode_Linear ||
+		descriptor.MipmapFilter != api.MipmapFilterMode_Linear):
+		return nil, validationError(op, label, "anisotropic filtering needs linear filters")
+	}
+
+	return &Sampler{
+		device:     p,
+		label:      label,
+		comparison: descriptor.Compare != api.CompareFunction_Undefined,
+		filtering:  filtering,
+	}, nil
+}
+
+func (p *Sampler) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "Sampler", Label: p.label})
+	}
+}
+
+func (p *Sampler) Release() {
+	p.released = true
+}
+
+type QuerySet struct {
+	device *Device
+	label  string
+	typ    api.QueryType
+	count  uint32
+
+	released bool
+}
+
+func (p *Device) CreateQuerySet(descriptor *api.QuerySetDescriptor) (api.QuerySet, error) {
+	p.checkReleased()
+	const op = "fake.(*Device).CreateQuerySet()"
+	if descriptor == nil {
+		descriptor = &api.QuerySetDescriptor{}
+	}
+	label := descriptor.Label
+
+	switch {
+	case descriptor.Count == 0 || descriptor.Count > api.QuerySetMaxQueries:
+		return nil, validationError(op, label, "count %d must be within 1..%d", descriptor.Count, api.QuerySetMaxQueries)
+	case descriptor.Type == api.QueryType_Timestamp && !hasFeature(p.features, api.FeatureName_TimestampQuery):
+		return nil, validationError(op, label, "timestamp queries need feature %s", api.FeatureName_TimestampQuery)
+	case descriptor.Type == api.QueryType_PipelineStatistics && !hasFeature(p.features, api.FeatureName_PipelineStatisticsQuery):
+		return nil, validationError(op, label, "pipeline statistics queries need feature %s", api.FeatureName_PipelineStatisticsQuery)
+	case descriptor.Type == api.QueryType_PipelineStatistics && len(descriptor.PipelineStatistics) == 0:
+		return nil, validationError(op, label, "pipeline statistics query set must list statistics")
+	}
+
+	return &QuerySet{
+		device: p,
+		label:  label,
+		typ:    descriptor.Type,
+		count:  descriptor.Count,
+	}, nil
+}
+
+func (p *QuerySet) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "QuerySet", Label: p.label})
+	}
+}
+
+func (p *QuerySet) Release() {
+	p.released = true
+}
+
+var (
+	_ api.Texture     = (*Texture)(nil)
+	_ api.TextureView = (*TextureView)(nil)
+	_ api.Sampler     = (*Sampler)(nil)
+	_ api.QuerySet    = (*QuerySet)(nil)
+)
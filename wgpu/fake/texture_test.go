@@ -0,0 +1,214 @@
+package fake_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+	"github.com/rajveermalviya/go-webgpu/wgpu/fake"
+)
+
+func TestCreateTextureErrors(t *testing.T) {
+	device := testDevice(t)
+
+	valid := api.TextureDescriptor{
+		Usage:         api.TextureUsage_TextureBinding,
+		Dimension:     api.TextureDimension_2D,
+		Size:          api.Extent3D{Width: 16, Height: 16, DepthOrArrayLayers: 1},
+		Format:        api.TextureFormat_RGBA8Unorm,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	}
+	if _, err := device.CreateTexture(&valid); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(d *api.TextureDescriptor)
+	}{
+		{"usage", func(d *api.TextureDescriptor) { d.Usage = api.TextureUsage_None }},
+		{"empty size", func(d *api.TextureDescriptor) { d.Size.Height = 0 }},
+		{"feature", func(d *api.TextureDescriptor) { d.Format = api.TextureFormat_BC1RGBAUnorm }},
+		{"mip level count", func(d *api.TextureDescriptor) { d.MipLevelCount = 6 }},
+		{"2d size", func(d *api.TextureDescriptor) { d.Size.Width = 8193 }},
+		{"1d height", func(d *api.TextureDescriptor) { d.Dimension = api.TextureDimension_1D }},
+		{"3d depth format", func(d *api.TextureDescriptor) {
+			d.Dimension = api.TextureDimension_3D
+			d.Format = api.TextureFormat_Depth32Float
+		}},
+		{"sample count", func(d *api.TextureDescriptor) { d.SampleCount = 2 }},
+		{"multisampled usage", func(d *api.TextureDescriptor) { d.SampleCount = 4 }},
+		{"multisampled mip levels", func(d *api.TextureDescriptor) {
+			d.Usage = api.TextureUsage_RenderAttachment
+			d.SampleCount = 4
+			d.MipLevelCount = 2
+		}},
+		{"renderable", func(d *api.TextureDescriptor) {
+			d.Usage = api.TextureUsage_RenderAttachment
+			d.Format = api.TextureFormat_RGB9E5Ufloat
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			descriptor := valid
+			tt.modify(&descriptor)
+			_, err := device.CreateTexture(&descriptor)
+			expectValidation(t, err)
+		})
+	}
+}
+
+func TestCreateViewErrors(t *testing.T) {
+	device := testDevice(t)
+
+	texture, err := device.CreateTexture(&api.TextureDescriptor{
+		Usage:         api.TextureUsage_TextureBinding,
+		Dimension:     api.TextureDimension_2D,
+		Size:          api.Extent3D{Width: 16, Height: 8, DepthOrArrayLayers: 6},
+		Format:        api.TextureFormat_RGBA8Unorm,
+		MipLevelCount: 2,
+		SampleCount:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := texture.CreateView(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []api.TextureViewDescriptor{
+		{Format: api.TextureFormat_BGRA8Unorm},
+		{BaseMipLevel: 2},
+		{BaseMipLevel: 1, MipLevelCount: 2},
+		{BaseArrayLayer: 4, ArrayLayerCount: 3},
+		{Aspect: api.TextureAspect_DepthOnly},
+		{Dimension: api.TextureViewDimension_2D, ArrayLayerCount: 2},
+		// cube faces must be square.
+		{Dimension: api.TextureViewDimension_Cube},
+		{Dimension: api.TextureViewDimension_3D},
+	}
+	for _, descriptor := range invalid {
+		_, err := texture.CreateView(&descriptor)
+		expectValidation(t, err)
+	}
+}
+
+func TestTextureCopies(t *testing.T) {
+	device := testDevice(t)
+	queue := device.GetQueue()
+
+	texture, err := device.CreateTexture(&api.TextureDescriptor{
+		Usage:         api.TextureUsage_CopySrc | api.TextureUsage_CopyDst,
+		Dimension:     api.TextureDimension_2D,
+		Size:          api.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+		Format:        api.TextureFormat_RGBA8Unorm,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// write the 2x2 texels at 2,1, with rows 12 bytes apart.
+	data := make([]byte, 12+8)
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+	if err := queue.WriteTexture(
+		&api.ImageCopyTexture{Texture: texture, Origin: api.Origin3D{X: 2, Y: 1}},
+		data,
+		&api.TextureDataLayout{BytesPerRow: 12, RowsPerImage: 2},
+		&api.Extent3D{Width: 2, Height: 2, DepthOrArrayLayers: 1},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := make([]byte, 4*4*4)
+	copy(expected[1*16+2*4:], data[0:8])
+	copy(expected[2*16+2*4:], data[12:20])
+	if got := texture.(*fake.Texture).Bytes(0); !bytes.Equal(got, expected) {
+		t.Errorf("got texels %v, expected %v", got, expected)
+	}
+
+	// read the texture back with rows 256 bytes apart.
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopyDst, Size: 256*3 + 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.CopyTextureToBuffer(
+		&api.ImageCopyTexture{Texture: texture},
+		&api.ImageCopyBuffer{Buffer: buffer, Layout: api.TextureDataLayout{BytesPerRow: 256, RowsPerImage: 4}},
+		&api.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+	); err != nil {
+		t.Fatal(err)
+	}
+	commands, err := encoder.Finish(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue.Submit(commands)
+
+	got := buffer.(*fake.Buffer).Bytes()
+	for y := 0; y < 4; y++ {
+		if row := got[y*256 : y*256+16]; !bytes.Equal(row, expected[y*16:y*16+16]) {
+			t.Errorf("got row %d %v, expected %v", y, row, expected[y*16:y*16+16])
+		}
+	}
+}
+
+func TestTextureCopyErrors(t *testing.T) {
+	device := testDevice(t)
+
+	texture, err := device.CreateTexture(&api.TextureDescriptor{
+		Usage:         api.TextureUsage_CopySrc,
+		Dimension:     api.TextureDimension_2D,
+		Size:          api.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+		Format:        api.TextureFormat_RGBA8Unorm,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopyDst, Size: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	size := &api.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1}
+	layout := api.TextureDataLayout{BytesPerRow: 256, RowsPerImage: 4}
+	tests := []struct {
+		name        string
+		source      api.ImageCopyTexture
+		destination api.ImageCopyBuffer
+		size        *api.Extent3D
+	}{
+		{"mip level", api.ImageCopyTexture{Texture: texture, MipLevel: 1}, api.ImageCopyBuffer{Buffer: buffer, Layout: layout}, size},
+		{"out of bounds", api.ImageCopyTexture{Texture: texture, Origin: api.Origin3D{X: 1}}, api.ImageCopyBuffer{Buffer: buffer, Layout: layout}, size},
+		{"aspect", api.ImageCopyTexture{Texture: texture, Aspect: api.TextureAspect_StencilOnly}, api.ImageCopyBuffer{Buffer: buffer, Layout: layout}, size},
+		{"bytes per row alignment", api.ImageCopyTexture{Texture: texture}, api.ImageCopyBuffer{Buffer: buffer, Layout: api.TextureDataLayout{BytesPerRow: 16}}, size},
+		{"rows per image", api.ImageCopyTexture{Texture: texture}, api.ImageCopyBuffer{Buffer: buffer, Layout: api.TextureDataLayout{BytesPerRow: 256, RowsPerImage: 2}}, size},
+		{"buffer too small", api.ImageCopyTexture{Texture: texture}, api.ImageCopyBuffer{Buffer: buffer, Layout: api.TextureDataLayout{Offset: 256, BytesPerRow: 256}}, size},
+		{"offset alignment", api.ImageCopyTexture{Texture: texture}, api.ImageCopyBuffer{Buffer: buffer, Layout: api.TextureDataLayout{Offset: 2, BytesPerRow: 256}}, size},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder, err := device.CreateCommandEncoder(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectValidation(t, encoder.CopyTextureToBuffer(&tt.source, &tt.destination, tt.size))
+			// the first error invalidates the encoder.
+			_, err = encoder.Finish(nil)
+			expectValidation(t, err)
+		})
+	}
+
+	// the texture lacks CopyDst.
+	expectValidation(t, device.GetQueue().WriteTexture(&api.ImageCopyTexture{Texture: texture}, make([]byte, 64), &api.TextureDataLayout{BytesPerRow: 16}, size))
+}
@@ -0,0 +1,31 @@
+package wgpu
+
+/*
+
+#include <stdlib.h>
+
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// newCallbackHandle returns a handle to callback in C memory, to be passed as
+// the userdata of the callbacks native calls after the function they are
+// passed to returns, as C must not keep Go pointers. The callback frees it
+// with takeCallbackHandle.
+func newCallbackHandle(callback any) unsafe.Pointer {
+	p := (*cgo.Handle)(C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0)))))
+	*p = cgo.NewHandle(callback)
+	return unsafe.Pointer(p)
+}
+
+// takeCallbackHandle deletes and frees the handle returned by
+// newCallbackHandle, and returns its callback.
+func takeCallbackHandle(userdata unsafe.Pointer) any {
+	handle := *(*cgo.Handle)(userdata)
+	C.free(userdata)
+	defer handle.Delete()
+	return handle.Value()
+}
@@ -0,0 +1,263 @@
+package wgpu
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+type ImageTextureDescriptor struct {
+	Label string
+	// Format is one of TextureFormat_RGBA8Unorm, TextureFormat_RGBA8UnormSrgb,
+	// TextureFormat_BGRA8Unorm, TextureFormat_BGRA8UnormSrgb,
+	// TextureFormat_R8Unorm and TextureFormat_RGBA16Float, by default
+	// TextureFormat_RGBA8UnormSrgb.
+	Format TextureFormat
+	// Usage is TextureUsage_TextureBinding by default, TextureUsage_CopyDst
+	// is always added.
+	Usage TextureUsage
+	// PremultipliedAlpha stores colors premultiplied by their alpha instead
+	// of straight colors.
+	PremultipliedAlpha bool
+}
+
+// CreateTextureFromImage creates a 2D texture of the size of img and writes
+// img to it, see (*Queue).WriteImage.
+func (p *Device) CreateTextureFromImage(img image.Image, descriptor *ImageTextureDescriptor) (*Texture, error) {
+	p.checkReleased()
+	var desc ImageTextureDescriptor
+	if descriptor != nil {
+		desc = *descriptor
+	}
+	if desc.Format == TextureFormat_Undefined {
+		desc.Format = TextureFormat_RGBA8UnormSrgb
+	}
+	if desc.Usage == 0 {
+		desc.Usage = TextureUsage_TextureBinding
+	}
+
+	size := img.Bounds().Size()
+	texture, err := p.CreateTexture(&TextureDescriptor{
+		Label:     desc.Label,
+		Usage:     desc.Usage | TextureUsage_CopyDst,
+		Dimension: TextureDimension_2D,
+		Size: Extent3D{
+			Width:              uint32(size.X),
+			Height:             uint32(size.Y),
+			DepthOrArrayLayers: 1,
+		},
+		Format:        desc.Format,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queue := p.GetQueue()
+	defer queue.Release()
+	if err := queue.WriteImage(texture.AsImageCopy(), img, desc.PremultipliedAlpha); err != nil {
+		texture.Release()
+		return nil, err
+	}
+	return texture, nil
+}
+
+// WriteImage converts img to the format of the destination texture and
+// writes it at the origin of destination.
+//
+// Go images hold sRGB encoded colors. They are stored as is in the 8-bit
+// formats, the sRGB ones decoding them when sampled, and decoded to linear
+// values in TextureFormat_RGBA16Float. TextureFormat_R8Unorm stores the
+// luminance of img, as computed by color.GrayModel.
+//
+// If premultipliedAlpha is set, colors are premultiplied by their alpha, in
+// linear space for the sRGB and float formats.
+func (p *Queue) WriteImage(destination *ImageCopyTexture, img image.Image, premultipliedAlpha bool) error {
+	p.checkReleased()
+	destination.Texture.checkReleased()
+
+	format := destination.Texture.GetFormat()
+	var bytesPerPixel int
+	switch format {
+	case TextureFormat_R8Unorm:
+		bytesPerPixel = 1
+	case TextureFormat_RGBA8Unorm, TextureFormat_RGBA8UnormSrgb,
+		TextureFormat_BGRA8Unorm, TextureFormat_BGRA8UnormSrgb:
+		bytesPerPixel = 4
+	case TextureFormat_RGBA16Float:
+		bytesPerPixel = 8
+	default:
+		return &Error{
+			Type:    ErrorType_Validation,
+			Op:      "wgpu.(*Queue).WriteImage()",
+			Label:   destination.Texture.label,
+			Message: "can't write images to textures of format " + format.String(),
+		}
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil
+	}
+	data := imageData(img, format, bytesPerPixel, premultipliedAlpha)
+	return p.WriteTexture(destination, data, &TextureDataLayout{
+		BytesPerRow:  uint32(width * bytesPerPixel),
+		RowsPerImage: uint32(height),
+	}, &Extent3D{
+		Width:              uint32(width),
+		Height:             uint32(height),
+		DepthOrArrayLayers: 1,
+	})
+}
+
+// imageData returns the pixels of img converted to format, with rows of
+// bytesPerPixel pixels tightly packed.
+func imageData(img image.Image, format TextureFormat, bytesPerPixel int, premultipliedAlpha bool) []byte {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	data := make([]byte, width*height*bytesPerPixel)
+
+	for y := 0; y < height; y++ {
+		row := data[y*width*bytesPerPixel : (y+1)*width*bytesPerPixel]
+		for x := 0; x < width; x++ {
+			px := row[x*bytesPerPixel : (x+1)*bytesPerPixel]
+			if format == TextureFormat_R8Unorm {
+				px[0] = imageGray(img, bounds.Min.X+x, bounds.Min.Y+y)
+				continue
+			}
+
+			c := imageNRGBA64(img, bounds.Min.X+x, bounds.Min.Y+y)
+			switch format {
+			case TextureFormat_RGBA8Unorm, TextureFormat_BGRA8Unorm:
+				if premultipliedAlpha {
+					c.R = uint16(uint32(c.R) * uint32(c.A) / 0xffff)
+					c.G = uint16(uint32(c.G) * uint32(c.A) / 0xffff)
+					c.B = uint16(uint32(c.B) * uint32(c.A) / 0xffff)
+				}
+			case TextureFormat_RGBA8UnormSrgb, TextureFormat_BGRA8UnormSrgb:
+				if premultipliedAlpha {
+					a := float64(c.A) / 0xffff
+					c.R = linearToSrgb16(srgbToLinear(float64(c.R)/0xffff) * a)
+					c.G = linearToSrgb16(srgbToLinear(float64(c.G)/0xffff) * a)
+					c.B = linearToSrgb16(srgbToLinear(float64(c.B)/0xffff) * a)
+				}
+			case TextureFormat_RGBA16Float:
+				a := float64(c.A) / 0xffff
+				m := 1.0
+				if premultipliedAlpha {
+					m = a
+				}
+				putFloat16(px[0:], srgbToLinear(float64(c.R)/0xffff)*m)
+				putFloat16(px[2:], srgbToLinear(float64(c.G)/0xffff)*m)
+				putFloat16(px[4:], srgbToLinear(float64(c.B)/0xffff)*m)
+				putFloat16(px[6:], a)
+				continue
+			}
+
+			r, g, b, a := uint8(c.R>>8), uint8(c.G>>8), uint8(c.B>>8), uint8(c.A>>8)
+			if format == TextureFormat_BGRA8Unorm || format == TextureFormat_BGRA8UnormSrgb {
+				r, b = b, r
+			}
+			px[0], px[1], px[2], px[3] = r, g, b, a
+		}
+	}
+	return data
+}
+
+// imageNRGBA64 returns the straight color of the pixel of img at x, y.
+func imageNRGBA64(img image.Image, x, y int) color.NRGBA64 {
+	switch img := img.(type) {
+	case *image.NRGBA:
+		i := img.PixOffset(x, y)
+		s := img.Pix[i : i+4 : i+4]
+		return color.NRGBA64{
+			R: uint16(s[0]) * 0x101,
+			G: uint16(s[1]) * 0x101,
+			B: uint16(s[2]) * 0x101,
+			A: uint16(s[3]) * 0x101,
+		}
+	case *image.RGBA:
+		i := img.PixOffset(x, y)
+		s := img.Pix[i : i+4 : i+4]
+		return color.NRGBA64Model.Convert(color.RGBA{R: s[0], G: s[1], B: s[2], A: s[3]}).(color.NRGBA64)
+	case *image.Gray:
+		v := uint16(img.Pix[img.PixOffset(x, y)]) * 0x101
+		return color.NRGBA64{R: v, G: v, B: v, A: 0xffff}
+	case *image.YCbCr:
+		c := img.YCbCrAt(x, y)
+		r, g, b := color.YCbCrToRGB(c.Y, c.Cb, c.Cr)
+		return color.NRGBA64{R: uint16(r) * 0x101, G: uint16(g) * 0x101, B: uint16(b) * 0x101, A: 0xffff}
+	}
+	return color.NRGBA64Model.Convert(img.At(x, y)).(color.NRGBA64)
+}
+
+// imageGray returns the luminance of the pixel of img at x, y.
+func imageGray(img image.Image, x, y int) uint8 {
+	switch img := img.(type) {
+	case *image.Gray:
+		return img.Pix[img.PixOffset(x, y)]
+	case *image.YCbCr:
+		return img.Y[img.YOffset(x, y)]
+	}
+	return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+}
+
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSrgb16(v float64) uint16 {
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return uint16(math.Round(math.Max(0, math.Min(1, v)) * 0xffff))
+}
+
+// putFloat16 stores v as a little-endian IEEE 754 half-precision float,
+// rounding to nearest even.
+func putFloat16(b []byte, v float64) {
+	bits := math.Float64bits(v)
+	sign := uint16(bits>>48) & 0x8000
+	exp := int(bits>>52&0x7ff) - 1023 + 15
+	mant := bits & (1<<52 - 1)
+
+	var h uint16
+	switch {
+	case bits&(1<<63-1) >= 0x7ff<<52:
+		// infinity or NaN
+		h = sign | 0x7c00
+		if mant != 0 {
+			h |= 0x200
+		}
+	case exp >= 0x1f:
+		h = sign | 0x7c00
+	case exp <= 0:
+		if exp < -10 {
+			h = sign
+			break
+		}
+		mant |= 1 << 52
+		shift := uint(43 - exp)
+		h = sign | uint16(mant>>shift)
+		rem := mant & (1<<shift - 1)
+		half := uint64(1) << (shift - 1)
+		if rem > half || (rem == half && h&1 != 0) {
+			h++
+		}
+	default:
+		h = sign | uint16(exp)<<10 | uint16(mant>>42)
+		rem := mant & (1<<42 - 1)
+		if rem > 1<<41 || (rem == 1<<41 && h&1 != 0) {
+			// may carry into the exponent, up to infinity.
+			h++
+		}
+	}
+	b[0], b[1] = byte(h), byte(h>>8)
+}
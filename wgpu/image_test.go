@@ -0,0 +1,172 @@
+package wgpu
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestPutFloat16(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        float64
+		expected uint16
+	}{
+		{"zero", 0, 0x0000},
+		{"negative zero", math.Copysign(0, -1), 0x8000},
+		{"one", 1, 0x3c00},
+		{"negative", -2, 0xc000},
+		{"fraction", 0.1, 0x2e66},
+		{"max", 65504, 0x7bff},
+		{"below the max halfway", 65519, 0x7bff},
+		{"rounding to infinity", 65520, 0x7c00},
+		{"overflow", 1e6, 0x7c00},
+		{"negative overflow", -1e6, 0xfc00},
+		{"infinity", math.Inf(1), 0x7c00},
+		{"negative infinity", math.Inf(-1), 0xfc00},
+		{"NaN", math.NaN(), 0x7e00},
+		{"smallest normal", 0x1p-14, 0x0400},
+		{"largest subnormal", 1023 * 0x1p-24, 0x03ff},
+		{"smallest subnormal", 0x1p-24, 0x0001},
+		{"subnormal tie to even zero", 0x1p-25, 0x0000},
+		{"subnormal above the tie", 1.5 * 0x1p-25, 0x0001},
+		{"subnormal tie to even", 3 * 0x1p-25, 0x0002},
+		{"subnormal rounding to normal", 1023.5 * 0x1p-24, 0x0400},
+		{"underflow", 1e-10, 0x0000},
+		{"negative underflow", -1e-10, 0x8000},
+		{"tie to even", 1 + 0x1p-11, 0x3c00},
+		{"tie to even up", 1 + 3*0x1p-11, 0x3c02},
+		// rounding to float32 first would round down to the tie.
+		{"above the tie", 1 + 0x1p-11 + 0x1p-40, 0x3c01},
+		{"below the tie", 1 + 0x1p-11 - 0x1p-40, 0x3c00},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := make([]byte, 2)
+			putFloat16(b, tt.v)
+			if h := binary.LittleEndian.Uint16(b); h != tt.expected {
+				t.Errorf("got %#04x, expected %#04x", h, tt.expected)
+			}
+		})
+	}
+}
+
+// float16 returns the value of the half-precision float h.
+func float16(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	switch exp {
+	case 0:
+		return sign * mant * 0x1p-24
+	case 0x1f:
+		if mant != 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(sign))
+	}
+	return sign * (1 + mant/1024) * math.Ldexp(1, exp-15)
+}
+
+func testImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	for i, c := range []color.NRGBA{
+		{R: 0xff, G: 0x80, B: 0x00, A: 0xff},
+		{R: 0x12, G: 0x34, B: 0x56, A: 0x80},
+		{R: 0xff, G: 0xff, B: 0xff, A: 0x00},
+		{R: 0x01, G: 0xfe, B: 0x7f, A: 0x40},
+	} {
+		img.SetNRGBA(i, 0, c)
+	}
+	return img
+}
+
+func TestImageData(t *testing.T) {
+	img := testImage()
+	pixel := func(i int) color.NRGBA { return img.NRGBAAt(i, 0) }
+
+	tests := []struct {
+		format        TextureFormat
+		bytesPerPixel int
+		premultiplied bool
+		// decode returns the color of pixel i of data.
+		decode func(data []byte, i int) color.NRGBA
+		// expected returns the expected color of pixel i.
+		expected func(i int) color.NRGBA
+	}{
+		{TextureFormat_RGBA8Unorm, 4, false, func(data []byte, i int) color.NRGBA {
+			return color.NRGBA{data[4*i], data[4*i+1], data[4*i+2], data[4*i+3]}
+		}, pixel},
+		{TextureFormat_RGBA8UnormSrgb, 4, false, func(data []byte, i int) color.NRGBA {
+			return color.NRGBA{data[4*i], data[4*i+1], data[4*i+2], data[4*i+3]}
+		}, pixel},
+		{TextureFormat_BGRA8Unorm, 4, false, func(data []byte, i int) color.NRGBA {
+			return color.NRGBA{data[4*i+2], data[4*i+1], data[4*i], data[4*i+3]}
+		}, pixel},
+		{TextureFormat_RGBA8Unorm, 4, true, func(data []byte, i int) color.NRGBA {
+			return color.NRGBA{data[4*i], data[4*i+1], data[4*i+2], data[4*i+3]}
+		}, func(i int) color.NRGBA {
+			c := pixel(i)
+			m := func(v uint8) uint8 { return uint8(uint32(v) * 0x101 * (uint32(c.A) * 0x101) / 0xffff >> 8) }
+			return color.NRGBA{m(c.R), m(c.G), m(c.B), c.A}
+		}},
+		{TextureFormat_R8Unorm, 1, false, func(data []byte, i int) color.NRGBA {
+			return color.NRGBA{data[i], data[i], data[i], 0xff}
+		}, func(i int) color.NRGBA {
+			y := color.GrayModel.Convert(pixel(i)).(color.Gray).Y
+			return color.NRGBA{y, y, y, 0xff}
+		}},
+		{TextureFormat_RGBA16Float, 8, false, func(data []byte, i int) color.NRGBA {
+			v := func(j int) float64 { return float16(binary.LittleEndian.Uint16(data[8*i+2*j:])) }
+			srgb := func(j int) uint8 { return uint8(math.Round(float64(linearToSrgb16(v(j))) / 0x101)) }
+			return color.NRGBA{srgb(0), srgb(1), srgb(2), uint8(math.Round(v(3) * 0xff))}
+		}, pixel},
+	}
+	for _, tt := range tests {
+		name := tt.format.String()
+		if tt.premultiplied {
+			name += " premultiplied"
+		}
+		t.Run(name, func(t *testing.T) {
+			data := imageData(img, tt.format, tt.bytesPerPixel, tt.premultiplied)
+			if len(data) != 4*tt.bytesPerPixel {
+				t.Fatalf("got %d bytes, expected %d", len(data), 4*tt.bytesPerPixel)
+			}
+			for i := 0; i < 4; i++ {
+				if got, expected := tt.decode(data, i), tt.expected(i); got != expected {
+					t.Errorf("pixel %d: got %v, expected %v", i, got, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteImage(t *testing.T) {
+	device := testDevice(t)
+	img := testImage()
+
+	for _, format := range []TextureFormat{TextureFormat_RGBA8UnormSrgb, TextureFormat_BGRA8Unorm, TextureFormat_RGBA16Float} {
+		texture, err := device.CreateTextureFromImage(img, &ImageTextureDescriptor{
+			Format: format,
+			Usage:  TextureUsage_CopySrc,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer texture.Release()
+
+		data, err := device.ReadTexture(context.Background(), *texture.AsImageCopy(), Extent3D{Width: 4, Height: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := imageData(img, format, len(data)/4, false); !bytes.Equal(data, expected) {
+			t.Errorf("%s: read %v, expected %v", format, data, expected)
+		}
+	}
+}
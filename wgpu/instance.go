@@ -10,8 +10,8 @@ extern void gowebgpu_request_adapter_callback_c(WGPURequestAdapterStatus status,
 */
 import "C"
 import (
-	"errors"
-	"runtime/cgo"
+	"context"
+	"sync"
 	"unsafe"
 )
 
@@ -58,7 +58,7 @@ func CreateInstance(descriptor *InstanceDescriptor) *Instance {
 		panic("Failed to acquire Instance")
 	}
 
-	return &Instance{ref}
+	return trackObject(&Instance{ref}, "", (*Instance).Release)
 }
 
 type SurfaceDescriptorFromWindowsHWND struct {
@@ -101,6 +101,7 @@ type SurfaceDescriptor struct {
 }
 
 func (p *Instance) CreateSurface(descriptor *SurfaceDescriptor) *Surface {
+	p.checkReleased()
 	var desc C.WGPUSurfaceDescriptor
 
 	if descriptor != nil {
@@ -186,17 +187,18 @@ func (p *Instance) CreateSurface(descriptor *SurfaceDescriptor) *Surface {
 	if ref == nil {
 		panic("Failed to acquire Surface")
 	}
-	return &Surface{ref}
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	return trackObject(&Surface{ref: ref, label: label}, label, (*Surface).Release)
 }
 
 type requestAdapterCb func(status RequestAdapterStatus, adapter *Adapter, message string)
 
 //export gowebgpu_request_adapter_callback_go
 func gowebgpu_request_adapter_callback_go(status C.WGPURequestAdapterStatus, adapter C.WGPUAdapter, message *C.char, userdata unsafe.Pointer) {
-	handle := *(*cgo.Handle)(userdata)
-	defer handle.Delete()
-
-	cb, ok := handle.Value().(requestAdapterCb)
+	cb, ok := takeCallbackHandle(userdata).(requestAdapterCb)
 	if ok {
 		cb(RequestAdapterStatus(status), &Adapter{ref: adapter}, C.GoString(message))
 	}
@@ -210,12 +212,26 @@ type RequestAdapterOptions struct {
 }
 
 func (p *Instance) RequestAdapter(options *RequestAdapterOptions) (*Adapter, error) {
+	p.checkReleased()
+	return p.RequestAdapterContext(context.Background(), options)
+}
+
+// RequestAdapterContext is like RequestAdapter but returns ctx.Err() if ctx
+// is done before the adapter is acquired. An adapter that arrives after that
+// is released.
+func (p *Instance) RequestAdapterContext(ctx context.Context, options *RequestAdapterOptions) (*Adapter, error) {
+	p.checkReleased()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	var opts *C.WGPURequestAdapterOptions
 
 	if options != nil {
 		opts = &C.WGPURequestAdapterOptions{}
 
 		if options.CompatibleSurface != nil {
+			options.CompatibleSurface.checkReleased()
 			opts.compatibleSurface = options.CompatibleSurface.ref
 		}
 		opts.powerPreference = C.WGPUPowerPreference(options.PowerPreference)
@@ -223,20 +239,59 @@ func (p *Instance) RequestAdapter(options *RequestAdapterOptions) (*Adapter, err
 		opts.backendType = C.WGPUBackendType(options.BackendType)
 	}
 
-	var status RequestAdapterStatus
-	var adapter *Adapter
+	type result struct {
+		status  RequestAdapterStatus
+		adapter *Adapter
+		message string
+	}
+	results := make(chan result, 1)
+
+	var mu sync.Mutex
+	var abandoned bool
 
-	var cb requestAdapterCb = func(s RequestAdapterStatus, a *Adapter, _ string) {
-		status = s
-		adapter = a
+	var cb requestAdapterCb = func(s RequestAdapterStatus, a *Adapter, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if abandoned {
+			if s == RequestAdapterStatus_Success {
+				a.Release()
+			}
+			return
+		}
+		results <- result{s, a, message}
 	}
-	handle := cgo.NewHandle(cb)
-	C.wgpuInstanceRequestAdapter(p.ref, opts, C.WGPURequestAdapterCallback(C.gowebgpu_request_adapter_callback_c), unsafe.Pointer(&handle))
+	C.wgpuInstanceRequestAdapter(p.ref, opts, C.WGPURequestAdapterCallback(C.gowebgpu_request_adapter_callback_c), newCallbackHandle(cb))
+
+	var r result
+	select {
+	case r = <-results:
+	case <-ctx.Done():
+		mu.Lock()
+		select {
+		case r = <-results:
+		default:
+			abandoned = true
+		}
+		mu.Unlock()
 
-	if status != RequestAdapterStatus_Success {
-		return nil, errors.New("failed to request adapter")
+		if abandoned {
+			return nil, ctx.Err()
+		}
 	}
-	return adapter, nil
+
+	if r.status != RequestAdapterStatus_Success {
+		message := r.message
+		if message == "" {
+			message = "failed to request adapter: " + r.status.String()
+		}
+		return nil, &Error{
+			Type:    ErrorType_Unknown,
+			Op:      "wgpu.(*Instance).RequestAdapter()",
+			Message: message,
+		}
+	}
+	return trackObject(r.adapter, "", (*Adapter).Release), nil
 }
 
 type InstanceEnumerateAdapterOptons struct {
@@ -244,6 +299,7 @@ type InstanceEnumerateAdapterOptons struct {
 }
 
 func (p *Instance) EnumerateAdapters(options *InstanceEnumerateAdapterOptons) []*Adapter {
+	p.checkReleased()
 	var opts *C.WGPUInstanceEnumerateAdapterOptions
 	if options != nil {
 		opts = &C.WGPUInstanceEnumerateAdapterOptions{
@@ -261,7 +317,7 @@ func (p *Instance) EnumerateAdapters(options *InstanceEnumerateAdapterOptons) []
 
 	adapters := make([]*Adapter, size)
 	for i, ref := range adapterRefs {
-		adapters[i] = &Adapter{ref}
+		adapters[i] = trackObject(&Adapter{ref}, "", (*Adapter).Release)
 	}
 	return adapters
 }
@@ -301,6 +357,7 @@ type GlobalReport struct {
 }
 
 func (p *Instance) GenerateReport() GlobalReport {
+	p.checkReleased()
 	var r C.WGPUGlobalReport
 	C.wgpuGenerateReport(p.ref, &r)
 
@@ -354,5 +411,10 @@ func (p *Instance) GenerateReport() GlobalReport {
 }
 
 func (p *Instance) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
 	C.wgpuInstanceRelease(p.ref)
+	p.ref = nil
 }
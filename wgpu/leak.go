@@ -0,0 +1,245 @@
+package wgpu
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+type Leak struct {
+	// Type is the name of the leaked type, e.g. "Buffer".
+	Type  string
+	Label string
+	// Finalized is set when the object became unreachable without being
+	// released, and was released by its finalizer.
+	Finalized bool
+	// Stack is the stack trace of the object's creation.
+	Stack string
+}
+
+func (v Leak) String() string {
+	s := "wgpu." + v.Type
+	if v.Label != "" {
+		s += " " + strconv.Quote(v.Label)
+	}
+	if v.Finalized {
+		s += " (finalized)"
+	}
+	return s + " created at:\n" + v.Stack
+}
+
+type leakRecord struct {
+	typ       string
+	label     string
+	finalized bool
+	pcs       []uintptr
+	// child is the object's entry in its device, if any, set when the
+	// object is released by Device.Close.
+	child *deviceChild
+}
+
+var leaks struct {
+	enabled atomic.Bool
+	// tracked counts live tracked objects, so that releases stay cheap
+	// while nothing is tracked.
+	tracked atomic.Int64
+
+	mu        sync.Mutex
+	live      map[uintptr]*leakRecord
+	finalized []*leakRecord
+}
+
+// SetLeakTracking enables or disables leak tracking for objects created
+// afterwards. Tracked objects record their creation stack, are listed by
+// LeakReport until released, and are released by a finalizer if they become
+// unreachable without being released.
+//
+// Finalizers run on an arbitrary goroutine in an unspecified order relative
+// to other objects, so they are a safety net and not a substitute for
+// calling Release.
+func SetLeakTracking(enabled bool) {
+	leaks.enabled.Store(enabled)
+}
+
+// LeakReport lists the tracked objects that are not released yet, and the
+// ones released by their finalizer since the previous call.
+func LeakReport() []Leak {
+	leaks.mu.Lock()
+	records := make([]*leakRecord, 0, len(leaks.live)+len(leaks.finalized))
+	for k, v := range leaks.live {
+		if v.child != nil && v.child.released.Load() {
+			// released by Device.Close
+			delete(leaks.live, k)
+			leaks.tracked.Add(-1)
+			continue
+		}
+		records = append(records, v)
+	}
+	records = append(records, leaks.finalized...)
+	leaks.finalized = nil
+	leaks.mu.Unlock()
+
+	report := make([]Leak, len(records))
+	for i, v := range records {
+		report[i] = Leak{
+			Type:      v.typ,
+			Label:     v.label,
+			Finalized: v.finalized,
+			Stack:     formatStack(v.pcs),
+		}
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].Type != report[j].Type {
+			return report[i].Type < report[j].Type
+		}
+		return report[i].Label < report[j].Label
+	})
+	return report
+}
+
+// trackObject starts tracking p if leak tracking is enabled, release is
+// called by the finalizer and must be p's Release method.
+func trackObject[T any](p *T, label string, release func(*T)) *T {
+	if !leaks.enabled.Load() {
+		return p
+	}
+
+	record := newLeakRecord(reflect.TypeOf(p).Elem().Name(), label)
+	record.child = childOf(p)
+	track(p, record, release)
+	return p
+}
+
+// deviceSentinel is tracked in place of a Device. Devices are in reference
+// cycles, their children and queue pointing back to them, and finalizers
+// don't run for objects in cycles, so the finalizer is set on a sentinel
+// that only the device points to.
+type deviceSentinel struct {
+	native *deviceNative
+}
+
+// trackDevice is trackObject for devices.
+func trackDevice(p *Device, label string) *Device {
+	if !leaks.enabled.Load() {
+		return p
+	}
+
+	p.sentinel = &deviceSentinel{native: p.native}
+	track(p.sentinel, newLeakRecord("Device", label), func(s *deviceSentinel) {
+		s.native.releaseLeaked()
+	})
+	return p
+}
+
+// untrackDevice is untrackObject for devices.
+func untrackDevice(p *Device) {
+	if p.sentinel != nil {
+		untrackObject(p.sentinel)
+	}
+}
+
+// newLeakRecord returns the record of an object created by the caller of
+// its caller.
+func newLeakRecord(typ string, label string) *leakRecord {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(3, pcs)]
+
+	return &leakRecord{
+		typ:   typ,
+		label: label,
+		pcs:   pcs,
+	}
+}
+
+// track records p as live, and sets its finalizer.
+func track[T any](p *T, record *leakRecord, release func(*T)) {
+	key := uintptr(unsafe.Pointer(p))
+
+	leaks.mu.Lock()
+	if leaks.live == nil {
+		leaks.live = map[uintptr]*leakRecord{}
+	}
+	leaks.live[key] = record
+	leaks.mu.Unlock()
+	leaks.tracked.Add(1)
+
+	runtime.SetFinalizer(p, func(p *T) {
+		leaks.mu.Lock()
+		if leaks.live[key] == record {
+			delete(leaks.live, key)
+			leaks.tracked.Add(-1)
+			record.finalized = true
+			leaks.finalized = append(leaks.finalized, record)
+		}
+		leaks.mu.Unlock()
+
+		release(p)
+	})
+}
+
+// untrackObject stops tracking p, called on release.
+func untrackObject[T any](p *T) {
+	if leaks.tracked.Load() == 0 {
+		return
+	}
+
+	key := uintptr(unsafe.Pointer(p))
+
+	leaks.mu.Lock()
+	_, ok := leaks.live[key]
+	if ok {
+		delete(leaks.live, key)
+		leaks.tracked.Add(-1)
+	}
+	leaks.mu.Unlock()
+
+	if ok {
+		runtime.SetFinalizer(p, nil)
+	}
+}
+
+// retrackObject updates the device entry recorded for p, after its fields
+// were replaced with the ones of an object recreated on another device.
+func retrackObject[T any](p *T) {
+	if leaks.tracked.Load() == 0 {
+		return
+	}
+
+	key := uintptr(unsafe.Pointer(p))
+
+	leaks.mu.Lock()
+	if record, ok := leaks.live[key]; ok {
+		record.child = childOf(p)
+	}
+	leaks.mu.Unlock()
+}
+
+func childOf[T any](p *T) *deviceChild {
+	if f := reflect.ValueOf(p).Elem().FieldByName("child"); f.IsValid() {
+		return (*deviceChild)(f.UnsafePointer())
+	}
+	return nil
+}
+
+func formatStack(pcs []uintptr) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+		sb.WriteByte('\n')
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
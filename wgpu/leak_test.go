@@ -0,0 +1,42 @@
+package wgpu
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestLeakedDevice(t *testing.T) {
+	SetLeakTracking(true)
+	defer SetLeakTracking(false)
+	LeakReport()
+
+	instance := CreateInstance(nil)
+	defer instance.Release()
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		t.Skipf("no adapter: %v", err)
+	}
+	defer adapter.Release()
+
+	func() {
+		device, err := adapter.RequestDevice(&DeviceDescriptor{Label: "leaked"})
+		if err != nil {
+			t.Skipf("no device: %v", err)
+		}
+		// the queue points back to the device.
+		device.GetQueue()
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		for _, leak := range LeakReport() {
+			if leak.Type == "Device" && leak.Label == "leaked" && leak.Finalized {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("the unreleased device wasn't reported")
+}
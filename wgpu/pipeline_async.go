@@ -0,0 +1,166 @@
+package wgpu
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// PipelineResult is the result of CreateRenderPipelineAsync and
+// CreateComputePipelineAsync.
+type PipelineResult[T any] struct {
+	Pipeline T
+	Err      error
+}
+
+// pipelineWorkers bounds the number of pipelines created at once by the
+// ...Async methods, as wgpu-native doesn't implement
+// wgpuDeviceCreateRenderPipelineAsync and wgpuDeviceCreateComputePipelineAsync.
+//
+// In ValidationMode_Immediate the error scope of each creation is held
+// locked, see (*Device).lockErrorScope: native has a single error scope
+// stack per device, so a scope pushed around one creation would also capture
+// the errors of the others. The workers only create pipelines in parallel in
+// ValidationMode_Deferred.
+type pipelineWorkers struct {
+	mu  sync.Mutex
+	sem chan struct{}
+}
+
+func (p *pipelineWorkers) semaphore() chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sem == nil {
+		p.sem = make(chan struct{}, runtime.GOMAXPROCS(0))
+	}
+	return p.sem
+}
+
+// SetPipelineWorkers sets the number of pipelines the ...Async methods create
+// at once, GOMAXPROCS by default. Creations already started are not affected.
+//
+// Pipelines are only created in parallel in ValidationMode_Deferred. In
+// ValidationMode_Immediate each creation holds the device's error scope
+// stack, so the workers create them one at a time, without blocking the
+// calling goroutines.
+func (p *Device) SetPipelineWorkers(n int) {
+	p.checkReleased()
+	if n < 1 {
+		n = 1
+	}
+
+	p.pipelineWorkers.mu.Lock()
+	defer p.pipelineWorkers.mu.Unlock()
+	p.pipelineWorkers.sem = make(chan struct{}, n)
+}
+
+// createPipelineAsync runs create on a pipeline worker once one is free, and
+// sends its result on the returned channel. If ctx is done before the
+// pipeline is created, ctx.Err() is sent instead and the pipeline, if any, is
+// released.
+func createPipelineAsync[T interface{ Release() }](ctx context.Context, workers *pipelineWorkers, create func() (T, error)) <-chan PipelineResult[T] {
+	result := make(chan PipelineResult[T], 1)
+	sem := workers.semaphore()
+
+	go func() {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			result <- PipelineResult[T]{Err: ctx.Err()}
+			return
+		}
+		defer func() { <-sem }()
+
+		if err := ctx.Err(); err != nil {
+			result <- PipelineResult[T]{Err: err}
+			return
+		}
+		pipeline, err := create()
+		if err == nil && ctx.Err() != nil {
+			pipeline.Release()
+			var zero T
+			pipeline, err = zero, ctx.Err()
+		}
+		result <- PipelineResult[T]{Pipeline: pipeline, Err: err}
+	}()
+	return result
+}
+
+// CreateRenderPipelineAsync creates a render pipeline without blocking the
+// calling goroutine, and sends the result on the returned channel. See
+// SetPipelineWorkers for how many are created at once.
+//
+// The descriptor must not be modified, and the device must not be released,
+// until the result is received.
+func (p *Device) CreateRenderPipelineAsync(ctx context.Context, descriptor *RenderPipelineDescriptor) <-chan PipelineResult[*RenderPipeline] {
+	p.checkReleased()
+	return createPipelineAsync(ctx, &p.pipelineWorkers, func() (*RenderPipeline, error) {
+		return p.CreateRenderPipeline(descriptor)
+	})
+}
+
+// CreateComputePipelineAsync creates a compute pipeline without blocking the
+// calling goroutine, and sends the result on the returned channel. See
+// SetPipelineWorkers for how many are created at once.
+//
+// The descriptor must not be modified, and the device must not be released,
+// until the result is received.
+func (p *Device) CreateComputePipelineAsync(ctx context.Context, descriptor *ComputePipelineDescriptor) <-chan PipelineResult[*ComputePipeline] {
+	p.checkReleased()
+	return createPipelineAsync(ctx, &p.pipelineWorkers, func() (*ComputePipeline, error) {
+		return p.CreateComputePipeline(descriptor)
+	})
+}
+
+// WarmPipelines creates the render and compute pipelines of the descriptors
+// in parallel, and waits for all of them. As with SetPipelineWorkers, they
+// are only created in parallel in ValidationMode_Deferred, where their errors
+// are collected once all of them are done. If progress is not nil, it is
+// called from the calling goroutine after each pipeline is created, with the
+// number of pipelines done so far.
+//
+// The pipelines are returned in the order of their descriptors. Pipelines
+// that failed to be created are nil, and their errors are joined in the
+// returned error.
+func (p *Device) WarmPipelines(
+	ctx context.Context,
+	render []*RenderPipelineDescriptor,
+	compute []*ComputePipelineDescriptor,
+	progress func(done, total int),
+) ([]*RenderPipeline, []*ComputePipeline, error) {
+	p.checkReleased()
+
+	renderPipelines := make([]*RenderPipeline, len(render))
+	computePipelines := make([]*ComputePipeline, len(compute))
+	errs := make(chan error, len(render)+len(compute))
+	for i, descriptor := range render {
+		result := p.CreateRenderPipelineAsync(ctx, descriptor)
+		go func(i int) {
+			r := <-result
+			renderPipelines[i] = r.Pipeline
+			errs <- r.Err
+		}(i)
+	}
+	for i, descriptor := range compute {
+		result := p.CreateComputePipelineAsync(ctx, descriptor)
+		go func(i int) {
+			r := <-result
+			computePipelines[i] = r.Pipeline
+			errs <- r.Err
+		}(i)
+	}
+
+	total := len(render) + len(compute)
+	var joined []error
+	for done := 1; done <= total; done++ {
+		if err := <-errs; err != nil {
+			joined = append(joined, err)
+		}
+		if progress != nil {
+			progress(done, total)
+		}
+	}
+	return renderPipelines, computePipelines, errors.Join(joined...)
+}
@@ -0,0 +1,89 @@
+package wgpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func testDevice(t *testing.T) *Device {
+	t.Helper()
+
+	instance := CreateInstance(nil)
+	t.Cleanup(instance.Release)
+
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		t.Skipf("no adapter: %v", err)
+	}
+	t.Cleanup(adapter.Release)
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Skipf("no device: %v", err)
+	}
+	t.Cleanup(device.Release)
+	return device
+}
+
+func TestCreateComputePipelineAsyncErrors(t *testing.T) {
+	device := testDevice(t)
+	device.SetPipelineWorkers(8)
+
+	module, err := device.CreateShaderModule(&ShaderModuleDescriptor{
+		WGSLDescriptor: &ShaderModuleWGSLDescriptor{
+			Code: "@compute @workgroup_size(1) fn main() {}",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer module.Release()
+
+	type pending struct {
+		label  string
+		bad    bool
+		result <-chan PipelineResult[*ComputePipeline]
+	}
+	var all []pending
+	for i := 0; i < 32; i++ {
+		bad := i%2 == 0
+		entryPoint := "main"
+		if bad {
+			entryPoint = "missing"
+		}
+		label := fmt.Sprintf("pipeline %d", i)
+		all = append(all, pending{label, bad, device.CreateComputePipelineAsync(context.Background(), &ComputePipelineDescriptor{
+			Label: label,
+			Compute: ProgrammableStageDescriptor{
+				Module:     module,
+				EntryPoint: entryPoint,
+			},
+		})})
+	}
+
+	for _, p := range all {
+		r := <-p.result
+		if !p.bad {
+			if r.Err != nil {
+				t.Errorf("%s: unexpected error: %v", p.label, r.Err)
+				continue
+			}
+			r.Pipeline.Release()
+			continue
+		}
+
+		var err *Error
+		if !errors.As(r.Err, &err) {
+			t.Errorf("%s: got %v, expected a validation error", p.label, r.Err)
+			if r.Pipeline != nil {
+				r.Pipeline.Release()
+			}
+			continue
+		}
+		if err.Type != ErrorType_Validation || err.Label != p.label {
+			t.Errorf("%s: got error %v of %q", p.label, err, err.Label)
+		}
+	}
+}
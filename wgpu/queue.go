@@ -9,57 +9,113 @@ extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message,
 extern void gowebgpu_queue_work_done_callback_c(WGPUQueueWorkDoneStatus status, void * userdata);
 
 static inline void gowebgpu_queue_write_buffer(WGPUQueue queue, WGPUBuffer buffer, uint64_t bufferOffset, void const * data, size_t size, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuQueueWriteBuffer(queue, buffer, bufferOffset, data, size);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 static inline void gowebgpu_queue_write_texture(WGPUQueue queue, WGPUImageCopyTexture const * destination, void const * data, size_t dataSize, WGPUTextureDataLayout const * dataLayout, WGPUExtent3D const * writeSize, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuQueueWriteTexture(queue, destination, data, dataSize, dataLayout, writeSize);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
-}
-
-static inline void gowebgpu_queue_release(WGPUQueue queue, WGPUDevice device) {
-	wgpuDeviceRelease(device);
-	wgpuQueueRelease(queue);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 */
 import "C"
 import (
-	"errors"
+	"context"
 	"runtime/cgo"
 	"unsafe"
 )
 
 type Queue struct {
-	deviceRef C.WGPUDevice
-	ref       C.WGPUQueue
+	device *Device
+	ref    C.WGPUQueue
+	child  *deviceChild
 }
 
 type QueueWorkDoneCallback func(QueueWorkDoneStatus)
 
 //export gowebgpu_queue_work_done_callback_go
 func gowebgpu_queue_work_done_callback_go(status C.WGPUQueueWorkDoneStatus, userdata unsafe.Pointer) {
-	handle := *(*cgo.Handle)(userdata)
-	defer handle.Delete()
-
-	cb, ok := handle.Value().(QueueWorkDoneCallback)
+	cb, ok := takeCallbackHandle(userdata).(QueueWorkDoneCallback)
 	if ok {
 		cb(QueueWorkDoneStatus(status))
 	}
 }
 
 func (p *Queue) OnSubmittedWorkDone(callback QueueWorkDoneCallback) {
-	handle := cgo.NewHandle(callback)
+	p.checkReleased()
+	if p.device.isLost() {
+		callback(QueueWorkDoneStatus_DeviceLost)
+		return
+	}
+	C.wgpuQueueOnSubmittedWorkDone(p.ref, C.WGPUQueueWorkDoneCallback(C.gowebgpu_queue_work_done_callback_c), newCallbackHandle(callback))
+}
 
-	C.wgpuQueueOnSubmittedWorkDone(p.ref, C.WGPUQueueWorkDoneCallback(C.gowebgpu_queue_work_done_callback_c), unsafe.Pointer(&handle))
+// WorkDone is like OnSubmittedWorkDone but sends the status on the returned
+// channel. The device has to be polled for it to arrive, see
+// (*Device).StartPoller.
+func (p *Queue) WorkDone() <-chan QueueWorkDoneStatus {
+	p.checkReleased()
+	statuses := make(chan QueueWorkDoneStatus, 1)
+	p.OnSubmittedWorkDone(func(status QueueWorkDoneStatus) {
+		statuses <- status
+	})
+	return statuses
+}
+
+// OnSubmittedWorkDoneContext polls the device until all the work submitted
+// so far has completed or ctx is done. If ctx is done first the callback
+// stays registered and is freed by a later poll of the device.
+func (p *Queue) OnSubmittedWorkDoneContext(ctx context.Context) error {
+	p.checkReleased()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	statuses := make(chan QueueWorkDoneStatus, 1)
+	p.OnSubmittedWorkDone(func(status QueueWorkDoneStatus) {
+		statuses <- status
+	})
+
+	status, err := pollUntil(ctx, p.device.ref, statuses)
+	if err != nil {
+		return err
+	}
+	if status != QueueWorkDoneStatus_Success {
+		typ := ErrorType_Unknown
+		if status == QueueWorkDoneStatus_DeviceLost {
+			typ = ErrorType_DeviceLost
+		}
+		return &Error{
+			Type:    typ,
+			Op:      "wgpu.(*Queue).OnSubmittedWorkDoneContext()",
+			Message: status.String(),
+		}
+	}
+	return nil
 }
 
 type SubmissionIndex uint64
 
 func (p *Queue) Submit(commands ...*CommandBuffer) (submissionIndex SubmissionIndex) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return 0
+	}
+	if p.device.recordDeferred("wgpu.(*Queue).Submit()", "") {
+		defer p.device.collectDeferred("wgpu.(*Queue).Submit()")
+	}
+
 	commandCount := len(commands)
 	if commandCount == 0 {
 		r := C.wgpuQueueSubmitForIndex(p.ref, 0, nil)
@@ -71,6 +127,7 @@ func (p *Queue) Submit(commands ...*CommandBuffer) (submissionIndex SubmissionIn
 
 	commandRefsSlice := unsafe.Slice((*C.WGPUCommandBuffer)(commandRefs), commandCount)
 	for i, v := range commands {
+		v.checkReleased()
 		commandRefsSlice[i] = v.ref
 	}
 
@@ -83,39 +140,54 @@ func (p *Queue) Submit(commands ...*CommandBuffer) (submissionIndex SubmissionIn
 }
 
 func (p *Queue) WriteBuffer(buffer *Buffer, bufferOffset uint64, data []byte) (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Queue).WriteBuffer(): " + message)
+	p.checkReleased()
+	buffer.checkReleased()
+	if err := p.device.lostError("wgpu.(*Queue).WriteBuffer()", buffer.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*Queue).WriteBuffer()", buffer.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Queue).WriteBuffer()", Label: buffer.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
 	size := len(data)
 	if size == 0 {
+		unlock := p.device.lockErrorScope(errorUserdata)
 		C.gowebgpu_queue_write_buffer(
 			p.ref,
 			buffer.ref,
 			C.uint64_t(bufferOffset),
 			nil,
 			0,
-			p.deviceRef,
-			unsafe.Pointer(&errorCallbackHandle),
+			p.device.ref,
+			errorUserdata,
 		)
+		unlock()
 		return
 	}
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_queue_write_buffer(
 		p.ref,
 		buffer.ref,
 		C.uint64_t(bufferOffset),
 		unsafe.Pointer(&data[0]),
 		C.size_t(size),
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *Queue) WriteTexture(destination *ImageCopyTexture, data []byte, dataLayout *TextureDataLayout, writeSize *Extent3D) (err error) {
+	p.checkReleased()
 	var dst C.WGPUImageCopyTexture
 	if destination != nil {
 		dst = C.WGPUImageCopyTexture{
@@ -128,6 +200,7 @@ func (p *Queue) WriteTexture(destination *ImageCopyTexture, data []byte, dataLay
 			aspect: C.WGPUTextureAspect(destination.Aspect),
 		}
 		if destination.Texture != nil {
+			destination.Texture.checkReleased()
 			dst.texture = destination.Texture.ref
 		}
 	}
@@ -150,14 +223,28 @@ func (p *Queue) WriteTexture(destination *ImageCopyTexture, data []byte, dataLay
 		}
 	}
 
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Queue).WriteTexture(): " + message)
+	var label string
+	if destination != nil && destination.Texture != nil {
+		label = destination.Texture.label
+	}
+
+	if err := p.device.lostError("wgpu.(*Queue).WriteTexture()", label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*Queue).WriteTexture()", label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Queue).WriteTexture()", Label: label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
 	size := len(data)
 	if size == 0 {
+		unlock := p.device.lockErrorScope(errorUserdata)
 		C.gowebgpu_queue_write_texture(
 			p.ref,
 			&dst,
@@ -165,12 +252,14 @@ func (p *Queue) WriteTexture(destination *ImageCopyTexture, data []byte, dataLay
 			0,
 			&layout,
 			&writeExtent,
-			p.deviceRef,
-			unsafe.Pointer(&errorCallbackHandle),
+			p.device.ref,
+			errorUserdata,
 		)
+		unlock()
 		return
 	}
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_queue_write_texture(
 		p.ref,
 		&dst,
@@ -178,12 +267,13 @@ func (p *Queue) WriteTexture(destination *ImageCopyTexture, data []byte, dataLay
 		C.size_t(size),
 		&layout,
 		&writeExtent,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
-func (p *Queue) Release() {
-	C.gowebgpu_queue_release(p.ref, p.deviceRef)
-}
+// Release does nothing, the queue is shared by the callers of
+// Device.GetQueue and is released with the device.
+func (p *Queue) Release() {}
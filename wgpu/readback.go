@@ -0,0 +1,146 @@
+package wgpu
+
+import "context"
+
+// ReadBuffer copies size bytes of buffer at offset to a staging buffer, waits
+// for the copy to complete or ctx to be done, and returns the bytes.
+//
+// Buffers created with BufferUsage_MapRead are mapped directly, others must
+// have BufferUsage_CopySrc.
+func (p *Device) ReadBuffer(ctx context.Context, buffer *Buffer, offset, size uint64) ([]byte, error) {
+	p.checkReleased()
+	buffer.checkReleased()
+	if size == 0 {
+		return []byte{}, nil
+	}
+	bufferSize := buffer.GetSize()
+	if offset > bufferSize || size > bufferSize-offset {
+		return nil, &Error{
+			Type:    ErrorType_Validation,
+			Op:      "wgpu.(*Device).ReadBuffer()",
+			Label:   buffer.label,
+			Message: "range is out of the bounds of the buffer",
+		}
+	}
+
+	if buffer.GetUsage()&BufferUsage_MapRead != 0 {
+		start, end := readBufferRange(offset, size, bufferSize, MapAlignment)
+		if err := buffer.MapAsyncContext(ctx, MapMode_Read, start, end-start); err != nil {
+			return nil, err
+		}
+		defer buffer.Unmap()
+
+		data := make([]byte, size)
+		copy(data, buffer.GetMappedRange(uint(start), uint(end-start))[offset-start:])
+		return data, nil
+	}
+
+	// copies must be aligned to CopyBufferAlignment, read the enclosing range.
+	start, end := readBufferRange(offset, size, bufferSize, CopyBufferAlignment)
+	data, err := p.readback(ctx, end-start, func(encoder *CommandEncoder, staging *Buffer) error {
+		return encoder.CopyBufferToBuffer(buffer, start, staging, 0, end-start)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data[offset-start : offset-start+size], nil
+}
+
+// readBufferRange returns the range of a buffer of bufferSize bytes to map or
+// copy to read size bytes at offset: its start is aligned down to alignment
+// and its end up to CopyBufferAlignment, but not past the end of the buffer.
+func readBufferRange(offset, size, bufferSize, alignment uint64) (start, end uint64) {
+	start = offset &^ (alignment - 1)
+	end = alignUp(offset+size, CopyBufferAlignment)
+	if end > bufferSize {
+		end = bufferSize
+	}
+	return start, end
+}
+
+// ReadTexture copies the extent of the texture at source to a staging
+// buffer, waits for the copy to complete or ctx to be done, and returns the
+// texels. They are tightly packed: rows of blocks follow each other without
+// padding, and so do the images of each layer or depth slice.
+//
+// The texture must have TextureUsage_CopySrc. Depth and stencil textures
+// must be read one aspect at a time.
+func (p *Device) ReadTexture(ctx context.Context, source ImageCopyTexture, extent Extent3D) ([]byte, error) {
+	p.checkReleased()
+	source.Texture.checkReleased()
+
+	texture := source.Texture
+	extent.DepthOrArrayLayers = max32(extent.DepthOrArrayLayers, 1)
+	if extent.Width == 0 || extent.Height == 0 {
+		return []byte{}, nil
+	}
+	layout, err := CalculateTextureCopyLayout(&TextureDescriptor{
+		Label:     texture.label,
+		Dimension: texture.GetDimension(),
+		Size: Extent3D{
+			Width:              texture.GetWidth(),
+			Height:             texture.GetHeight(),
+			DepthOrArrayLayers: texture.GetDepthOrArrayLayers(),
+		},
+		Format:        texture.GetFormat(),
+		MipLevelCount: texture.GetMipLevelCount(),
+	}, source.MipLevel, source.Aspect, &extent, true)
+	if err != nil {
+		if err, ok := err.(*Error); ok {
+			err.Op = "wgpu.(*Device).ReadTexture()"
+		}
+		return nil, err
+	}
+
+	data, err := p.readback(ctx, layout.Size, func(encoder *CommandEncoder, staging *Buffer) error {
+		return encoder.CopyTextureToBuffer(&source, &ImageCopyBuffer{
+			Buffer: staging,
+			Layout: layout.Layout,
+		}, &extent)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return layout.Unpad(data), nil
+}
+
+// readback creates a staging buffer of size bytes, submits the copy recorded
+// by encode to it, and returns its contents once the copy completes.
+func (p *Device) readback(ctx context.Context, size uint64, encode func(encoder *CommandEncoder, staging *Buffer) error) ([]byte, error) {
+	staging, err := p.CreateBuffer(&BufferDescriptor{
+		Label: "readback",
+		Size:  size,
+		Usage: BufferUsage_MapRead | BufferUsage_CopyDst,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer staging.Release()
+
+	encoder, err := p.CreateCommandEncoder(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer encoder.Release()
+	if err := encode(encoder, staging); err != nil {
+		return nil, err
+	}
+	commands, err := encoder.Finish(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer commands.Release()
+
+	queue := p.GetQueue()
+	defer queue.Release()
+	queue.Submit(commands)
+
+	if err := staging.MapAsyncContext(ctx, MapMode_Read, 0, size); err != nil {
+		return nil, err
+	}
+	defer staging.Unmap()
+
+	data := make([]byte, size)
+	copy(data, staging.GetMappedRange(0, uint(size)))
+	return data, nil
+}
@@ -0,0 +1,65 @@
+package wgpu
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestReadBufferRange(t *testing.T) {
+	tests := []struct {
+		name                                string
+		offset, size, bufferSize, alignment uint64
+		start, end                          uint64
+	}{
+		{"aligned", 8, 8, 32, MapAlignment, 8, 16},
+		{"unaligned offset", 12, 4, 32, MapAlignment, 8, 16},
+		{"unaligned size", 8, 3, 32, MapAlignment, 8, 12},
+		{"copy", 6, 4, 32, CopyBufferAlignment, 4, 12},
+		// the end is aligned past the end of the buffer.
+		{"end of the buffer", 12, 6, 18, MapAlignment, 8, 18},
+		{"whole buffer", 0, 18, 18, CopyBufferAlignment, 0, 18},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := readBufferRange(tt.offset, tt.size, tt.bufferSize, tt.alignment)
+			if start != tt.start || end != tt.end {
+				t.Errorf("got [%d, %d), expected [%d, %d)", start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestReadBuffer(t *testing.T) {
+	device := testDevice(t)
+
+	contents := []byte("0123456789abcdefghij")
+	for _, usage := range []BufferUsage{BufferUsage_MapRead, BufferUsage_CopySrc} {
+		buffer, err := device.CreateBuffer(&BufferDescriptor{
+			Usage:            usage,
+			Size:             uint64(len(contents)),
+			MappedAtCreation: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer buffer.Release()
+		copy(buffer.GetMappedRange(0, uint(len(contents))), contents)
+		if err := buffer.Unmap(); err != nil {
+			t.Fatal(err)
+		}
+
+		// an unaligned range at the end of the buffer.
+		data, err := device.ReadBuffer(context.Background(), buffer, 17, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, contents[17:]) {
+			t.Errorf("%s: got %q, expected %q", usage, data, contents[17:])
+		}
+
+		if _, err := device.ReadBuffer(context.Background(), buffer, 16, 8); err == nil {
+			t.Errorf("%s: expected an error reading past the end of the buffer", usage)
+		}
+	}
+}
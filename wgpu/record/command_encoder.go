@@ -0,0 +1,364 @@
+package record
+
+import (
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+type commandEncoder struct {
+	object
+	inner api.CommandEncoder
+}
+
+func (p *commandEncoder) unwrap() any { return p.inner }
+
+func (p *commandEncoder) BeginComputePass(descriptor *api.ComputePassDescriptor) api.ComputePassEncoder {
+	inner := p.inner.BeginComputePass(descriptor)
+	id := p.r.create(p.id, "BeginComputePass", nil, descriptor)
+	return &computePassEncoder{object{p.r, id}, inner}
+}
+
+func (p *commandEncoder) BeginRenderPass(descriptor *api.RenderPassDescriptor) api.RenderPassEncoder {
+	inner := p.inner.BeginRenderPass(unwrap(descriptor))
+	id := p.r.create(p.id, "BeginRenderPass", nil, descriptor)
+	return &renderPassEncoder{object{p.r, id}, inner}
+}
+
+func (p *commandEncoder) ClearBuffer(buffer api.Buffer, offset uint64, size uint64) error {
+	err := p.inner.ClearBuffer(unwrap(buffer), offset, size)
+	p.r.call(p.id, "ClearBuffer", err, buffer, offset, size)
+	return err
+}
+
+func (p *commandEncoder) CopyBufferToBuffer(source api.Buffer, sourceOffset uint64, destination api.Buffer, destinationOffset uint64, size uint64) error {
+	err := p.inner.CopyBufferToBuffer(unwrap(source), sourceOffset, unwrap(destination), destinationOffset, size)
+	p.r.call(p.id, "CopyBufferToBuffer", err, source, sourceOffset, destination, destinationOffset, size)
+	return err
+}
+
+func (p *commandEncoder) CopyBufferToTexture(source *api.ImageCopyBuffer, destination *api.ImageCopyTexture, copySize *api.Extent3D) error {
+	err := p.inner.CopyBufferToTexture(unwrap(source), unwrap(destination), copySize)
+	p.r.call(p.id, "CopyBufferToTexture", err, source, destination, copySize)
+	return err
+}
+
+func (p *commandEncoder) CopyTextureToBuffer(source *api.ImageCopyTexture, destination *api.ImageCopyBuffer, copySize *api.Extent3D) error {
+	err := p.inner.CopyTextureToBuffer(unwrap(source), unwrap(destination), copySize)
+	p.r.call(p.id, "CopyTextureToBuffer", err, source, destination, copySize)
+	return err
+}
+
+func (p *commandEncoder) CopyTextureToTexture(source *api.ImageCopyTexture, destination *api.ImageCopyTexture, copySize *api.Extent3D) error {
+	err := p.inner.CopyTextureToTexture(unwrap(source), unwrap(destination), copySize)
+	p.r.call(p.id, "CopyTextureToTexture", err, source, destination, copySize)
+	return err
+}
+
+func (p *commandEncoder) Finish(descriptor *api.CommandBufferDescriptor) (api.CommandBuffer, error) {
+	inner, err := p.inner.Finish(descriptor)
+	id := p.r.create(p.id, "Finish", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &commandBuffer{object{p.r, id}, inner}, nil
+}
+
+func (p *commandEncoder) InsertDebugMarker(markerLabel string) error {
+	err := p.inner.InsertDebugMarker(markerLabel)
+	p.r.call(p.id, "InsertDebugMarker", err, markerLabel)
+	return err
+}
+
+func (p *commandEncoder) PopDebugGroup() error {
+	err := p.inner.PopDebugGroup()
+	p.r.call(p.id, "PopDebugGroup", err)
+	return err
+}
+
+func (p *commandEncoder) PushDebugGroup(groupLabel string) error {
+	err := p.inner.PushDebugGroup(groupLabel)
+	p.r.call(p.id, "PushDebugGroup", err, groupLabel)
+	return err
+}
+
+func (p *commandEncoder) ResolveQuerySet(querySet api.QuerySet, firstQuery uint32, queryCount uint32, destination api.Buffer, destinationOffset uint64) error {
+	err := p.inner.ResolveQuerySet(unwrap(querySet), firstQuery, queryCount, unwrap(destination), destinationOffset)
+	p.r.call(p.id, "ResolveQuerySet", err, querySet, firstQuery, queryCount, destination, destinationOffset)
+	return err
+}
+
+func (p *commandEncoder) WriteTimestamp(querySet api.QuerySet, queryIndex uint32) error {
+	err := p.inner.WriteTimestamp(unwrap(querySet), queryIndex)
+	p.r.call(p.id, "WriteTimestamp", err, querySet, queryIndex)
+	return err
+}
+
+func (p *commandEncoder) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type computePassEncoder struct {
+	object
+	inner api.ComputePassEncoder
+}
+
+func (p *computePassEncoder) unwrap() any { return p.inner }
+
+func (p *computePassEncoder) BeginPipelineStatisticsQuery(querySet api.QuerySet, queryIndex uint32) {
+	p.inner.BeginPipelineStatisticsQuery(unwrap(querySet), queryIndex)
+	p.r.call(p.id, "BeginPipelineStatisticsQuery", nil, querySet, queryIndex)
+}
+
+func (p *computePassEncoder) DispatchWorkgroups(workgroupCountX uint32, workgroupCountY uint32, workgroupCountZ uint32) {
+	p.inner.DispatchWorkgroups(workgroupCountX, workgroupCountY, workgroupCountZ)
+	p.r.call(p.id, "DispatchWorkgroups", nil, workgroupCountX, workgroupCountY, workgroupCountZ)
+}
+
+func (p *computePassEncoder) DispatchWorkgroupsIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.inner.DispatchWorkgroupsIndirect(unwrap(indirectBuffer), indirectOffset)
+	p.r.call(p.id, "DispatchWorkgroupsIndirect", nil, indirectBuffer, indirectOffset)
+}
+
+func (p *computePassEncoder) End() error {
+	err := p.inner.End()
+	p.r.call(p.id, "End", err)
+	return err
+}
+
+func (p *computePassEncoder) EndPipelineStatisticsQuery() {
+	p.inner.EndPipelineStatisticsQuery()
+	p.r.call(p.id, "EndPipelineStatisticsQuery", nil)
+}
+
+func (p *computePassEncoder) InsertDebugMarker(markerLabel string) {
+	p.inner.InsertDebugMarker(markerLabel)
+	p.r.call(p.id, "InsertDebugMarker", nil, markerLabel)
+}
+
+func (p *computePassEncoder) PopDebugGroup() {
+	p.inner.PopDebugGroup()
+	p.r.call(p.id, "PopDebugGroup", nil)
+}
+
+func (p *computePassEncoder) PushDebugGroup(groupLabel string) {
+	p.inner.PushDebugGroup(groupLabel)
+	p.r.call(p.id, "PushDebugGroup", nil, groupLabel)
+}
+
+func (p *computePassEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	p.inner.SetBindGroup(groupIndex, unwrap(group), dynamicOffsets)
+	p.r.call(p.id, "SetBindGroup", nil, groupIndex, group, dynamicOffsets)
+}
+
+func (p *computePassEncoder) SetPipeline(pipeline api.ComputePipeline) {
+	p.inner.SetPipeline(unwrap(pipeline))
+	p.r.call(p.id, "SetPipeline", nil, pipeline)
+}
+
+func (p *computePassEncoder) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type renderPassEncoder struct {
+	object
+	inner api.RenderPassEncoder
+}
+
+func (p *renderPassEncoder) unwrap() any { return p.inner }
+
+func (p *renderPassEncoder) BeginOcclusionQuery(queryIndex uint32) {
+	p.inner.BeginOcclusionQuery(queryIndex)
+	p.r.call(p.id, "BeginOcclusionQuery", nil, queryIndex)
+}
+
+func (p *renderPassEncoder) BeginPipelineStatisticsQuery(querySet api.QuerySet, queryIndex uint32) {
+	p.inner.BeginPipelineStatisticsQuery(unwrap(querySet), queryIndex)
+	p.r.call(p.id, "BeginPipelineStatisticsQuery", nil, querySet, queryIndex)
+}
+
+func (p *renderPassEncoder) Draw(vertexCount uint32, instanceCount uint32, firstVertex uint32, firstInstance uint32) {
+	p.inner.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
+	p.r.call(p.id, "Draw", nil, vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+func (p *renderPassEncoder) DrawIndexed(indexCount uint32, instanceCount uint32, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	p.inner.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+	p.r.call(p.id, "DrawIndexed", nil, indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+func (p *renderPassEncoder) DrawIndexedIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.inner.DrawIndexedIndirect(unwrap(indirectBuffer), indirectOffset)
+	p.r.call(p.id, "DrawIndexedIndirect", nil, indirectBuffer, indirectOffset)
+}
+
+func (p *renderPassEncoder) DrawIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.inner.DrawIndirect(unwrap(indirectBuffer), indirectOffset)
+	p.r.call(p.id, "DrawIndirect", nil, indirectBuffer, indirectOffset)
+}
+
+func (p *renderPassEncoder) End() error {
+	err := p.inner.End()
+	p.r.call(p.id, "End", err)
+	return err
+}
+
+func (p *renderPassEncoder) EndOcclusionQuery() {
+	p.inner.EndOcclusionQuery()
+	p.r.call(p.id, "EndOcclusionQuery", nil)
+}
+
+func (p *renderPassEncoder) EndPipelineStatisticsQuery() {
+	p.inner.EndPipelineStatisticsQuery()
+	p.r.call(p.id, "EndPipelineStatisticsQuery", nil)
+}
+
+func (p *renderPassEncoder) ExecuteBundles(bundles ...api.RenderBundle) {
+	p.inner.ExecuteBundles(unwrap(bundles)...)
+	p.r.call(p.id, "ExecuteBundles", nil, bundles)
+}
+
+func (p *renderPassEncoder) InsertDebugMarker(markerLabel string) {
+	p.inner.InsertDebugMarker(markerLabel)
+	p.r.call(p.id, "InsertDebugMarker", nil, markerLabel)
+}
+
+func (p *renderPassEncoder) PopDebugGroup() {
+	p.inner.PopDebugGroup()
+	p.r.call(p.id, "PopDebugGroup", nil)
+}
+
+func (p *renderPassEncoder) PushDebugGroup(groupLabel string) {
+	p.inner.PushDebugGroup(groupLabel)
+	p.r.call(p.id, "PushDebugGroup", nil, groupLabel)
+}
+
+func (p *renderPassEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	p.inner.SetBindGroup(groupIndex, unwrap(group), dynamicOffsets)
+	p.r.call(p.id, "SetBindGroup", nil, groupIndex, group, dynamicOffsets)
+}
+
+func (p *renderPassEncoder) SetBlendConstant(color *api.Color) {
+	p.inner.SetBlendConstant(color)
+	p.r.call(p.id, "SetBlendConstant", nil, color)
+}
+
+func (p *renderPassEncoder) SetIndexBuffer(buffer api.Buffer, format api.IndexFormat, offset uint64, size uint64) {
+	p.inner.SetIndexBuffer(unwrap(buffer), format, offset, size)
+	p.r.call(p.id, "SetIndexBuffer", nil, buffer, format, offset, size)
+}
+
+func (p *renderPassEncoder) SetPipeline(pipeline api.RenderPipeline) {
+	p.inner.SetPipeline(unwrap(pipeline))
+	p.r.call(p.id, "SetPipeline", nil, pipeline)
+}
+
+func (p *renderPassEncoder) SetPushConstants(stages api.ShaderStage, offset uint32, data []byte) {
+	p.inner.SetPushConstants(stages, offset, data)
+	p.r.call(p.id, "SetPushConstants", nil, stages, offset, data)
+}
+
+func (p *renderPassEncoder) SetScissorRect(x uint32, y uint32, width uint32, height uint32) {
+	p.inner.SetScissorRect(x, y, width, height)
+	p.r.call(p.id, "SetScissorRect", nil, x, y, width, height)
+}
+
+func (p *renderPassEncoder) SetStencilReference(reference uint32) {
+	p.inner.SetStencilReference(reference)
+	p.r.call(p.id, "SetStencilReference", nil, reference)
+}
+
+func (p *renderPassEncoder) SetVertexBuffer(slot uint32, buffer api.Buffer, offset uint64, size uint64) {
+	p.inner.SetVertexBuffer(slot, unwrap(buffer), offset, size)
+	p.r.call(p.id, "SetVertexBuffer", nil, slot, buffer, offset, size)
+}
+
+func (p *renderPassEncoder) SetViewport(x float32, y float32, width float32, height float32, minDepth float32, maxDepth float32) {
+	p.inner.SetViewport(x, y, width, height, minDepth, maxDepth)
+	p.r.call(p.id, "SetViewport", nil, x, y, width, height, minDepth, maxDepth)
+}
+
+func (p *renderPassEncoder) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type renderBundleEncoder struct {
+	object
+	inner api.RenderBundleEncoder
+}
+
+func (p *renderBundleEncoder) unwrap() any { return p.inner }
+
+func (p *renderBundleEncoder) Draw(vertexCount uint32, instanceCount uint32, firstVertex uint32, firstInstance uint32) {
+	p.inner.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
+	p.r.call(p.id, "Draw", nil, vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+func (p *renderBundleEncoder) DrawIndexed(indexCount uint32, instanceCount uint32, firstIndex uint32, baseVertex uint32, firstInstance uint32) {
+	p.inner.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+	p.r.call(p.id, "DrawIndexed", nil, indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+func (p *renderBundleEncoder) DrawIndexedIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.inner.DrawIndexedIndirect(unwrap(indirectBuffer), indirectOffset)
+	p.r.call(p.id, "DrawIndexedIndirect", nil, indirectBuffer, indirectOffset)
+}
+
+func (p *renderBundleEncoder) DrawIndirect(indirectBuffer api.Buffer, indirectOffset uint64) {
+	p.inner.DrawIndirect(unwrap(indirectBuffer), indirectOffset)
+	p.r.call(p.id, "DrawIndirect", nil, indirectBuffer, indirectOffset)
+}
+
+func (p *renderBundleEncoder) Finish(descriptor *api.RenderBundleDescriptor) api.RenderBundle {
+	inner := p.inner.Finish(descriptor)
+	id := p.r.create(p.id, "Finish", nil, descriptor)
+	return &renderBundle{object{p.r, id}, inner}
+}
+
+func (p *renderBundleEncoder) InsertDebugMarker(markerLabel string) {
+	p.inner.InsertDebugMarker(markerLabel)
+	p.r.call(p.id, "InsertDebugMarker", nil, markerLabel)
+}
+
+func (p *renderBundleEncoder) PopDebugGroup() {
+	p.inner.PopDebugGroup()
+	p.r.call(p.id, "PopDebugGroup", nil)
+}
+
+func (p *renderBundleEncoder) PushDebugGroup(groupLabel string) {
+	p.inner.PushDebugGroup(groupLabel)
+	p.r.call(p.id, "PushDebugGroup", nil, groupLabel)
+}
+
+func (p *renderBundleEncoder) SetBindGroup(groupIndex uint32, group api.BindGroup, dynamicOffsets []uint32) {
+	p.inner.SetBindGroup(groupIndex, unwrap(group), dynamicOffsets)
+	p.r.call(p.id, "SetBindGroup", nil, groupIndex, group, dynamicOffsets)
+}
+
+func (p *renderBundleEncoder) SetIndexBuffer(buffer api.Buffer, format api.IndexFormat, offset uint64, size uint64) {
+	p.inner.SetIndexBuffer(unwrap(buffer), format, offset, size)
+	p.r.call(p.id, "SetIndexBuffer", nil, buffer, format, offset, size)
+}
+
+func (p *renderBundleEncoder) SetPipeline(pipeline api.RenderPipeline) {
+	p.inner.SetPipeline(unwrap(pipeline))
+	p.r.call(p.id, "SetPipeline", nil, pipeline)
+}
+
+func (p *renderBundleEncoder) SetVertexBuffer(slot uint32, buffer api.Buffer, offset uint64, size uint64) {
+	p.inner.SetVertexBuffer(slot, unwrap(buffer), offset, size)
+	p.r.call(p.id, "SetVertexBuffer", nil, slot, buffer, offset, size)
+}
+
+func (p *renderBundleEncoder) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+var (
+	_ api.CommandEncoder      = (*commandEncoder)(nil)
+	_ api.ComputePassEncoder  = (*computePassEncoder)(nil)
+	_ api.RenderPassEncoder   = (*renderPassEncoder)(nil)
+	_ api.RenderBundleEncoder = (*renderBundleEncoder)(nil)
+)
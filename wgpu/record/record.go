@@ -0,0 +1,339 @@
+// Package record wraps the api interfaces to write every call made on them
+// to a capture, that package replay can run against any device.
+//
+// A capture is a stream of JSON values: a Header followed by one Call per
+// recorded call, including descriptors, labels and the data written to
+// buffers and textures. Handles are written as the ID of the object they
+// refer to, the recorded device being object 1.
+//
+// Calls that only query state, like GetLimits or Poll, are not recorded.
+package record
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+// Version is the version of the capture format.
+const Version = 1
+
+// MethodWriteMappedRange is the method of the calls recorded before Unmap,
+// one per range returned by GetMappedRange on a buffer mapped for writing.
+// Its arguments are the offset of the range and its contents.
+const MethodWriteMappedRange = "WriteMappedRange"
+
+// Header is the first value of a capture.
+type Header struct {
+	Version  int               `json:"version"`
+	Features []api.FeatureName `json:"features,omitempty"`
+	Limits   api.Limits        `json:"limits"`
+}
+
+// Call is a method call on a recorded object.
+type Call struct {
+	// Object is the ID of the object the method is called on.
+	Object uint64 `json:"object"`
+	Method string `json:"method"`
+	// Args holds the arguments of the call. Callbacks are written as null,
+	// and variadic arguments as a single array.
+	Args []json.RawMessage `json:"args,omitempty"`
+	// Result is the ID given to the object the call returned, if any.
+	Result uint64 `json:"result,omitempty"`
+	// Error is the error the call returned, if any.
+	Error string `json:"error,omitempty"`
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	lastID uint64
+	err    error
+}
+
+func (r *recorder) write(id uint64, method string, create bool, err error, args ...any) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	call := Call{Object: id, Method: method}
+	if create {
+		r.lastID++
+		call.Result = r.lastID
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	for _, arg := range args {
+		raw, err := json.Marshal(arg)
+		if err != nil && r.err == nil {
+			r.err = err
+		}
+		call.Args = append(call.Args, raw)
+	}
+	if r.err == nil {
+		r.err = r.enc.Encode(&call)
+	}
+	return call.Result
+}
+
+// call records a call to method on the object id.
+func (r *recorder) call(id uint64, method string, err error, args ...any) {
+	r.write(id, method, false, err, args...)
+}
+
+// create records a call to method on the object id that returns a new
+// object, and returns the ID of that object.
+func (r *recorder) create(id uint64, method string, err error, args ...any) uint64 {
+	return r.write(id, method, true, err, args...)
+}
+
+// object is embedded by all the recorded types.
+type object struct {
+	r  *recorder
+	id uint64
+}
+
+// MarshalJSON writes the object as its ID.
+func (p *object) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, p.id, 10), nil
+}
+
+type Device struct {
+	object
+	inner api.Device
+
+	queueOnce sync.Once
+	queue     *queue
+}
+
+// NewDevice returns a Device that records the calls made on it, and on the
+// objects it creates, to w.
+func NewDevice(device api.Device, w io.Writer) (*Device, error) {
+	r := &recorder{enc: json.NewEncoder(w), lastID: 1}
+	err := r.enc.Encode(&Header{
+		Version:  Version,
+		Features: device.EnumerateFeatures(),
+		Limits:   device.GetLimits().Limits,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Device{object: object{r: r, id: 1}, inner: device}, nil
+}
+
+// Err returns the first error met while writing the capture, if any.
+func (p *Device) Err() error {
+	p.r.mu.Lock()
+	defer p.r.mu.Unlock()
+	return p.r.err
+}
+
+func (p *Device) unwrap() any { return p.inner }
+
+func (p *Device) CreateBindGroup(descriptor *api.BindGroupDescriptor) (api.BindGroup, error) {
+	inner, err := p.inner.CreateBindGroup(unwrap(descriptor))
+	id := p.r.create(p.id, "CreateBindGroup", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &bindGroup{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateBindGroupLayout(descriptor *api.BindGroupLayoutDescriptor) (api.BindGroupLayout, error) {
+	inner, err := p.inner.CreateBindGroupLayout(unwrap(descriptor))
+	id := p.r.create(p.id, "CreateBindGroupLayout", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &bindGroupLayout{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateBuffer(descriptor *api.BufferDescriptor) (api.Buffer, error) {
+	inner, err := p.inner.CreateBuffer(descriptor)
+	id := p.r.create(p.id, "CreateBuffer", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &buffer{
+		object:   object{p.r, id},
+		inner:    inner,
+		writable: descriptor != nil && descriptor.MappedAtCreation,
+	}, nil
+}
+
+func (p *Device) CreateBufferInit(descriptor *api.BufferInitDescriptor) (api.Buffer, error) {
+	inner, err := p.inner.CreateBufferInit(descriptor)
+	id := p.r.create(p.id, "CreateBufferInit", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &buffer{object: object{p.r, id}, inner: inner}, nil
+}
+
+func (p *Device) CreateCommandEncoder(descriptor *api.CommandEncoderDescriptor) (api.CommandEncoder, error) {
+	inner, err := p.inner.CreateCommandEncoder(descriptor)
+	id := p.r.create(p.id, "CreateCommandEncoder", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &commandEncoder{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateComputePipeline(descriptor *api.ComputePipelineDescriptor) (api.ComputePipeline, error) {
+	inner, err := p.inner.CreateComputePipeline(unwrap(descriptor))
+	id := p.r.create(p.id, "CreateComputePipeline", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &computePipeline{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreatePipelineLayout(descriptor *api.PipelineLayoutDescriptor) (api.PipelineLayout, error) {
+	inner, err := p.inner.CreatePipelineLayout(unwrap(descriptor))
+	id := p.r.create(p.id, "CreatePipelineLayout", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineLayout{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateQuerySet(descriptor *api.QuerySetDescriptor) (api.QuerySet, error) {
+	inner, err := p.inner.CreateQuerySet(descriptor)
+	id := p.r.create(p.id, "CreateQuerySet", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &querySet{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateRenderBundleEncoder(descriptor *api.RenderBundleEncoderDescriptor) (api.RenderBundleEncoder, error) {
+	inner, err := p.inner.CreateRenderBundleEncoder(descriptor)
+	id := p.r.create(p.id, "CreateRenderBundleEncoder", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &renderBundleEncoder{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateRenderPipeline(descriptor *api.RenderPipelineDescriptor) (api.RenderPipeline, error) {
+	inner, err := p.inner.CreateRenderPipeline(unwrap(descriptor))
+	id := p.r.create(p.id, "CreateRenderPipeline", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &renderPipeline{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateSampler(descriptor *api.SamplerDescriptor) (api.Sampler, error) {
+	inner, err := p.inner.CreateSampler(descriptor)
+	id := p.r.create(p.id, "CreateSampler", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &sampler{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateShaderModule(descriptor *api.ShaderModuleDescriptor) (api.ShaderModule, error) {
+	inner, err := p.inner.CreateShaderModule(descriptor)
+	id := p.r.create(p.id, "CreateShaderModule", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &shaderModule{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) CreateTexture(descriptor *api.TextureDescriptor) (api.Texture, error) {
+	inner, err := p.inner.CreateTexture(descriptor)
+	id := p.r.create(p.id, "CreateTexture", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &texture{object{p.r, id}, inner}, nil
+}
+
+func (p *Device) EnumerateFeatures() []api.FeatureName {
+	return p.inner.EnumerateFeatures()
+}
+
+func (p *Device) GetLimits() api.SupportedLimits {
+	return p.inner.GetLimits()
+}
+
+func (p *Device) GetQueue() api.Queue {
+	p.queueOnce.Do(func() {
+		q := p.inner.GetQueue()
+		p.queue = &queue{object{p.r, p.r.create(p.id, "GetQueue", nil)}, q}
+	})
+	return p.queue
+}
+
+func (p *Device) HasFeature(feature api.FeatureName) bool {
+	return p.inner.HasFeature(feature)
+}
+
+func (p *Device) Poll(wait bool, wrappedSubmissionIndex *api.WrappedSubmissionIndex) (queueEmpty bool) {
+	return p.inner.Poll(wait, unwrap(wrappedSubmissionIndex))
+}
+
+func (p *Device) PushErrorScope(filter api.ErrorFilter) {
+	p.inner.PushErrorScope(filter)
+	p.r.call(p.id, "PushErrorScope", nil, filter)
+}
+
+func (p *Device) PopErrorScope() error {
+	err := p.inner.PopErrorScope()
+	p.r.call(p.id, "PopErrorScope", err)
+	return err
+}
+
+func (p *Device) SetUncapturedErrorCallback(callback func(err error)) {
+	p.inner.SetUncapturedErrorCallback(callback)
+}
+
+func (p *Device) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type queue struct {
+	object
+	inner api.Queue
+}
+
+func (p *queue) unwrap() any { return p.inner }
+
+func (p *queue) OnSubmittedWorkDone(callback api.QueueWorkDoneCallback) {
+	p.inner.OnSubmittedWorkDone(callback)
+	p.r.call(p.id, "OnSubmittedWorkDone", nil, nil)
+}
+
+func (p *queue) Submit(commands ...api.CommandBuffer) api.SubmissionIndex {
+	index := p.inner.Submit(unwrap(commands)...)
+	p.r.call(p.id, "Submit", nil, commands)
+	return index
+}
+
+func (p *queue) WriteBuffer(buffer api.Buffer, bufferOffset uint64, data []byte) error {
+	err := p.inner.WriteBuffer(unwrap(buffer), bufferOffset, data)
+	p.r.call(p.id, "WriteBuffer", err, buffer, bufferOffset, data)
+	return err
+}
+
+func (p *queue) WriteTexture(destination *api.ImageCopyTexture, data []byte, dataLayout *api.TextureDataLayout, writeSize *api.Extent3D) error {
+	err := p.inner.WriteTexture(unwrap(destination), data, dataLayout, writeSize)
+	p.r.call(p.id, "WriteTexture", err, destination, data, dataLayout, writeSize)
+	return err
+}
+
+func (p *queue) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+var (
+	_ api.Device = (*Device)(nil)
+	_ api.Queue  = (*queue)(nil)
+)
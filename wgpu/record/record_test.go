@@ -0,0 +1,208 @@
+package record_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+	"github.com/rajveermalviya/go-webgpu/wgpu/fake"
+	"github.com/rajveermalviya/go-webgpu/wgpu/record"
+	"github.com/rajveermalviya/go-webgpu/wgpu/replay"
+)
+
+// collector keeps the buffers created on a device, nil for the creations
+// that failed.
+type collector struct {
+	api.Device
+	buffers []api.Buffer
+}
+
+func (p *collector) CreateBuffer(descriptor *api.BufferDescriptor) (api.Buffer, error) {
+	buffer, err := p.Device.CreateBuffer(descriptor)
+	p.buffers = append(p.buffers, buffer)
+	return buffer, err
+}
+
+func (p *collector) CreateBufferInit(descriptor *api.BufferInitDescriptor) (api.Buffer, error) {
+	buffer, err := p.Device.CreateBufferInit(descriptor)
+	p.buffers = append(p.buffers, buffer)
+	return buffer, err
+}
+
+const shader = `
+@group(0) @binding(0) var<storage, read_write> data: array<u32>;
+
+@compute @workgroup_size(64)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+	data[id.x] = id.x;
+}
+`
+
+// run makes calls on device, some of them failing.
+func run(t *testing.T, device api.Device) {
+	t.Helper()
+	queue := device.GetQueue()
+
+	check := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	fail := func(err error) {
+		t.Helper()
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+
+	src, err := device.CreateBuffer(&api.BufferDescriptor{
+		Usage:            api.BufferUsage_CopySrc | api.BufferUsage_CopyDst | api.BufferUsage_Storage,
+		Size:             256,
+		MappedAtCreation: true,
+	})
+	check(err)
+	copy(src.GetMappedRange(0, 16), "0123456789abcdef")
+	copy(src.GetMappedRange(128, 8), "mapped!!")
+	check(src.Unmap())
+
+	_, err = device.CreateBuffer(&api.BufferDescriptor{Size: 16})
+	fail(err)
+	_, err = device.CreateTexture(&api.TextureDescriptor{Usage: api.TextureUsage_CopyDst})
+	fail(err)
+
+	dst, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_MapRead | api.BufferUsage_CopyDst, Size: 256})
+	check(err)
+	init, err := device.CreateBufferInit(&api.BufferInitDescriptor{
+		Usage:    api.BufferUsage_CopySrc,
+		Contents: []byte("initial contents"),
+	})
+	check(err)
+	check(queue.WriteBuffer(src, 64, []byte("written by queue")))
+	fail(queue.WriteBuffer(src, 3, []byte("misaligned")))
+
+	module, err := device.CreateShaderModule(&api.ShaderModuleDescriptor{
+		WGSLDescriptor: &api.ShaderModuleWGSLDescriptor{Code: shader},
+	})
+	check(err)
+	_, err = device.CreateComputePipeline(&api.ComputePipelineDescriptor{
+		Compute: api.ProgrammableStageDescriptor{Module: module, EntryPoint: "missing"},
+	})
+	fail(err)
+	pipeline, err := device.CreateComputePipeline(&api.ComputePipelineDescriptor{
+		Compute: api.ProgrammableStageDescriptor{Module: module, EntryPoint: "main"},
+	})
+	check(err)
+	bindGroup, err := device.CreateBindGroup(&api.BindGroupDescriptor{
+		Layout:  pipeline.GetBindGroupLayout(0),
+		Entries: []api.BindGroupEntry{{Binding: 0, Buffer: src, Size: api.WholeSize}},
+	})
+	check(err)
+
+	// an encoder that fails to finish.
+	encoder, err := device.CreateCommandEncoder(nil)
+	check(err)
+	fail(encoder.CopyBufferToBuffer(src, 0, dst, 0, 6))
+	_, err = encoder.Finish(nil)
+	fail(err)
+
+	encoder, err = device.CreateCommandEncoder(&api.CommandEncoderDescriptor{Label: "encoder"})
+	check(err)
+	pass := encoder.BeginComputePass(nil)
+	pass.SetPipeline(pipeline)
+	pass.SetBindGroup(0, bindGroup, nil)
+	pass.DispatchWorkgroups(1, 1, 1)
+	check(pass.End())
+	check(encoder.CopyBufferToBuffer(src, 0, dst, 0, 256))
+	check(encoder.CopyBufferToBuffer(init, 0, dst, 192, 16))
+	commands, err := encoder.Finish(nil)
+	check(err)
+	queue.Submit(commands)
+
+	check(dst.MapAsync(api.MapMode_Read, 0, api.WholeSize, func(api.BufferMapAsyncStatus) {}))
+	device.Poll(true, nil)
+	check(dst.Unmap())
+	fail(dst.MapAsync(api.MapMode_Write, 0, api.WholeSize, func(api.BufferMapAsyncStatus) {}))
+	device.Poll(true, nil)
+
+	// a buffer mapped for writing after creation.
+	upload, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_MapWrite | api.BufferUsage_CopySrc, Size: 16})
+	check(err)
+	check(upload.MapAsync(api.MapMode_Write, 0, 16, func(api.BufferMapAsyncStatus) {}))
+	device.Poll(true, nil)
+	copy(upload.GetMappedRange(8, 8), "uploaded")
+	check(upload.Unmap())
+}
+
+func TestRecordReplay(t *testing.T) {
+	recorded := &collector{Device: fake.NewDevice()}
+	var capture bytes.Buffer
+	device, err := record.NewDevice(recorded, &capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(t, device)
+	if err := device.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// record the replay too, to compare the captures.
+	c, err := replay.Open(bytes.NewReader(capture.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := &collector{Device: fake.NewDevice()}
+	var recapture bytes.Buffer
+	device, err = record.NewDevice(replayed, &recapture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Run(device); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(recapture.Bytes(), capture.Bytes()) {
+		t.Errorf("replay recorded\n%s\nexpected\n%s", recapture.Bytes(), capture.Bytes())
+	}
+	if len(replayed.buffers) != len(recorded.buffers) {
+		t.Fatalf("replay created %d buffers, expected %d", len(replayed.buffers), len(recorded.buffers))
+	}
+	for i, b := range recorded.buffers {
+		r := replayed.buffers[i]
+		if b == nil || r == nil {
+			if b != r {
+				t.Errorf("buffer %d: replay created %v, expected %v", i, r, b)
+			}
+			continue
+		}
+		expected, got := b.(*fake.Buffer).Bytes(), r.(*fake.Buffer).Bytes()
+		if !bytes.Equal(got, expected) {
+			t.Errorf("buffer %d: replay wrote %q, expected %q", i, got, expected)
+		}
+	}
+}
+
+func TestReplayUnknownObject(t *testing.T) {
+	var capture bytes.Buffer
+	device, err := record.NewDevice(fake.NewDevice(), &capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := device.CreateBuffer(&api.BufferDescriptor{Size: 16}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := device.CreateBuffer(&api.BufferDescriptor{Usage: api.BufferUsage_CopyDst, Size: 16}); err != nil {
+		t.Fatal(err)
+	}
+
+	// drop the failed creation, so that the IDs don't match.
+	lines := bytes.SplitAfter(capture.Bytes(), []byte("\n"))
+	corrupted := bytes.Join(append(lines[:1:1], append(lines[2:], []byte(`{"object":2,"method":"Destroy"}`+"\n"))...), nil)
+	c, err := replay.Open(bytes.NewReader(corrupted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Run(fake.NewDevice()); err == nil {
+		t.Error("expected an error")
+	}
+}
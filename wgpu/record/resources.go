@@ -0,0 +1,305 @@
+package record
+
+import (
+	"sync"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+)
+
+type buffer struct {
+	object
+	inner api.Buffer
+
+	mu sync.Mutex
+	// writable reports whether the buffer is mapped for writing, in which
+	// case ranges holds the ranges returned by GetMappedRange, recorded on
+	// Unmap.
+	writable bool
+	ranges   []mappedRange
+}
+
+type mappedRange struct {
+	offset uint
+	data   []byte
+}
+
+func (p *buffer) unwrap() any { return p.inner }
+
+func (p *buffer) Destroy() {
+	p.mu.Lock()
+	p.writable = false
+	p.ranges = nil
+	p.mu.Unlock()
+
+	p.inner.Destroy()
+	p.r.call(p.id, "Destroy", nil)
+}
+
+func (p *buffer) GetMappedRange(offset, size uint) []byte {
+	data := p.inner.GetMappedRange(offset, size)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.writable && data != nil {
+		p.ranges = append(p.ranges, mappedRange{offset: offset, data: data})
+	}
+	return data
+}
+
+func (p *buffer) GetSize() uint64 {
+	return p.inner.GetSize()
+}
+
+func (p *buffer) GetUsage() api.BufferUsage {
+	return p.inner.GetUsage()
+}
+
+func (p *buffer) MapAsync(mode api.MapMode, offset uint64, size uint64, callback api.BufferMapCallback) error {
+	err := p.inner.MapAsync(mode, offset, size, callback)
+	p.r.call(p.id, "MapAsync", err, mode, offset, size, nil)
+
+	if err == nil && mode == api.MapMode_Write {
+		p.mu.Lock()
+		p.writable = true
+		p.mu.Unlock()
+	}
+	return err
+}
+
+func (p *buffer) Unmap() error {
+	p.mu.Lock()
+	// the mapped memory is only valid until the buffer is unmapped.
+	for _, r := range p.ranges {
+		p.r.call(p.id, MethodWriteMappedRange, nil, r.offset, r.data)
+	}
+	p.writable = false
+	p.ranges = nil
+	p.mu.Unlock()
+
+	err := p.inner.Unmap()
+	p.r.call(p.id, "Unmap", err)
+	return err
+}
+
+func (p *buffer) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type texture struct {
+	object
+	inner api.Texture
+}
+
+func (p *texture) unwrap() any { return p.inner }
+
+func (p *texture) CreateView(descriptor *api.TextureViewDescriptor) (api.TextureView, error) {
+	inner, err := p.inner.CreateView(descriptor)
+	id := p.r.create(p.id, "CreateView", err, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &textureView{object{p.r, id}, inner}, nil
+}
+
+func (p *texture) Destroy() {
+	p.inner.Destroy()
+	p.r.call(p.id, "Destroy", nil)
+}
+
+func (p *texture) GetDepthOrArrayLayers() uint32 {
+	return p.inner.GetDepthOrArrayLayers()
+}
+
+func (p *texture) GetDimension() api.TextureDimension {
+	return p.inner.GetDimension()
+}
+
+func (p *texture) GetFormat() api.TextureFormat {
+	return p.inner.GetFormat()
+}
+
+func (p *texture) GetHeight() uint32 {
+	return p.inner.GetHeight()
+}
+
+func (p *texture) GetMipLevelCount() uint32 {
+	return p.inner.GetMipLevelCount()
+}
+
+func (p *texture) GetSampleCount() uint32 {
+	return p.inner.GetSampleCount()
+}
+
+func (p *texture) GetUsage() api.TextureUsage {
+	return p.inner.GetUsage()
+}
+
+func (p *texture) GetWidth() uint32 {
+	return p.inner.GetWidth()
+}
+
+func (p *texture) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type textureView struct {
+	object
+	inner api.TextureView
+}
+
+func (p *textureView) unwrap() any { return p.inner }
+
+func (p *textureView) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type sampler struct {
+	object
+	inner api.Sampler
+}
+
+func (p *sampler) unwrap() any { return p.inner }
+
+func (p *sampler) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type querySet struct {
+	object
+	inner api.QuerySet
+}
+
+func (p *querySet) unwrap() any { return p.inner }
+
+func (p *querySet) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type shaderModule struct {
+	object
+	inner api.ShaderModule
+}
+
+func (p *shaderModule) unwrap() any { return p.inner }
+
+func (p *shaderModule) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type bindGroupLayout struct {
+	object
+	inner api.BindGroupLayout
+}
+
+func (p *bindGroupLayout) unwrap() any { return p.inner }
+
+func (p *bindGroupLayout) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type bindGroup struct {
+	object
+	inner api.BindGroup
+}
+
+func (p *bindGroup) unwrap() any { return p.inner }
+
+func (p *bindGroup) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type pipelineLayout struct {
+	object
+	inner api.PipelineLayout
+}
+
+func (p *pipelineLayout) unwrap() any { return p.inner }
+
+func (p *pipelineLayout) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type computePipeline struct {
+	object
+	inner api.ComputePipeline
+}
+
+func (p *computePipeline) unwrap() any { return p.inner }
+
+func (p *computePipeline) GetBindGroupLayout(groupIndex uint32) api.BindGroupLayout {
+	inner := p.inner.GetBindGroupLayout(groupIndex)
+	id := p.r.create(p.id, "GetBindGroupLayout", nil, groupIndex)
+	return &bindGroupLayout{object{p.r, id}, inner}
+}
+
+func (p *computePipeline) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type renderPipeline struct {
+	object
+	inner api.RenderPipeline
+}
+
+func (p *renderPipeline) unwrap() any { return p.inner }
+
+func (p *renderPipeline) GetBindGroupLayout(groupIndex uint32) api.BindGroupLayout {
+	inner := p.inner.GetBindGroupLayout(groupIndex)
+	id := p.r.create(p.id, "GetBindGroupLayout", nil, groupIndex)
+	return &bindGroupLayout{object{p.r, id}, inner}
+}
+
+func (p *renderPipeline) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type commandBuffer struct {
+	object
+	inner api.CommandBuffer
+}
+
+func (p *commandBuffer) unwrap() any { return p.inner }
+
+func (p *commandBuffer) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+type renderBundle struct {
+	object
+	inner api.RenderBundle
+}
+
+func (p *renderBundle) unwrap() any { return p.inner }
+
+func (p *renderBundle) Release() {
+	p.inner.Release()
+	p.r.call(p.id, "Release", nil)
+}
+
+var (
+	_ api.Buffer          = (*buffer)(nil)
+	_ api.Texture         = (*texture)(nil)
+	_ api.TextureView     = (*textureView)(nil)
+	_ api.Sampler         = (*sampler)(nil)
+	_ api.QuerySet        = (*querySet)(nil)
+	_ api.ShaderModule    = (*shaderModule)(nil)
+	_ api.BindGroupLayout = (*bindGroupLayout)(nil)
+	_ api.BindGroup       = (*bindGroup)(nil)
+	_ api.PipelineLayout  = (*pipelineLayout)(nil)
+	_ api.ComputePipeline = (*computePipeline)(nil)
+	_ api.RenderPipeline  = (*renderPipeline)(nil)
+	_ api.CommandBuffer   = (*commandBuffer)(nil)
+	_ api.RenderBundle    = (*renderBundle)(nil)
+)
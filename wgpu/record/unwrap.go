@@ -0,0 +1,94 @@
+package record
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// wrapper is implemented by the recorded types.
+type wrapper interface {
+	unwrap() any
+}
+
+// unwrap returns v with the recorded handles it holds replaced by the
+// handles they wrap. Descriptors are copied, not modified.
+func unwrap[T any](v T) T {
+	rv := reflect.ValueOf(&v).Elem()
+	if !hasHandles(rv.Type()) {
+		return v
+	}
+	var out T
+	reflect.ValueOf(&out).Elem().Set(unwrapValue(rv))
+	return out
+}
+
+// hasHandles reports whether values of type t can hold handles.
+func hasHandles(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return hasHandles(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasHandles(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func unwrapValue(v reflect.Value) reflect.Value {
+	t := v.Type()
+	if !hasHandles(t) {
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		w, ok := v.Elem().Interface().(wrapper)
+		if !ok {
+			panic(fmt.Sprintf("record: %s was not created by package record", t))
+		}
+		out := reflect.New(t).Elem()
+		out.Set(reflect.ValueOf(w.unwrap()))
+		return out
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(t.Elem())
+		out.Elem().Set(unwrapValue(v.Elem()))
+		return out
+
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			out.Field(i).Set(unwrapValue(v.Field(i)))
+		}
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(unwrapValue(v.Index(i)))
+		}
+		return out
+
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(unwrapValue(v.Index(i)))
+		}
+		return out
+	}
+	return v
+}
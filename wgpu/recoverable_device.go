@@ -0,0 +1,749 @@
+package wgpu
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// RecoverableDevice is a device that is recreated, together with the objects
+// created through it, when it is lost.
+//
+// Objects created through a RecoverableDevice keep their Go handles across
+// recoveries, so they don't need to be looked up again. Objects created in
+// any other way, e.g. command encoders or bind group layouts returned by
+// GetBindGroupLayout, belong to the lost device and must be recreated by
+// hand. Descriptors are kept until recovery and must not be modified
+// afterwards.
+type RecoverableDevice struct {
+	adapter    *Adapter
+	descriptor *DeviceDescriptor
+
+	mu       sync.Mutex
+	device   *Device
+	queue    *Queue
+	objects  []*recoverableObject
+	restores []recoverableRestore
+	// pruned is the number of objects after the last prune.
+	pruned   int
+	callback func(err error)
+	closed   bool
+	stop     chan struct{}
+}
+
+type recoverableObject struct {
+	object any
+	// deps are the objects referenced by the descriptor, they are recreated
+	// first even if released.
+	deps     []any
+	released func() bool
+	recreate func(device *Device) error
+	release  func()
+}
+
+type recoverableRestore struct {
+	object   any
+	released func() bool
+	restore  func(queue *Queue) error
+}
+
+// RequestRecoverableDevice requests a device like RequestDevice. When the
+// device is lost, a new one is requested from the adapter with the same
+// descriptor, so the adapter must not be released before the device.
+//
+// Loss is only noticed when the device is polled, e.g. by StartPoller, whose
+// interval is kept by the recovered devices.
+func (p *Adapter) RequestRecoverableDevice(descriptor *DeviceDescriptor) (*RecoverableDevice, error) {
+	p.checkReleased()
+	device, err := p.RequestDevice(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	rd := &RecoverableDevice{
+		adapter:    p,
+		descriptor: copyDescriptor(descriptor),
+		device:     device,
+		stop:       make(chan struct{}),
+	}
+	go rd.watch(device)
+	return rd, nil
+}
+
+// Device returns the current device, which changes on every recovery.
+func (p *RecoverableDevice) Device() *Device {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.device
+}
+
+// GetQueue returns the queue of the device, it is kept across recoveries.
+func (p *RecoverableDevice) GetQueue() *Queue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.queue == nil {
+		p.queue = p.device.GetQueue()
+	}
+	return p.queue
+}
+
+// SetRecoverCallback sets the callback called after each recovery attempt,
+// with the error of the attempt if any. A failed attempt can be retried with
+// Recover.
+func (p *RecoverableDevice) SetRecoverCallback(callback func(err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.callback = callback
+}
+
+// RestoreBuffer registers data to be written to buffer at offset after each
+// recovery. data is kept, not copied.
+func (p *RecoverableDevice) RestoreBuffer(buffer *Buffer, offset uint64, data []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.restores = append(p.restores, recoverableRestore{
+		object:   buffer,
+		released: func() bool { return buffer.child.released.Load() },
+		restore: func(queue *Queue) error {
+			return queue.WriteBuffer(buffer, offset, data)
+		},
+	})
+}
+
+// RestoreTexture registers data to be written to destination after each
+// recovery. data is kept, not copied.
+func (p *RecoverableDevice) RestoreTexture(destination *ImageCopyTexture, data []byte, dataLayout *TextureDataLayout, writeSize *Extent3D) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	dst := *destination
+	layout := *dataLayout
+	size := *writeSize
+	p.restores = append(p.restores, recoverableRestore{
+		object:   dst.Texture,
+		released: func() bool { return dst.Texture.child.released.Load() },
+		restore: func(queue *Queue) error {
+			return queue.WriteTexture(&dst, data, &layout, &size)
+		},
+	})
+}
+
+func (p *RecoverableDevice) watch(device *Device) {
+	select {
+	case <-device.Lost():
+	case <-p.stop:
+		return
+	}
+
+	p.mu.Lock()
+	if p.closed || p.device != device {
+		p.mu.Unlock()
+		return
+	}
+	err := p.recover()
+	callback := p.callback
+	p.mu.Unlock()
+
+	if callback != nil {
+		callback(err)
+	}
+}
+
+// Recover requests a new device and recreates the objects created through
+// the RecoverableDevice on it, then writes the registered restore data. It
+// is called automatically when the device is lost, and can be called by hand
+// to retry a failed recovery.
+func (p *RecoverableDevice) Recover() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return &Error{
+			Type:    ErrorType_Validation,
+			Op:      "wgpu.(*RecoverableDevice).Recover()",
+			Message: "device is released",
+		}
+	}
+	return p.recover()
+}
+
+// recover must be called with mu held.
+func (p *RecoverableDevice) recover() error {
+	old := p.device
+
+	device, err := p.adapter.RequestDevice(p.descriptor)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	if err := copyDeviceState(device, old); err != nil {
+		errs = append(errs, err)
+	}
+
+	// objects released by hand are recreated only when a live object
+	// depends on them, and released again afterwards. Objects depending on
+	// one that failed to be recreated are skipped, they keep the handle of
+	// the lost device.
+	p.prune()
+	failed := map[any]bool{}
+	var released []*recoverableObject
+	for _, v := range p.objects {
+		if v.released() {
+			released = append(released, v)
+		}
+		if dependsOn(v.deps, failed) {
+			failed[v.object] = true
+			errs = append(errs, &Error{
+				Type:    ErrorType_Unknown,
+				Op:      "wgpu.(*RecoverableDevice).Recover()",
+				Message: "an object the descriptor refers to failed to be recreated",
+			})
+			continue
+		}
+		if err := v.recreate(device); err != nil {
+			failed[v.object] = true
+			errs = append(errs, err)
+		}
+	}
+	for _, v := range released {
+		v.release()
+	}
+
+	if p.queue != nil {
+		replaceObject(p.queue, p.queue.child, device.GetQueue())
+	}
+
+	p.device = device
+	old.Release()
+	go p.watch(device)
+
+	if len(p.restores) > 0 {
+		queue := p.queue
+		if queue == nil {
+			queue = device.GetQueue()
+			defer queue.Release()
+		}
+		for _, v := range p.restores {
+			if failed[v.object] {
+				continue
+			}
+			if err := v.restore(queue); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// copyDeviceState applies the poller, uncaptured error callback and
+// validation mode of old to device.
+func copyDeviceState(device *Device, old *Device) error {
+	old.pollerMu.Lock()
+	var interval time.Duration
+	if old.poller != nil {
+		interval = old.poller.interval
+	}
+	old.pollerMu.Unlock()
+	if interval > 0 {
+		device.StartPoller(interval)
+	}
+
+	old.uncapturedErrorMu.Lock()
+	var callback UncapturedErrorCallback
+	if old.uncapturedErrorState != nil {
+		old.uncapturedErrorState.mu.Lock()
+		callback = old.uncapturedErrorState.callback
+		old.uncapturedErrorState.mu.Unlock()
+	}
+	old.uncapturedErrorMu.Unlock()
+	if callback != nil {
+		device.SetUncapturedErrorCallback(callback)
+	}
+
+	if old.deferred.Load() != nil {
+		return device.SetValidationMode(ValidationMode_Deferred)
+	}
+	return nil
+}
+
+// replaceObject makes object use the native handle of with, which was
+// created on another device, and releases the handle of the lost device.
+func replaceObject[T any](object *T, oldChild *deviceChild, with *T) {
+	oldChild.Release()
+	untrackObject(with)
+	*object = *with
+	retrackObject(object)
+}
+
+func copyDescriptor[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+// record must be called with mu held.
+func (p *RecoverableDevice) record(object any, deps []any, released func() bool, recreate func(device *Device) error, release func()) {
+	// released objects are pruned once the list doubled, so that it stays
+	// proportional to the live objects.
+	if len(p.objects) >= 2*p.pruned+16 {
+		p.prune()
+	}
+	p.objects = append(p.objects, &recoverableObject{
+		object:   object,
+		deps:     deps,
+		released: released,
+		recreate: recreate,
+		release:  release,
+	})
+}
+
+// prune drops the objects released by hand that no live object depends on,
+// and the restores of released objects. It must be called with mu held.
+func (p *RecoverableDevice) prune() {
+	needed := make(map[any]bool, len(p.objects))
+	for i := len(p.objects) - 1; i >= 0; i-- {
+		v := p.objects[i]
+		if needed[v.object] || !v.released() {
+			needed[v.object] = true
+			for _, dep := range v.deps {
+				needed[dep] = true
+			}
+		}
+	}
+
+	objects := p.objects[:0]
+	for _, v := range p.objects {
+		if needed[v.object] {
+			objects = append(objects, v)
+		}
+	}
+	for i := len(objects); i < len(p.objects); i++ {
+		p.objects[i] = nil
+	}
+	p.objects = objects
+	p.pruned = len(objects)
+
+	restores := p.restores[:0]
+	for _, v := range p.restores {
+		if !v.released() {
+			restores = append(restores, v)
+		}
+	}
+	for i := len(restores); i < len(p.restores); i++ {
+		p.restores[i] = recoverableRestore{}
+	}
+	p.restores = restores
+}
+
+func dependsOn(deps []any, objects map[any]bool) bool {
+	for _, dep := range deps {
+		if objects[dep] {
+			return true
+		}
+	}
+	return false
+}
+
+// Release stops recovering the device and releases it.
+func (p *RecoverableDevice) Release() {
+	device := p.close()
+	if device != nil {
+		device.Release()
+	}
+}
+
+// Close stops recovering the device and closes it, releasing every object
+// created from it.
+func (p *RecoverableDevice) Close() {
+	device := p.close()
+	if device != nil {
+		device.Close()
+	}
+}
+
+func (p *RecoverableDevice) close() *Device {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	close(p.stop)
+
+	p.objects = nil
+	p.restores = nil
+	return p.device
+}
+
+func (p *RecoverableDevice) CreateBindGroup(descriptor *BindGroupDescriptor) (*BindGroup, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bindGroup, err := p.device.CreateBindGroup(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	var deps []any
+	if desc != nil {
+		if desc.Layout != nil {
+			deps = append(deps, desc.Layout)
+		}
+		for _, v := range desc.Entries {
+			if v.Buffer != nil {
+				deps = append(deps, v.Buffer)
+			}
+			if v.Sampler != nil {
+				deps = append(deps, v.Sampler)
+			}
+			if v.TextureView != nil {
+				deps = append(deps, v.TextureView)
+			}
+		}
+	}
+	p.record(bindGroup, deps,
+		func() bool { return bindGroup.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateBindGroup(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(bindGroup, bindGroup.child, v)
+			return nil
+		},
+		bindGroup.Release,
+	)
+	return bindGroup, nil
+}
+
+func (p *RecoverableDevice) CreateBindGroupLayout(descriptor *BindGroupLayoutDescriptor) (*BindGroupLayout, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bindGroupLayout, err := p.device.CreateBindGroupLayout(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	p.record(bindGroupLayout, nil,
+		func() bool { return bindGroupLayout.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateBindGroupLayout(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(bindGroupLayout, bindGroupLayout.child, v)
+			return nil
+		},
+		bindGroupLayout.Release,
+	)
+	return bindGroupLayout, nil
+}
+
+func (p *RecoverableDevice) CreateBuffer(descriptor *BufferDescriptor) (*Buffer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	buffer, err := p.device.CreateBuffer(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	if desc != nil {
+		// content written while mapped is lost, use RestoreBuffer to
+		// restore it.
+		desc.MappedAtCreation = false
+	}
+	p.record(buffer, nil,
+		func() bool { return buffer.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateBuffer(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(buffer, buffer.child, v)
+			return nil
+		},
+		buffer.Release,
+	)
+	return buffer, nil
+}
+
+// CreateBufferInit is like Device.CreateBufferInit, the contents are
+// restored after each recovery.
+func (p *RecoverableDevice) CreateBufferInit(descriptor *BufferInitDescriptor) (*Buffer, error) {
+	if descriptor == nil {
+		panic("got nil descriptor")
+	}
+
+	unpaddedSize := len(descriptor.Contents)
+	const alignMask = CopyBufferAlignment - 1
+	paddedSize := ((unpaddedSize + alignMask) & ^alignMask)
+
+	buffer, err := p.CreateBuffer(&BufferDescriptor{
+		Label:            descriptor.Label,
+		Size:             uint64(paddedSize),
+		Usage:            descriptor.Usage,
+		MappedAtCreation: paddedSize > 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if paddedSize == 0 {
+		return buffer, nil
+	}
+
+	buf := buffer.GetMappedRange(0, uint(paddedSize))
+	copy(buf, descriptor.Contents)
+	buffer.Unmap()
+
+	if aligned := unpaddedSize &^ alignMask; aligned > 0 {
+		p.RestoreBuffer(buffer, 0, descriptor.Contents[:aligned])
+	}
+	if tail := unpaddedSize & alignMask; tail != 0 {
+		padded := make([]byte, CopyBufferAlignment)
+		copy(padded, descriptor.Contents[unpaddedSize-tail:])
+		p.RestoreBuffer(buffer, uint64(unpaddedSize-tail), padded)
+	}
+	return buffer, nil
+}
+
+func (p *RecoverableDevice) CreateComputePipeline(descriptor *ComputePipelineDescriptor) (*ComputePipeline, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pipeline, err := p.device.CreateComputePipeline(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	var deps []any
+	if desc != nil {
+		if desc.Layout != nil {
+			deps = append(deps, desc.Layout)
+		}
+		if desc.Compute.Module != nil {
+			deps = append(deps, desc.Compute.Module)
+		}
+	}
+	p.record(pipeline, deps,
+		func() bool { return pipeline.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateComputePipeline(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(pipeline, pipeline.child, v)
+			return nil
+		},
+		pipeline.Release,
+	)
+	return pipeline, nil
+}
+
+func (p *RecoverableDevice) CreatePipelineLayout(descriptor *PipelineLayoutDescriptor) (*PipelineLayout, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pipelineLayout, err := p.device.CreatePipelineLayout(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	var deps []any
+	if desc != nil {
+		for _, v := range desc.BindGroupLayouts {
+			deps = append(deps, v)
+		}
+	}
+	p.record(pipelineLayout, deps,
+		func() bool { return pipelineLayout.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreatePipelineLayout(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(pipelineLayout, pipelineLayout.child, v)
+			return nil
+		},
+		pipelineLayout.Release,
+	)
+	return pipelineLayout, nil
+}
+
+func (p *RecoverableDevice) CreateQuerySet(descriptor *QuerySetDescriptor) (*QuerySet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	querySet, err := p.device.CreateQuerySet(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	p.record(querySet, nil,
+		func() bool { return querySet.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateQuerySet(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(querySet, querySet.child, v)
+			return nil
+		},
+		querySet.Release,
+	)
+	return querySet, nil
+}
+
+func (p *RecoverableDevice) CreateRenderPipeline(descriptor *RenderPipelineDescriptor) (*RenderPipeline, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pipeline, err := p.device.CreateRenderPipeline(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	var deps []any
+	if desc != nil {
+		if desc.Layout != nil {
+			deps = append(deps, desc.Layout)
+		}
+		if desc.Vertex.Module != nil {
+			deps = append(deps, desc.Vertex.Module)
+		}
+		if desc.Fragment != nil && desc.Fragment.Module != nil {
+			deps = append(deps, desc.Fragment.Module)
+		}
+	}
+	p.record(pipeline, deps,
+		func() bool { return pipeline.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateRenderPipeline(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(pipeline, pipeline.child, v)
+			return nil
+		},
+		pipeline.Release,
+	)
+	return pipeline, nil
+}
+
+func (p *RecoverableDevice) CreateSampler(descriptor *SamplerDescriptor) (*Sampler, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sampler, err := p.device.CreateSampler(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	p.record(sampler, nil,
+		func() bool { return sampler.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateSampler(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(sampler, sampler.child, v)
+			return nil
+		},
+		sampler.Release,
+	)
+	return sampler, nil
+}
+
+func (p *RecoverableDevice) CreateShaderModule(descriptor *ShaderModuleDescriptor) (*ShaderModule, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	shaderModule, err := p.device.CreateShaderModule(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	p.record(shaderModule, nil,
+		func() bool { return shaderModule.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateShaderModule(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(shaderModule, shaderModule.child, v)
+			return nil
+		},
+		shaderModule.Release,
+	)
+	return shaderModule, nil
+}
+
+func (p *RecoverableDevice) CreateTexture(descriptor *TextureDescriptor) (*Texture, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	texture, err := p.device.CreateTexture(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	p.record(texture, nil,
+		func() bool { return texture.child.released.Load() },
+		func(device *Device) error {
+			v, err := device.CreateTexture(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(texture, texture.child, v)
+			return nil
+		},
+		texture.Release,
+	)
+	return texture, nil
+}
+
+// CreateTextureView is like Texture.CreateView, for a texture created
+// through the RecoverableDevice.
+func (p *RecoverableDevice) CreateTextureView(texture *Texture, descriptor *TextureViewDescriptor) (*TextureView, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	textureView, err := texture.CreateView(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := copyDescriptor(descriptor)
+	p.record(textureView, []any{texture},
+		func() bool { return textureView.child.released.Load() },
+		func(*Device) error {
+			v, err := texture.CreateView(desc)
+			if err != nil {
+				return err
+			}
+			replaceObject(textureView, textureView.child, v)
+			return nil
+		},
+		textureView.Release,
+	)
+	return textureView, nil
+}
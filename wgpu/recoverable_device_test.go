@@ -0,0 +1,117 @@
+package wgpu
+
+import "testing"
+
+// testObject is an object recorded by a RecoverableDevice in tests.
+type testObject struct {
+	released bool
+}
+
+func recordTestObject(p *RecoverableDevice, deps ...any) *testObject {
+	o := &testObject{}
+	p.record(o, deps,
+		func() bool { return o.released },
+		func(*Device) error { return nil },
+		func() {},
+	)
+	return o
+}
+
+func TestRecoverableDevicePrune(t *testing.T) {
+	p := &RecoverableDevice{}
+
+	// a released layout that a live pipeline depends on is kept.
+	layout := recordTestObject(p)
+	pipeline := recordTestObject(p, layout)
+	layout.released = true
+	for i := 0; i < 1000; i++ {
+		recordTestObject(p).released = true
+	}
+	if n := len(p.objects); n > 64 {
+		t.Errorf("got %d objects after releasing them, expected at most 64", n)
+	}
+	p.prune()
+	if len(p.objects) != 2 || p.objects[0].object != layout || p.objects[1].object != pipeline {
+		t.Errorf("got %d objects, expected the layout and the pipeline", len(p.objects))
+	}
+
+	pipeline.released = true
+	p.prune()
+	if len(p.objects) != 0 {
+		t.Errorf("got %d objects, expected none", len(p.objects))
+	}
+}
+
+func TestRecoverableDevicePruneRestores(t *testing.T) {
+	p := &RecoverableDevice{}
+	live, released := &testObject{}, &testObject{released: true}
+	for _, o := range []*testObject{live, released} {
+		o := o
+		p.restores = append(p.restores, recoverableRestore{
+			object:   o,
+			released: func() bool { return o.released },
+			restore:  func(*Queue) error { return nil },
+		})
+	}
+	p.prune()
+	if len(p.restores) != 1 || p.restores[0].object != live {
+		t.Errorf("got %d restores, expected the one of the live object", len(p.restores))
+	}
+}
+
+func TestRecoverableDeviceFailedDependency(t *testing.T) {
+	instance := CreateInstance(nil)
+	defer instance.Release()
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		t.Skipf("no adapter: %v", err)
+	}
+	defer adapter.Release()
+	p, err := adapter.RequestRecoverableDevice(nil)
+	if err != nil {
+		t.Skipf("no device: %v", err)
+	}
+	defer p.Release()
+
+	var recreated []string
+	record := func(name string, fail bool, deps ...any) *testObject {
+		o := &testObject{}
+		p.record(o, deps,
+			func() bool { return o.released },
+			func(*Device) error {
+				recreated = append(recreated, name)
+				if fail {
+					return &Error{Type: ErrorType_Validation, Message: name}
+				}
+				return nil
+			},
+			func() {},
+		)
+		return o
+	}
+	layout := record("layout", true)
+	module := record("module", false)
+	pipeline := record("pipeline", false, layout, module)
+	record("bundle", false, pipeline)
+	record("texture", false)
+
+	restored := false
+	p.restores = append(p.restores, recoverableRestore{
+		object:   pipeline,
+		released: func() bool { return false },
+		restore:  func(*Queue) error { restored = true; return nil },
+	})
+
+	p.mu.Lock()
+	err = p.recover()
+	p.mu.Unlock()
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if len(recreated) != 3 || recreated[0] != "layout" || recreated[1] != "module" || recreated[2] != "texture" {
+		t.Errorf("recreated %v, expected the layout, the module and the texture", recreated)
+	}
+	if restored {
+		t.Error("restored the pipeline that failed to be recreated")
+	}
+}
@@ -0,0 +1,166 @@
+package wgpu
+
+// ReleasedError is the panic value when a released object is used.
+type ReleasedError struct {
+	// Type is the name of the released type, e.g. "Buffer".
+	Type  string
+	Label string
+}
+
+func (v *ReleasedError) Error() string {
+	s := "use of released wgpu." + v.Type
+	if v.Label != "" {
+		s += " '" + v.Label + "'"
+	}
+	return s
+}
+
+func (p *Device) checkReleased() {
+	if p.released.Load() {
+		panic(&ReleasedError{Type: "Device", Label: p.label})
+	}
+}
+
+func (p *Adapter) checkReleased() {
+	if p.ref == nil {
+		panic(&ReleasedError{Type: "Adapter"})
+	}
+}
+
+func (p *BindGroup) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "BindGroup", Label: p.label})
+	}
+}
+
+func (p *BindGroupLayout) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "BindGroupLayout", Label: p.label})
+	}
+}
+
+func (p *Buffer) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "Buffer", Label: p.label})
+	}
+}
+
+func (p *CommandBuffer) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "CommandBuffer", Label: p.label})
+	}
+}
+
+func (p *CommandEncoder) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "CommandEncoder", Label: p.label})
+	}
+}
+
+func (p *ComputePassEncoder) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "ComputePassEncoder", Label: p.label})
+	}
+}
+
+func (p *ComputePipeline) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "ComputePipeline", Label: p.label})
+	}
+}
+
+func (p *Instance) checkReleased() {
+	if p.ref == nil {
+		panic(&ReleasedError{Type: "Instance"})
+	}
+}
+
+func (p *PipelineLayout) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "PipelineLayout", Label: p.label})
+	}
+}
+
+func (p *QuerySet) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "QuerySet", Label: p.label})
+	}
+}
+
+func (p *Queue) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "Queue"})
+	}
+}
+
+func (p *RenderBundle) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "RenderBundle", Label: p.label})
+	}
+}
+
+func (p *RenderBundleEncoder) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "RenderBundleEncoder", Label: p.label})
+	}
+}
+
+func (p *RenderPassEncoder) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "RenderPassEncoder", Label: p.label})
+	}
+}
+
+func (p *RenderPipeline) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "RenderPipeline", Label: p.label})
+	}
+}
+
+func (p *Sampler) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "Sampler", Label: p.label})
+	}
+}
+
+func (p *ShaderModule) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "ShaderModule", Label: p.label})
+	}
+}
+
+func (p *StagingBelt) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "StagingBelt", Label: p.label})
+	}
+}
+
+func (p *Surface) checkReleased() {
+	if p.ref == nil {
+		panic(&ReleasedError{Type: "Surface", Label: p.label})
+	}
+}
+
+func (p *SwapChain) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "SwapChain"})
+	}
+}
+
+func (p *Texture) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "Texture", Label: p.label})
+	}
+}
+
+func (p *TextureView) checkReleased() {
+	if p.ref == nil || p.child.released.Load() {
+		panic(&ReleasedError{Type: "TextureView", Label: p.label})
+	}
+}
+
+func (p *UniformRing) checkReleased() {
+	if p.released {
+		panic(&ReleasedError{Type: "UniformRing", Label: p.label})
+	}
+}
@@ -10,10 +10,17 @@ import "C"
 import "unsafe"
 
 type RenderBundleEncoder struct {
-	ref C.WGPURenderBundleEncoder
+	device *Device
+	ref    C.WGPURenderBundleEncoder
+	label  string
+	child  *deviceChild
 }
 
 func (p *RenderBundleEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderDraw(
 		p.ref,
 		C.uint32_t(vertexCount),
@@ -24,6 +31,10 @@ func (p *RenderBundleEncoder) Draw(vertexCount, instanceCount, firstVertex, firs
 }
 
 func (p *RenderBundleEncoder) DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderDrawIndexed(
 		p.ref,
 		C.uint32_t(indexCount),
@@ -35,6 +46,11 @@ func (p *RenderBundleEncoder) DrawIndexed(indexCount, instanceCount, firstIndex,
 }
 
 func (p *RenderBundleEncoder) DrawIndexedIndirect(indirectBuffer *Buffer, indirectOffset uint64) {
+	p.checkReleased()
+	indirectBuffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderDrawIndexedIndirect(
 		p.ref,
 		indirectBuffer.ref,
@@ -43,6 +59,11 @@ func (p *RenderBundleEncoder) DrawIndexedIndirect(indirectBuffer *Buffer, indire
 }
 
 func (p *RenderBundleEncoder) DrawIndirect(indirectBuffer *Buffer, indirectOffset uint64) {
+	p.checkReleased()
+	indirectBuffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderDrawIndirect(
 		p.ref,
 		indirectBuffer.ref,
@@ -55,6 +76,7 @@ type RenderBundleDescriptor struct {
 }
 
 func (p *RenderBundleEncoder) Finish(descriptor *RenderBundleDescriptor) *RenderBundle {
+	p.checkReleased()
 	var desc *C.WGPURenderBundleDescriptor
 
 	if descriptor != nil {
@@ -70,10 +92,19 @@ func (p *RenderBundleEncoder) Finish(descriptor *RenderBundleDescriptor) *Render
 	if ref == nil {
 		panic("Failed to accquire RenderBundle")
 	}
-	return &RenderBundle{ref}
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	child := p.device.addChild(deviceChildOrderCommand, func(*Device) { C.wgpuRenderBundleRelease(ref) })
+	return trackObject(&RenderBundle{ref: ref, label: label, child: child}, label, (*RenderBundle).Release)
 }
 
 func (p *RenderBundleEncoder) InsertDebugMarker(markerLabel string) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	markerLabelStr := C.CString(markerLabel)
 	defer C.free(unsafe.Pointer(markerLabelStr))
 
@@ -81,10 +112,18 @@ func (p *RenderBundleEncoder) InsertDebugMarker(markerLabel string) {
 }
 
 func (p *RenderBundleEncoder) PopDebugGroup() {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderPopDebugGroup(p.ref)
 }
 
 func (p *RenderBundleEncoder) PushDebugGroup(groupLabel string) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	groupLabelStr := C.CString(groupLabel)
 	defer C.free(unsafe.Pointer(groupLabelStr))
 
@@ -92,6 +131,11 @@ func (p *RenderBundleEncoder) PushDebugGroup(groupLabel string) {
 }
 
 func (p *RenderBundleEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, dynamicOffsets []uint32) {
+	p.checkReleased()
+	group.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	dynamicOffsetCount := len(dynamicOffsets)
 	if dynamicOffsetCount == 0 {
 		C.wgpuRenderBundleEncoderSetBindGroup(p.ref, C.uint32_t(groupIndex), group.ref, 0, nil)
@@ -104,6 +148,11 @@ func (p *RenderBundleEncoder) SetBindGroup(groupIndex uint32, group *BindGroup,
 }
 
 func (p *RenderBundleEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, offset uint64, size uint64) {
+	p.checkReleased()
+	buffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderSetIndexBuffer(
 		p.ref,
 		buffer.ref,
@@ -114,10 +163,20 @@ func (p *RenderBundleEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat,
 }
 
 func (p *RenderBundleEncoder) SetPipeline(pipeline *RenderPipeline) {
+	p.checkReleased()
+	pipeline.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderSetPipeline(p.ref, pipeline.ref)
 }
 
 func (p *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset uint64, size uint64) {
+	p.checkReleased()
+	buffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderBundleEncoderSetVertexBuffer(
 		p.ref,
 		C.uint32_t(slot),
@@ -128,5 +187,10 @@ func (p *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offse
 }
 
 func (p *RenderBundleEncoder) Release() {
-	C.wgpuRenderBundleEncoderRelease(p.ref)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
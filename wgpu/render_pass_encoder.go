@@ -8,38 +8,51 @@ package wgpu
 extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message, void * userdata);
 
 static inline void gowebgpu_render_pass_encoder_end(WGPURenderPassEncoder renderPassEncoder, WGPUDevice device, void * error_userdata) {
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	wgpuRenderPassEncoderEnd(renderPassEncoder);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
-}
-
-static inline void gowebgpu_render_pass_encoder_release(WGPURenderPassEncoder renderPassEncoder, WGPUDevice device) {
-	wgpuDeviceRelease(device);
-	wgpuRenderPassEncoderRelease(renderPassEncoder);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 }
 
 */
 import "C"
 import (
-	"errors"
 	"runtime/cgo"
 	"unsafe"
 )
 
 type RenderPassEncoder struct {
-	deviceRef C.WGPUDevice
-	ref       C.WGPURenderPassEncoder
+	device *Device
+	ref    C.WGPURenderPassEncoder
+	label  string
+	child  *deviceChild
 }
 
 func (p *RenderPassEncoder) BeginOcclusionQuery(queryIndex uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderBeginOcclusionQuery(p.ref, C.uint32_t(queryIndex))
 }
 
 func (p *RenderPassEncoder) BeginPipelineStatisticsQuery(querySet *QuerySet, queryIndex uint32) {
+	p.checkReleased()
+	querySet.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderBeginPipelineStatisticsQuery(p.ref, querySet.ref, C.uint32_t(queryIndex))
 }
 
 func (p *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderDraw(p.ref,
 		C.uint32_t(vertexCount),
 		C.uint32_t(instanceCount),
@@ -49,6 +62,10 @@ func (p *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstI
 }
 
 func (p *RenderPassEncoder) DrawIndexed(indexCount uint32, instanceCount uint32, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderDrawIndexed(p.ref,
 		C.uint32_t(indexCount),
 		C.uint32_t(instanceCount),
@@ -59,37 +76,70 @@ func (p *RenderPassEncoder) DrawIndexed(indexCount uint32, instanceCount uint32,
 }
 
 func (p *RenderPassEncoder) DrawIndexedIndirect(indirectBuffer *Buffer, indirectOffset uint64) {
+	p.checkReleased()
+	indirectBuffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderDrawIndexedIndirect(p.ref, indirectBuffer.ref, C.uint64_t(indirectOffset))
 }
 
 func (p *RenderPassEncoder) DrawIndirect(indirectBuffer *Buffer, indirectOffset uint64) {
+	p.checkReleased()
+	indirectBuffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderDrawIndirect(p.ref, indirectBuffer.ref, C.uint64_t(indirectOffset))
 }
 
 func (p *RenderPassEncoder) End() (err error) {
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*RenderPassEncoder).End(): " + message)
+	p.checkReleased()
+	if err := p.device.lostError("wgpu.(*RenderPassEncoder).End()", p.label); err != nil {
+		return err
+	}
+
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*RenderPassEncoder).End()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*RenderPassEncoder).End()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	C.gowebgpu_render_pass_encoder_end(
 		p.ref,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	return
 }
 
 func (p *RenderPassEncoder) EndOcclusionQuery() {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderEndOcclusionQuery(p.ref)
 }
 
 func (p *RenderPassEncoder) EndPipelineStatisticsQuery() {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderEndPipelineStatisticsQuery(p.ref)
 }
 
 func (p *RenderPassEncoder) ExecuteBundles(bundles ...*RenderBundle) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	bundlesCount := len(bundles)
 	if bundlesCount == 0 {
 		C.wgpuRenderPassEncoderExecuteBundles(p.ref, 0, nil)
@@ -101,6 +151,7 @@ func (p *RenderPassEncoder) ExecuteBundles(bundles ...*RenderBundle) {
 
 	bundlesSlice := unsafe.Slice((*C.WGPURenderBundle)(bundlesPtr), bundlesCount)
 	for i, v := range bundles {
+		v.checkReleased()
 		bundlesSlice[i] = v.ref
 	}
 
@@ -108,6 +159,10 @@ func (p *RenderPassEncoder) ExecuteBundles(bundles ...*RenderBundle) {
 }
 
 func (p *RenderPassEncoder) InsertDebugMarker(markerLabel string) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	markerLabelStr := C.CString(markerLabel)
 	defer C.free(unsafe.Pointer(markerLabelStr))
 
@@ -115,10 +170,18 @@ func (p *RenderPassEncoder) InsertDebugMarker(markerLabel string) {
 }
 
 func (p *RenderPassEncoder) PopDebugGroup() {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderPopDebugGroup(p.ref)
 }
 
 func (p *RenderPassEncoder) PushDebugGroup(groupLabel string) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	groupLabelStr := C.CString(groupLabel)
 	defer C.free(unsafe.Pointer(groupLabelStr))
 
@@ -126,6 +189,11 @@ func (p *RenderPassEncoder) PushDebugGroup(groupLabel string) {
 }
 
 func (p *RenderPassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, dynamicOffsets []uint32) {
+	p.checkReleased()
+	group.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	dynamicOffsetCount := len(dynamicOffsets)
 	if dynamicOffsetCount == 0 {
 		C.wgpuRenderPassEncoderSetBindGroup(
@@ -147,6 +215,10 @@ func (p *RenderPassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, dy
 }
 
 func (p *RenderPassEncoder) SetBlendConstant(color *Color) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	c := C.WGPUColor{
 		r: C.double(color.R),
 		g: C.double(color.G),
@@ -157,6 +229,11 @@ func (p *RenderPassEncoder) SetBlendConstant(color *Color) {
 }
 
 func (p *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, offset uint64, size uint64) {
+	p.checkReleased()
+	buffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderSetIndexBuffer(
 		p.ref,
 		buffer.ref,
@@ -167,10 +244,19 @@ func (p *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, o
 }
 
 func (p *RenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
+	p.checkReleased()
+	pipeline.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderSetPipeline(p.ref, pipeline.ref)
 }
 
 func (p *RenderPassEncoder) SetScissorRect(x, y, width, height uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderSetScissorRect(
 		p.ref,
 		C.uint32_t(x),
@@ -181,10 +267,19 @@ func (p *RenderPassEncoder) SetScissorRect(x, y, width, height uint32) {
 }
 
 func (p *RenderPassEncoder) SetStencilReference(reference uint32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderSetStencilReference(p.ref, C.uint32_t(reference))
 }
 
 func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset uint64, size uint64) {
+	p.checkReleased()
+	buffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderSetVertexBuffer(
 		p.ref,
 		C.uint32_t(slot),
@@ -195,6 +290,10 @@ func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset
 }
 
 func (p *RenderPassEncoder) SetViewport(x, y, width, height, minDepth, maxDepth float32) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderSetViewport(
 		p.ref,
 		C.float(x),
@@ -207,6 +306,10 @@ func (p *RenderPassEncoder) SetViewport(x, y, width, height, minDepth, maxDepth
 }
 
 func (p *RenderPassEncoder) SetPushConstants(stages ShaderStage, offset uint32, data []byte) {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	size := len(data)
 	if size == 0 {
 		C.wgpuRenderPassEncoderSetPushConstants(
@@ -229,6 +332,12 @@ func (p *RenderPassEncoder) SetPushConstants(stages ShaderStage, offset uint32,
 }
 
 func (p *RenderPassEncoder) MultiDrawIndirect(encoder *RenderPassEncoder, buffer Buffer, offset uint64, count uint32) {
+	p.checkReleased()
+	encoder.checkReleased()
+	buffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderMultiDrawIndirect(
 		encoder.ref,
 		buffer.ref,
@@ -238,6 +347,12 @@ func (p *RenderPassEncoder) MultiDrawIndirect(encoder *RenderPassEncoder, buffer
 }
 
 func (p *RenderPassEncoder) MultiDrawIndexedIndirect(encoder *RenderPassEncoder, buffer Buffer, offset uint64, count uint32) {
+	p.checkReleased()
+	encoder.checkReleased()
+	buffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderMultiDrawIndexedIndirect(
 		encoder.ref,
 		buffer.ref,
@@ -247,6 +362,13 @@ func (p *RenderPassEncoder) MultiDrawIndexedIndirect(encoder *RenderPassEncoder,
 }
 
 func (p *RenderPassEncoder) MultiDrawIndirectCount(encoder *RenderPassEncoder, buffer Buffer, offset uint64, countBuffer Buffer, countBufferOffset uint64, maxCount uint32) {
+	p.checkReleased()
+	encoder.checkReleased()
+	buffer.checkReleased()
+	countBuffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderMultiDrawIndirectCount(
 		encoder.ref,
 		buffer.ref,
@@ -258,6 +380,13 @@ func (p *RenderPassEncoder) MultiDrawIndirectCount(encoder *RenderPassEncoder, b
 }
 
 func (p *RenderPassEncoder) MultiDrawIndexedIndirectCount(encoder *RenderPassEncoder, buffer Buffer, offset uint64, countBuffer Buffer, countBufferOffset uint64, maxCount uint32) {
+	p.checkReleased()
+	encoder.checkReleased()
+	buffer.checkReleased()
+	countBuffer.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuRenderPassEncoderMultiDrawIndexedIndirectCount(
 		encoder.ref,
 		buffer.ref,
@@ -269,5 +398,10 @@ func (p *RenderPassEncoder) MultiDrawIndexedIndirectCount(encoder *RenderPassEnc
 }
 
 func (p *RenderPassEncoder) Release() {
-	C.gowebgpu_render_pass_encoder_release(p.ref, p.deviceRef)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
@@ -9,18 +9,28 @@ package wgpu
 import "C"
 
 type RenderPipeline struct {
-	ref C.WGPURenderPipeline
+	device *Device
+	ref    C.WGPURenderPipeline
+	label  string
+	child  *deviceChild
 }
 
 func (p *RenderPipeline) GetBindGroupLayout(groupIndex uint32) *BindGroupLayout {
+	p.checkReleased()
 	ref := C.wgpuRenderPipelineGetBindGroupLayout(p.ref, C.uint32_t(groupIndex))
 	if ref == nil {
 		panic("Failed to accquire BindGroupLayout")
 	}
 
-	return &BindGroupLayout{ref}
+	child := p.device.addChild(deviceChildOrderBindGroupLayout, func(*Device) { C.wgpuBindGroupLayoutRelease(ref) })
+	return trackObject(&BindGroupLayout{ref: ref, child: child}, "", (*BindGroupLayout).Release)
 }
 
 func (p *RenderPipeline) Release() {
-	C.wgpuRenderPipelineRelease(p.ref)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
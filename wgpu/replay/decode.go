@@ -0,0 +1,103 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// decode decodes raw as a value of type t, resolving the handles it holds.
+// Callbacks are replaced by functions doing nothing.
+func (s *state) decode(raw json.RawMessage, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if t.Kind() == reflect.Func {
+		v.Set(reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value { return nil }))
+		return v, nil
+	}
+	return v, s.decodeInto(raw, v)
+}
+
+// hasHandles reports whether values of type t can hold handles.
+func hasHandles(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return hasHandles(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasHandles(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *state) decodeInto(raw json.RawMessage, v reflect.Value) error {
+	t := v.Type()
+	if !hasHandles(t) {
+		return json.Unmarshal(raw, v.Addr().Interface())
+	}
+	if string(raw) == "null" {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		var id uint64
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return err
+		}
+		obj, ok := s.objects[id]
+		if !ok {
+			return fmt.Errorf("unknown object %d", id)
+		}
+		if obj.IsNil() {
+			return nil
+		}
+		if !obj.Elem().Type().Implements(t) {
+			return fmt.Errorf("object %d is a %s, not a %s", id, obj.Type(), t)
+		}
+		v.Set(obj.Elem())
+
+	case reflect.Pointer:
+		v.Set(reflect.New(t.Elem()))
+		return s.decodeInto(raw, v.Elem())
+
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f, ok := fields[t.Field(i).Name]
+			if !ok {
+				continue
+			}
+			if err := s.decodeInto(f, v.Field(i)); err != nil {
+				return fmt.Errorf("%s: %w", t.Field(i).Name, err)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return err
+		}
+		if t.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(t, len(elems), len(elems)))
+		} else if len(elems) != t.Len() {
+			return fmt.Errorf("got %d elements, want %d", len(elems), t.Len())
+		}
+		for i, elem := range elems {
+			if err := s.decodeInto(elem, v.Index(i)); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return json.Unmarshal(raw, v.Addr().Interface())
+	}
+	return nil
+}
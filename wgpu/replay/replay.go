@@ -0,0 +1,207 @@
+// Package replay runs captures written by package record against a device.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu/api"
+	"github.com/rajveermalviya/go-webgpu/wgpu/record"
+)
+
+// Capture is a capture being read.
+type Capture struct {
+	Header record.Header
+
+	dec *json.Decoder
+}
+
+// Open reads the header of the capture read from r.
+func Open(r io.Reader) (*Capture, error) {
+	dec := json.NewDecoder(r)
+	var header record.Header
+	if err := dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("replay: reading header: %w", err)
+	}
+	if header.Version != record.Version {
+		return nil, fmt.Errorf("replay: unsupported capture version %d", header.Version)
+	}
+	return &Capture{Header: header, dec: dec}, nil
+}
+
+// Run executes the recorded calls on device. Calls that failed when they were
+// recorded may fail again, other errors stop the replay.
+//
+// The device must have the features listed in the header, and limits at
+// least as high as the recorded ones for the capture to replay faithfully.
+func (p *Capture) Run(device api.Device) error {
+	for _, f := range p.Header.Features {
+		if !device.HasFeature(f) {
+			return fmt.Errorf("replay: device doesn't have feature %s", f)
+		}
+	}
+
+	s := &state{
+		device:  device,
+		objects: map[uint64]reflect.Value{1: reflect.ValueOf(&device).Elem()},
+		maps:    map[uint64]*bool{},
+	}
+	for n := 0; ; n++ {
+		var call record.Call
+		if err := p.dec.Decode(&call); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("replay: reading call %d: %w", n, err)
+		}
+		if err := s.run(&call); err != nil {
+			return fmt.Errorf("replay: call %d (%s): %w", n, call.Method, err)
+		}
+	}
+}
+
+type state struct {
+	device api.Device
+	// objects maps the IDs of the recorded objects to the objects created
+	// by the replay, as values of the api interface types. Objects that
+	// failed to be created are nil.
+	objects map[uint64]reflect.Value
+	// maps holds whether the pending mappings of buffers are done.
+	maps map[uint64]*bool
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func (s *state) run(call *record.Call) error {
+	obj, ok := s.objects[call.Object]
+	if !ok {
+		return fmt.Errorf("unknown object %d", call.Object)
+	}
+	if obj.IsNil() {
+		return nil
+	}
+
+	if buffer, ok := obj.Interface().(api.Buffer); ok {
+		switch call.Method {
+		case "MapAsync":
+			return s.mapAsync(call, buffer)
+		case record.MethodWriteMappedRange:
+			return s.writeMappedRange(call, buffer)
+		case "Unmap", "Destroy":
+			s.waitMap(call.Object)
+		}
+	}
+
+	m := obj.MethodByName(call.Method)
+	if !m.IsValid() {
+		return fmt.Errorf("unknown method %s of %s", call.Method, obj.Type())
+	}
+	t := m.Type()
+	if len(call.Args) != t.NumIn() {
+		return fmt.Errorf("got %d arguments, want %d", len(call.Args), t.NumIn())
+	}
+	args := make([]reflect.Value, t.NumIn())
+	for i := range args {
+		v, err := s.decode(call.Args[i], t.In(i))
+		if err != nil {
+			return fmt.Errorf("argument %d: %w", i, err)
+		}
+		args[i] = v
+	}
+
+	var results []reflect.Value
+	if t.IsVariadic() {
+		results = m.CallSlice(args)
+	} else {
+		results = m.Call(args)
+	}
+
+	var err error
+	created := reflect.Zero(reflect.TypeOf((*any)(nil)).Elem())
+	for _, r := range results {
+		switch {
+		case r.Type() == errorType:
+			if !r.IsNil() {
+				err = r.Interface().(error)
+			}
+		case r.Kind() == reflect.Interface:
+			created = r
+		}
+	}
+	if call.Result != 0 {
+		s.objects[call.Result] = created
+	}
+	if err != nil && call.Error == "" {
+		return err
+	}
+	return nil
+}
+
+func (s *state) mapAsync(call *record.Call, buffer api.Buffer) error {
+	var (
+		mode         api.MapMode
+		offset, size uint64
+	)
+	if len(call.Args) != 4 {
+		return fmt.Errorf("got %d arguments, want 4", len(call.Args))
+	}
+	if err := errors.Join(
+		json.Unmarshal(call.Args[0], &mode),
+		json.Unmarshal(call.Args[1], &offset),
+		json.Unmarshal(call.Args[2], &size),
+	); err != nil {
+		return err
+	}
+
+	done := new(bool)
+	err := buffer.MapAsync(mode, offset, size, func(api.BufferMapAsyncStatus) {
+		*done = true
+	})
+	if err != nil {
+		if call.Error == "" {
+			return err
+		}
+		return nil
+	}
+	s.maps[call.Object] = done
+	return nil
+}
+
+// waitMap polls the device until the pending mapping of the buffer id is
+// done, if any.
+func (s *state) waitMap(id uint64) {
+	done, ok := s.maps[id]
+	if !ok {
+		return
+	}
+	for !*done {
+		s.device.Poll(true, nil)
+	}
+	delete(s.maps, id)
+}
+
+func (s *state) writeMappedRange(call *record.Call, buffer api.Buffer) error {
+	var (
+		offset uint
+		data   []byte
+	)
+	if len(call.Args) != 2 {
+		return fmt.Errorf("got %d arguments, want 2", len(call.Args))
+	}
+	if err := errors.Join(
+		json.Unmarshal(call.Args[0], &offset),
+		json.Unmarshal(call.Args[1], &data),
+	); err != nil {
+		return err
+	}
+
+	s.waitMap(call.Object)
+	dst := buffer.GetMappedRange(offset, uint(len(data)))
+	if dst == nil {
+		return fmt.Errorf("range %d..%d is not mapped", offset, offset+uint(len(data)))
+	}
+	copy(dst, data)
+	return nil
+}
@@ -0,0 +1,263 @@
+package wgpu
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type CompilationMessage struct {
+	Type    CompilationMessageType
+	Message string
+	// LineNum and LinePos are the 1-based line and column, in characters,
+	// of the span the message points at, or zero if it has none.
+	LineNum uint64
+	LinePos uint64
+	// Offset and Length are the span in bytes within the code.
+	Offset uint64
+	Length uint64
+	// Label is the label of the shader module.
+	Label string
+}
+
+type CompilationInfo struct {
+	Messages []CompilationMessage
+	// Code is the WGSL or GLSL code the messages point into, empty for
+	// SPIR-V.
+	Code string
+}
+
+// GetCompilationInfo returns the messages of the compilation of the module.
+//
+// wgpu-native doesn't implement wgpuShaderModuleGetCompilationInfo, and
+// reports compilation failures as errors of CreateShaderModule instead (see
+// ShaderCompileError), so the module has no messages.
+func (p *ShaderModule) GetCompilationInfo() *CompilationInfo {
+	p.checkReleased()
+	return &CompilationInfo{}
+}
+
+// Annotate returns the messages, each followed by the line of code it points
+// at with its span underlined by carets:
+//
+//	shader:4:13: error: expected expression, found ';'
+//	    let x = ;
+//	            ^
+func (v *CompilationInfo) Annotate() string {
+	lines := strings.Split(v.Code, "\n")
+
+	var b strings.Builder
+	for _, m := range v.Messages {
+		if m.Label != "" {
+			b.WriteString(m.Label + ":")
+		}
+		if m.LineNum != 0 {
+			b.WriteString(strconv.FormatUint(m.LineNum, 10) + ":" + strconv.FormatUint(m.LinePos, 10) + ": ")
+		} else if m.Label != "" {
+			b.WriteString(" ")
+		}
+		b.WriteString(strings.ToLower(m.Type.String()) + ": " + m.Message + "\n")
+
+		if m.LineNum == 0 || m.LineNum > uint64(len(lines)) {
+			continue
+		}
+		line := strings.TrimSuffix(lines[m.LineNum-1], "\r")
+		b.WriteString(line + "\n")
+
+		// keep the tabs of the line, so the carets line up with the span.
+		var prefix strings.Builder
+		var pos uint64 = 1
+		for _, r := range line {
+			if pos >= m.LinePos {
+				break
+			}
+			if r == '\t' {
+				prefix.WriteRune('\t')
+			} else {
+				prefix.WriteRune(' ')
+			}
+			pos++
+		}
+		carets := utf8.RuneCountInString(v.Code[minUint64(m.Offset, uint64(len(v.Code))):minUint64(m.Offset+m.Length, uint64(len(v.Code)))])
+		if rest := utf8.RuneCountInString(line) - int(pos) + 1; carets > rest {
+			carets = rest
+		}
+		if carets < 1 {
+			carets = 1
+		}
+		b.WriteString(prefix.String() + strings.Repeat("^", carets) + "\n")
+	}
+	return b.String()
+}
+
+func minUint64(x, y uint64) uint64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// ShaderCompileError is the error returned by CreateShaderModule when the
+// code fails to compile.
+type ShaderCompileError struct {
+	// Err is the error reported by wgpu.
+	Err *Error
+	CompilationInfo
+}
+
+func (v *ShaderCompileError) Error() string {
+	return v.Err.Error()
+}
+
+func (v *ShaderCompileError) Unwrap() error {
+	return v.Err
+}
+
+var (
+	// shaderSpanRegexp matches the location of a diagnostic printed by naga,
+	// like "┌─ wgsl:4:13".
+	shaderSpanRegexp = regexp.MustCompile(`(?:┌─|-->) [^\n]*?:(\d+):(\d+)\s*$`)
+	// shaderMessageRegexp matches the header of a diagnostic, like
+	// "error: expected expression, found ';'".
+	shaderMessageRegexp = regexp.MustCompile(`\b(error|warning|info)(?:\[\w+\])?: (.+)$`)
+	// shaderSnippetRegexp matches the lines of the code snippet below the
+	// location, like "4 │     let x = ;", with the line number if any.
+	shaderSnippetRegexp = regexp.MustCompile(`^\s*(\d*) ?[│|] ?`)
+)
+
+// newShaderCompileError parses the diagnostics naga printed in the message
+// of err, pointing into code.
+func newShaderCompileError(err *Error, code string) *ShaderCompileError {
+	v := &ShaderCompileError{Err: err}
+	v.Code = code
+
+	lines := strings.Split(err.Message, "\n")
+	header := CompilationMessage{Type: CompilationMessageType_Error, Label: err.Label}
+	for i, line := range lines {
+		if m := shaderMessageRegexp.FindStringSubmatch(line); m != nil {
+			header.Message = strings.TrimSpace(m[2])
+			switch m[1] {
+			case "warning":
+				header.Type = CompilationMessageType_Warning
+			case "info":
+				header.Type = CompilationMessageType_Info
+			default:
+				header.Type = CompilationMessageType_Error
+			}
+			continue
+		}
+
+		m := shaderSpanRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		msg := header
+		msg.LineNum, _ = strconv.ParseUint(m[1], 10, 64)
+		msg.LinePos, _ = strconv.ParseUint(m[2], 10, 64)
+		if msg.Message == "" {
+			msg.Message = err.Message
+		}
+		msg.Offset, msg.Length = shaderLabel(code, lines[i+1:], msg.LineNum, msg.LinePos)
+		v.Messages = append(v.Messages, msg)
+	}
+
+	if len(v.Messages) == 0 {
+		v.Messages = append(v.Messages, CompilationMessage{
+			Type:    CompilationMessageType_Error,
+			Message: err.Message,
+			Label:   err.Label,
+		})
+	}
+	return v
+}
+
+// shaderLabel returns the byte offset and length in code of the span at line
+// and pos, as underlined in the snippet following its location:
+//
+//	4 │     let x = ;
+//	  │             ^ expected expression
+//
+// or for a span over several lines:
+//
+//	2 │ ╭ fn main() {
+//	3 │ │ }
+//	  │ ╰─^
+func shaderLabel(code string, snippet []string, line, pos uint64) (offset, size uint64) {
+	var current uint64
+	multiline := false
+	for _, l := range snippet {
+		m := shaderSnippetRegexp.FindStringSubmatch(l)
+		if m == nil {
+			break
+		}
+		rest := l[len(m[0]):]
+		if m[1] != "" {
+			current, _ = strconv.ParseUint(m[1], 10, 64)
+			if current == line && strings.HasPrefix(rest, "╭") {
+				multiline = true
+			}
+			continue
+		}
+		if current != line && !multiline {
+			continue
+		}
+
+		switch {
+		case !multiline && strings.HasPrefix(strings.TrimLeft(rest, " "), "╭"):
+			// the span starts within the line, "╭────^".
+			multiline = true
+		case multiline:
+			i := strings.Index(rest, "╰")
+			if i < 0 {
+				continue
+			}
+			end := rest[i+len("╰"):]
+			j := strings.IndexByte(end, '^')
+			if j < 0 {
+				continue
+			}
+			// the carets line up with the code after the "│ " of the gutter.
+			endPos := uint64(utf8.RuneCountInString(end[:j]))
+			if endPos < 1 {
+				endPos = 1
+			}
+			start, _ := shaderSpan(code, line, pos, 0)
+			endOffset, endSize := shaderSpan(code, current, endPos, 1)
+			if endOffset+endSize <= start {
+				return start, 0
+			}
+			return start, endOffset + endSize - start
+		default:
+			if j := strings.IndexByte(rest, '^'); j >= 0 {
+				carets := uint64(len(rest[j:]) - len(strings.TrimLeft(rest[j:], "^")))
+				return shaderSpan(code, line, pos, carets)
+			}
+		}
+	}
+	return shaderSpan(code, line, pos, 0)
+}
+
+// shaderSpan returns the byte offset and length of the span of length
+// characters at line and pos in code.
+func shaderSpan(code string, line, pos, length uint64) (offset, size uint64) {
+	if line == 0 || pos == 0 {
+		return 0, 0
+	}
+	for l := uint64(1); l < line; l++ {
+		i := strings.IndexByte(code[offset:], '\n')
+		if i < 0 {
+			return 0, 0
+		}
+		offset += uint64(i) + 1
+	}
+	for p := uint64(1); p < pos && offset < uint64(len(code)); p++ {
+		_, n := utf8.DecodeRuneInString(code[offset:])
+		offset += uint64(n)
+	}
+	for c := uint64(0); c < length && offset+size < uint64(len(code)); c++ {
+		_, n := utf8.DecodeRuneInString(code[offset+size:])
+		size += uint64(n)
+	}
+	return offset, size
+}
@@ -0,0 +1,202 @@
+package wgpu
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	parseErrorCode = "@compute @workgroup_size(1)\nfn main() {\n    let x = ;\n}\n"
+	// parseErrorMessage is the error wgpu-native reports for parseErrorCode.
+	parseErrorMessage = "Validation Error\n\nCaused by:\n    In wgpuDeviceCreateShaderModule\n      note: label = `shader`\n    \n" +
+		"Shader 'shader' parsing error: expected expression, found ';'\n" +
+		"  ┌─ wgsl:3:13\n" +
+		"  │\n" +
+		"3 │     let x = ;\n" +
+		"  │             ^ expected expression\n" +
+		"\n\n    expected expression, found ';'\n"
+
+	validationErrorCode = "@fragment\nfn main() -> @location(0) vec4<f32> {\n    return 1.0;\n}\n"
+	// validationErrorMessage is the error wgpu-native reports for
+	// validationErrorCode, with a span over several lines.
+	validationErrorMessage = "Validation Error\n\nCaused by:\n    In wgpuDeviceCreateShaderModule\n      note: label = `shader`\n    \n" +
+		"Shader validation error: Function [0] 'main' is invalid\n" +
+		"  ┌─ :2:1\n" +
+		"  │  \n" +
+		"2 │ ╭ fn main() -> @location(0) vec4<f32> {\n" +
+		"3 │ │     return 1.0;\n" +
+		"  │ │            ^^^ naga::Expression [0]\n" +
+		"4 │ │ }\n" +
+		"  │ ╰─^ naga::Function [0]\n" +
+		"  │  \n" +
+		"  = The `return` value Some([0]) does not match the function return value\n" +
+		"\n\n    Function [0] 'main' is invalid\n"
+
+	glslErrorCode = "#version 450\nvoid main() {\n    x = 1;\n    y = 2;\n}\n"
+	// glslErrorMessage is the error wgpu-native reports for glslErrorCode,
+	// with a diagnostic for each error.
+	glslErrorMessage = "Validation Error\n\nCaused by:\n    In wgpuDeviceCreateShaderModule\n    \n" +
+		"Shader 'shader' parsing error: Unknown variable: x\n" +
+		"  ┌─ glsl:3:5\n" +
+		"  │\n" +
+		"3 │     x = 1;\n" +
+		"  │     ^ Unknown variable: x\n" +
+		"\n" +
+		"error: Unknown variable: y\n" +
+		"  ┌─ glsl:4:5\n" +
+		"  │\n" +
+		"4 │     y = 2;\n" +
+		"  │     ^ Unknown variable: y\n"
+
+	spanStartCode = "fn main() {\n    let x = f(1,\n        2);\n}\n"
+	// spanStartMessage has a span over several lines starting within the
+	// line.
+	spanStartMessage = "error: unknown function: 'f'\n" +
+		"  ┌─ wgsl:2:13\n" +
+		"  │\n" +
+		"2 │       let x = f(1,\n" +
+		"  │ ╭─────────────^\n" +
+		"3 │ │         2);\n" +
+		"  │ ╰──────────^ unknown function\n"
+)
+
+func TestNewShaderCompileError(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		code     string
+		expected []CompilationMessage
+	}{
+		{"parse error", parseErrorMessage, parseErrorCode, []CompilationMessage{{
+			Type:    CompilationMessageType_Error,
+			Message: "expected expression, found ';'",
+			LineNum: 3, LinePos: 13,
+			Offset: 52, Length: 1,
+			Label: "shader",
+		}}},
+		{"span over several lines", validationErrorMessage, validationErrorCode, []CompilationMessage{{
+			Type:    CompilationMessageType_Error,
+			Message: "Function [0] 'main' is invalid",
+			LineNum: 2, LinePos: 1,
+			Offset: 10, Length: uint64(len(validationErrorCode)) - 11,
+			Label: "shader",
+		}}},
+		{"span starting within the line", spanStartMessage, spanStartCode, []CompilationMessage{{
+			Type:    CompilationMessageType_Error,
+			Message: "unknown function: 'f'",
+			LineNum: 2, LinePos: 13,
+			Offset: 24, Length: 15,
+			Label: "shader",
+		}}},
+		{"several diagnostics", glslErrorMessage, glslErrorCode, []CompilationMessage{{
+			Type:    CompilationMessageType_Error,
+			Message: "Unknown variable: x",
+			LineNum: 3, LinePos: 5,
+			Offset: 31, Length: 1,
+			Label: "shader",
+		}, {
+			Type:    CompilationMessageType_Error,
+			Message: "Unknown variable: y",
+			LineNum: 4, LinePos: 5,
+			Offset: 42, Length: 1,
+			Label: "shader",
+		}}},
+		{"warning", "warning[W0001]: unused variable\n  --> wgsl:1:5\n  |\n1 | let x = 1;\n  |     ^\n", "let x = 1;", []CompilationMessage{{
+			Type:    CompilationMessageType_Warning,
+			Message: "unused variable",
+			LineNum: 1, LinePos: 5,
+			Offset: 4, Length: 1,
+			Label: "shader",
+		}}},
+		{"no code", parseErrorMessage, "", []CompilationMessage{{
+			Type:    CompilationMessageType_Error,
+			Message: "expected expression, found ';'",
+			LineNum: 3, LinePos: 13,
+			Label: "shader",
+		}}},
+		{"no location", "Validation Error\n\nCaused by:\n    In wgpuDeviceCreateShaderModule\n    Failed to parse shader: invalid word count", "", []CompilationMessage{{
+			Type:    CompilationMessageType_Error,
+			Message: "Validation Error\n\nCaused by:\n    In wgpuDeviceCreateShaderModule\n    Failed to parse shader: invalid word count",
+			Label:   "shader",
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &Error{Type: ErrorType_Validation, Label: "shader", Message: tt.message}
+			v := newShaderCompileError(err, tt.code)
+			if !reflect.DeepEqual(v.Messages, tt.expected) {
+				t.Errorf("got\n%+v\nexpected\n%+v", v.Messages, tt.expected)
+			}
+			if v.Code != tt.code || v.Unwrap() != err {
+				t.Errorf("got code %q and error %v", v.Code, v.Unwrap())
+			}
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     CompilationInfo
+		expected string
+	}{
+		{"parse error", CompilationInfo{
+			Code: parseErrorCode,
+			Messages: []CompilationMessage{{
+				Type:    CompilationMessageType_Error,
+				Message: "expected expression, found ';'",
+				LineNum: 3, LinePos: 13,
+				Offset: 52, Length: 1,
+				Label: "shader",
+			}},
+		}, "shader:3:13: error: expected expression, found ';'\n    let x = ;\n            ^\n"},
+		{"tabs and wide characters", CompilationInfo{
+			Code: "fn main() {\n\tlet é = vec2(1, 2);\r\n}",
+			Messages: []CompilationMessage{{
+				Type:    CompilationMessageType_Warning,
+				Message: "unused variable",
+				LineNum: 2, LinePos: 6,
+				Offset: 17, Length: 2,
+			}},
+		}, "2:6: warning: unused variable\n\tlet é = vec2(1, 2);\n\t    ^\n"},
+		{"span over several lines", CompilationInfo{
+			Code: "fn main() {\n}",
+			Messages: []CompilationMessage{{
+				Type:    CompilationMessageType_Error,
+				Message: "invalid function",
+				LineNum: 1, LinePos: 4,
+				Offset: 3, Length: 10,
+			}},
+		}, "1:4: error: invalid function\nfn main() {\n   ^^^^^^^^\n"},
+		{"empty span", CompilationInfo{
+			Code: "let x = 1;",
+			Messages: []CompilationMessage{{
+				Type:    CompilationMessageType_Info,
+				Message: "here",
+				LineNum: 1, LinePos: 11,
+				Offset: 10,
+			}},
+		}, "1:11: info: here\nlet x = 1;\n          ^\n"},
+		{"no location", CompilationInfo{
+			Messages: []CompilationMessage{
+				{Type: CompilationMessageType_Error, Message: "invalid word count", Label: "shader"},
+				{Type: CompilationMessageType_Error, Message: "invalid word count"},
+			},
+		}, "shader: error: invalid word count\nerror: invalid word count\n"},
+		{"line past the code", CompilationInfo{
+			Code: "let x = 1;",
+			Messages: []CompilationMessage{{
+				Type:    CompilationMessageType_Error,
+				Message: "unexpected end",
+				LineNum: 3, LinePos: 1,
+			}},
+		}, "3:1: error: unexpected end\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.Annotate(); got != tt.expected {
+				t.Errorf("got\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
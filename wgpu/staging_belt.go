@@ -0,0 +1,189 @@
+package wgpu
+
+import "sync"
+
+type StagingBeltDescriptor struct {
+	Label string
+	// ChunkSize is the size of the staging buffers. Writes larger than it get
+	// a buffer of their own.
+	ChunkSize uint64
+}
+
+// StagingBelt writes to buffers through a pool of mapped staging buffers,
+// recording a copy from the staging buffer for each write, like
+// wgpu::util::StagingBelt.
+//
+// Finish must be called before submitting the commands recording the copies,
+// and Recall after. The staging buffers are mapped again asynchronously, so
+// the device has to be polled for them to be reused, see
+// (*Device).StartPoller.
+type StagingBelt struct {
+	device    *Device
+	label     string
+	chunkSize uint64
+
+	mu sync.Mutex
+	// active chunks are mapped and being written, closed ones are unmapped
+	// and wait for the submission of their copies, free ones are mapped
+	// again.
+	active   []*stagingChunk
+	closed   []*stagingChunk
+	free     []*stagingChunk
+	released bool
+}
+
+type stagingChunk struct {
+	buffer *Buffer
+	size   uint64
+	data   []byte
+	offset uint64
+}
+
+func (p *Device) CreateStagingBelt(descriptor *StagingBeltDescriptor) *StagingBelt {
+	p.checkReleased()
+	if descriptor == nil {
+		panic("got nil descriptor")
+	}
+
+	return &StagingBelt{
+		device:    p,
+		label:     descriptor.Label,
+		chunkSize: alignUp(max64(descriptor.ChunkSize, 1), MapAlignment),
+	}
+}
+
+// Write records a copy of size bytes to target at offset on encoder, and
+// returns the staging memory to write the data to. The memory must not be
+// used after Finish.
+func (p *StagingBelt) Write(encoder *CommandEncoder, target *Buffer, offset uint64, size uint64) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checkReleased()
+
+	if size == 0 || size%CopyBufferAlignment != 0 {
+		return nil, &Error{
+			Type:    ErrorType_Validation,
+			Op:      "wgpu.(*StagingBelt).Write()",
+			Label:   target.label,
+			Message: "size must be a non-zero multiple of CopyBufferAlignment",
+		}
+	}
+
+	chunk, err := p.chunk(size)
+	if err != nil {
+		return nil, err
+	}
+	if err := encoder.CopyBufferToBuffer(chunk.buffer, chunk.offset, target, offset, size); err != nil {
+		return nil, err
+	}
+
+	data := chunk.data[chunk.offset : chunk.offset+size : chunk.offset+size]
+	chunk.offset = alignUp(chunk.offset+size, MapAlignment)
+	return data, nil
+}
+
+// chunk returns an active chunk with at least size bytes left.
+func (p *StagingBelt) chunk(size uint64) (*stagingChunk, error) {
+	for _, c := range p.active {
+		if c.offset+size <= c.size {
+			return c, nil
+		}
+	}
+
+	for i, c := range p.free {
+		if c.size >= size {
+			p.free = append(p.free[:i], p.free[i+1:]...)
+			p.active = append(p.active, c)
+			return c, nil
+		}
+	}
+
+	chunkSize := max64(p.chunkSize, alignUp(size, MapAlignment))
+	buffer, err := p.device.CreateBuffer(&BufferDescriptor{
+		Label:            p.label,
+		Size:             chunkSize,
+		Usage:            BufferUsage_MapWrite | BufferUsage_CopySrc,
+		MappedAtCreation: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c := &stagingChunk{
+		buffer: buffer,
+		size:   chunkSize,
+		data:   buffer.GetMappedRange(0, uint(chunkSize)),
+	}
+	p.active = append(p.active, c)
+	return c, nil
+}
+
+// Finish unmaps the staging buffers written since the last call, so the
+// commands recording their copies can be submitted.
+func (p *StagingBelt) Finish() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checkReleased()
+
+	var err error
+	for _, c := range p.active {
+		c.data = nil
+		if e := c.buffer.Unmap(); e != nil && err == nil {
+			err = e
+		}
+	}
+	p.closed = append(p.closed, p.active...)
+	p.active = nil
+	return err
+}
+
+// Recall maps the staging buffers closed by Finish again, to reuse them once
+// the commands copying from them complete. It must be called after the
+// commands are submitted.
+func (p *StagingBelt) Recall() {
+	p.mu.Lock()
+	p.checkReleased()
+	closed := p.closed
+	p.closed = nil
+	p.mu.Unlock()
+
+	for _, c := range closed {
+		c := c
+		err := c.buffer.MapAsync(MapMode_Write, 0, c.size, func(status BufferMapAsyncStatus) {
+			p.recall(c, status == BufferMapAsyncStatus_Success)
+		})
+		if err != nil {
+			p.recall(c, false)
+		}
+	}
+}
+
+func (p *StagingBelt) recall(c *stagingChunk, mapped bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.released || !mapped {
+		c.buffer.Release()
+		return
+	}
+	c.data = c.buffer.GetMappedRange(0, uint(c.size))
+	c.offset = 0
+	p.free = append(p.free, c)
+}
+
+// Release releases the staging buffers, the ones being mapped again once
+// they are.
+func (p *StagingBelt) Release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.released {
+		return
+	}
+	p.released = true
+
+	for _, chunks := range [][]*stagingChunk{p.active, p.closed, p.free} {
+		for _, c := range chunks {
+			c.buffer.Release()
+		}
+	}
+	p.active, p.closed, p.free = nil, nil, nil
+}
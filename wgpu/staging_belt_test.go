@@ -0,0 +1,153 @@
+package wgpu
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// beltTarget creates a buffer for a staging belt to write to, that can be
+// read back.
+func beltTarget(t *testing.T, device *Device, size uint64) *Buffer {
+	t.Helper()
+
+	buffer, err := device.CreateBuffer(&BufferDescriptor{
+		Size:  size,
+		Usage: BufferUsage_CopyDst | BufferUsage_CopySrc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(buffer.Release)
+	return buffer
+}
+
+// writeBelt writes data to target at offset through belt, submits the copy
+// and recalls the staging buffers.
+func writeBelt(t *testing.T, device *Device, belt *StagingBelt, target *Buffer, offset uint64, data ...[]byte) {
+	t.Helper()
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encoder.Release()
+	for _, data := range data {
+		staging, err := belt.Write(encoder, target, offset, uint64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		copy(staging, data)
+		offset += uint64(len(data))
+	}
+	if err := belt.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	commands, err := encoder.Finish(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer commands.Release()
+	device.GetQueue().Submit(commands)
+	belt.Recall()
+}
+
+func (p *StagingBelt) chunks() (active, closed, free []*stagingChunk) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active, p.closed, p.free
+}
+
+// waitRecall polls device until n chunks of belt are mapped again.
+func waitRecall(t *testing.T, device *Device, belt *StagingBelt, n int) []*stagingChunk {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		if _, _, free := belt.chunks(); len(free) == n {
+			return free
+		}
+		device.Poll(true, nil)
+	}
+	_, _, free := belt.chunks()
+	t.Fatalf("got %d free chunks, expected %d", len(free), n)
+	return nil
+}
+
+func TestStagingBeltReuse(t *testing.T) {
+	device := testDevice(t)
+	belt := device.CreateStagingBelt(&StagingBeltDescriptor{ChunkSize: 1024})
+	defer belt.Release()
+	target := beltTarget(t, device, 64)
+
+	writeBelt(t, device, belt, target, 0, []byte("0123456789abcdef"), []byte("ghij"))
+	chunks := waitRecall(t, device, belt, 1)
+	chunk := chunks[0]
+	if chunk.size != 1024 || chunk.offset != 0 {
+		t.Errorf("got a chunk of %d bytes at offset %d", chunk.size, chunk.offset)
+	}
+
+	// the recalled chunk is reused rather than a new one created.
+	writeBelt(t, device, belt, target, 20, []byte("klmnopqr"))
+	chunks = waitRecall(t, device, belt, 1)
+	if chunks[0] != chunk {
+		t.Error("got a new chunk")
+	}
+
+	data, err := device.ReadBuffer(context.Background(), target, 0, 28)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []byte("0123456789abcdefghijklmnopqr"); !bytes.Equal(data, expected) {
+		t.Errorf("got %q, expected %q", data, expected)
+	}
+}
+
+func TestStagingBeltLargeWrite(t *testing.T) {
+	device := testDevice(t)
+	belt := device.CreateStagingBelt(&StagingBeltDescriptor{ChunkSize: 64})
+	defer belt.Release()
+	target := beltTarget(t, device, 2048)
+
+	large := bytes.Repeat([]byte("large!!!"), 125)
+	writeBelt(t, device, belt, target, 0, []byte("smol"), large, []byte("tiny"))
+	chunks := waitRecall(t, device, belt, 2)
+
+	// the large write gets a chunk of its own, the small ones share one.
+	sizes := map[uint64]bool{}
+	for _, c := range chunks {
+		sizes[c.size] = true
+	}
+	if len(sizes) != 2 || !sizes[64] || !sizes[1000] {
+		t.Errorf("got chunks of sizes %v, expected 64 and 1000", sizes)
+	}
+
+	data, err := device.ReadBuffer(context.Background(), target, 0, 1008)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := append(append([]byte("smol"), large...), "tiny"...)
+	if !bytes.Equal(data, expected) {
+		t.Errorf("got %q, expected %q", data, expected)
+	}
+}
+
+func TestStagingBeltUnalignedWrite(t *testing.T) {
+	device := testDevice(t)
+	belt := device.CreateStagingBelt(&StagingBeltDescriptor{ChunkSize: 64})
+	defer belt.Release()
+	target := beltTarget(t, device, 64)
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encoder.Release()
+	for _, size := range []uint64{0, 3} {
+		if _, err := belt.Write(encoder, target, 0, size); err == nil {
+			t.Errorf("expected an error writing %d bytes", size)
+		}
+	}
+	if active, _, _ := belt.chunks(); len(active) != 0 {
+		t.Errorf("got %d chunks", len(active))
+	}
+}
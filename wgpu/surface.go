@@ -12,10 +12,13 @@ import (
 )
 
 type Surface struct {
-	ref C.WGPUSurface
+	ref   C.WGPUSurface
+	label string
 }
 
 func (p *Surface) GetPreferredFormat(adapter *Adapter) TextureFormat {
+	p.checkReleased()
+	adapter.checkReleased()
 	format := C.wgpuSurfaceGetPreferredFormat(p.ref, adapter.ref)
 	return TextureFormat(format)
 }
@@ -27,6 +30,8 @@ type SurfaceCapabilities struct {
 }
 
 func (p *Surface) GetCapabilities(adapter *Adapter) (ret SurfaceCapabilities) {
+	p.checkReleased()
+	adapter.checkReleased()
 	var caps C.WGPUSurfaceCapabilities
 	C.wgpuSurfaceGetCapabilities(p.ref, adapter.ref, &caps)
 
@@ -68,5 +73,10 @@ func (p *Surface) GetCapabilities(adapter *Adapter) (ret SurfaceCapabilities) {
 }
 
 func (p *Surface) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
 	C.wgpuSurfaceRelease(p.ref)
+	p.ref = nil
 }
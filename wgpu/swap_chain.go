@@ -9,43 +9,53 @@ extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message,
 
 static inline WGPUTextureView gowebgpu_swap_chain_get_current_texture_view(WGPUSwapChain swapChain, WGPUDevice device, void * error_userdata) {
 	WGPUTextureView ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuSwapChainGetCurrentTextureView(swapChain);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
-static inline void gowebgpu_swap_chain_release(WGPUSwapChain swapChain, WGPUDevice device) {
-	wgpuDeviceRelease(device);
-	wgpuSwapChainRelease(swapChain);
-}
-
 */
 import "C"
 import (
-	"errors"
 	"runtime/cgo"
 	"unsafe"
 )
 
 type SwapChain struct {
-	deviceRef C.WGPUDevice
-	ref       C.WGPUSwapChain
+	device *Device
+	ref    C.WGPUSwapChain
+	child  *deviceChild
 }
 
 func (p *SwapChain) GetCurrentTextureView() (*TextureView, error) {
+	p.checkReleased()
+	if err := p.device.lostError("wgpu.(*SwapChain).GetCurrentTextureView()", ""); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*SwapChain).GetCurrentTextureView(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*SwapChain).GetCurrentTextureView()", "") {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*SwapChain).GetCurrentTextureView()", Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_swap_chain_get_current_texture_view(
 		p.ref,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		if ref != nil {
 			C.wgpuTextureViewRelease(ref)
@@ -53,13 +63,23 @@ func (p *SwapChain) GetCurrentTextureView() (*TextureView, error) {
 		return nil, err
 	}
 
-	return &TextureView{ref}, nil
+	child := p.device.addChild(deviceChildOrderTextureView, func(*Device) { C.wgpuTextureViewRelease(ref) })
+	return trackObject(&TextureView{ref: ref, child: child}, "", (*TextureView).Release), nil
 }
 
 func (p *SwapChain) Present() {
+	p.checkReleased()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuSwapChainPresent(p.ref)
 }
 
 func (p *SwapChain) Release() {
-	C.gowebgpu_swap_chain_release(p.ref, p.deviceRef)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
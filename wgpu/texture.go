@@ -9,28 +9,28 @@ extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message,
 
 static inline WGPUTextureView gowebgpu_texture_create_view(WGPUTexture texture, WGPUTextureViewDescriptor const * descriptor, WGPUDevice device, void * error_userdata) {
 	WGPUTextureView ref = NULL;
-	wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	if (error_userdata != NULL) {
+		wgpuDevicePushErrorScope(device, WGPUErrorFilter_Validation);
+	}
 	ref = wgpuTextureCreateView(texture, descriptor);
-	wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	if (error_userdata != NULL) {
+		wgpuDevicePopErrorScope(device, gowebgpu_error_callback_c, error_userdata);
+	}
 	return ref;
 }
 
-static inline void gowebgpu_texture_release(WGPUTexture texture, WGPUDevice device) {
-	wgpuDeviceRelease(device);
-	wgpuTextureRelease(texture);
-}
-
 */
 import "C"
 import (
-	"errors"
 	"runtime/cgo"
 	"unsafe"
 )
 
 type Texture struct {
-	deviceRef C.WGPUDevice
-	ref       C.WGPUTexture
+	device *Device
+	ref    C.WGPUTexture
+	label  string
+	child  *deviceChild
 }
 
 type TextureViewDescriptor struct {
@@ -45,6 +45,7 @@ type TextureViewDescriptor struct {
 }
 
 func (p *Texture) CreateView(descriptor *TextureViewDescriptor) (*TextureView, error) {
+	p.checkReleased()
 	var desc *C.WGPUTextureViewDescriptor
 
 	if descriptor != nil {
@@ -66,63 +67,98 @@ func (p *Texture) CreateView(descriptor *TextureViewDescriptor) (*TextureView, e
 		}
 	}
 
+	if err := p.device.lostError("wgpu.(*Texture).CreateView()", p.label); err != nil {
+		return nil, err
+	}
+
 	var err error = nil
-	var cb errorCallback = func(_ ErrorType, message string) {
-		err = errors.New("wgpu.(*Texture).CreateView(): " + message)
+	var errorUserdata unsafe.Pointer
+	if !p.device.recordDeferred("wgpu.(*Texture).CreateView()", p.label) {
+		var cb errorCallback = func(typ ErrorType, message string) {
+			err = &Error{Type: typ, Op: "wgpu.(*Texture).CreateView()", Label: p.label, Message: message}
+		}
+		errorCallbackHandle := cgo.NewHandle(cb)
+		defer errorCallbackHandle.Delete()
+		errorUserdata = unsafe.Pointer(&errorCallbackHandle)
 	}
-	errorCallbackHandle := cgo.NewHandle(cb)
-	defer errorCallbackHandle.Delete()
 
+	unlock := p.device.lockErrorScope(errorUserdata)
 	ref := C.gowebgpu_texture_create_view(
 		p.ref,
 		desc,
-		p.deviceRef,
-		unsafe.Pointer(&errorCallbackHandle),
+		p.device.ref,
+		errorUserdata,
 	)
+	unlock()
 	if err != nil {
 		C.wgpuTextureViewRelease(ref)
 		return nil, err
 	}
 
-	return &TextureView{ref}, nil
+	var label string
+	if descriptor != nil {
+		label = descriptor.Label
+	}
+	child := p.device.addChild(deviceChildOrderTextureView, func(*Device) { C.wgpuTextureViewRelease(ref) })
+	// the view is invalidated with its texture.
+	child.onInvalidate(p.child.onInvalidate(child.invalidate))
+	return trackObject(&TextureView{ref: ref, label: label, child: child}, label, (*TextureView).Release), nil
 }
 
 func (p *Texture) Destroy() {
+	p.checkReleased()
+	p.child.invalidate()
+	if p.device.isLost() {
+		return
+	}
 	C.wgpuTextureDestroy(p.ref)
 }
 
 func (p *Texture) GetDepthOrArrayLayers() uint32 {
+	p.checkReleased()
 	return uint32(C.wgpuTextureGetDepthOrArrayLayers(p.ref))
 }
 
 func (p *Texture) GetDimension() TextureDimension {
+	p.checkReleased()
 	return TextureDimension(C.wgpuTextureGetDimension(p.ref))
 }
 
 func (p *Texture) GetFormat() TextureFormat {
+	p.checkReleased()
 	return TextureFormat(C.wgpuTextureGetFormat(p.ref))
 }
 
 func (p *Texture) GetHeight() uint32 {
+	p.checkReleased()
 	return uint32(C.wgpuTextureGetHeight(p.ref))
 }
 
 func (p *Texture) GetMipLevelCount() uint32 {
+	p.checkReleased()
 	return uint32(C.wgpuTextureGetMipLevelCount(p.ref))
 }
 
 func (p *Texture) GetSampleCount() uint32 {
+	p.checkReleased()
 	return uint32(C.wgpuTextureGetSampleCount(p.ref))
 }
 
 func (p *Texture) GetUsage() TextureUsage {
+	p.checkReleased()
 	return TextureUsage(C.wgpuTextureGetUsage(p.ref))
 }
 
 func (p *Texture) GetWidth() uint32 {
+	p.checkReleased()
 	return uint32(C.wgpuTextureGetWidth(p.ref))
 }
 
 func (p *Texture) Release() {
-	C.gowebgpu_texture_release(p.ref, p.deviceRef)
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
 }
@@ -0,0 +1,161 @@
+package wgpu
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+// TextureCopyLayout is the layout of the data of a copy between a buffer and
+// a texture.
+type TextureCopyLayout struct {
+	Layout TextureDataLayout
+	// RowSize is the size in bytes of a row of blocks, without padding.
+	RowSize uint64
+	// Rows is the number of rows of blocks of an image, and Images the number
+	// of images, that is layers or depth slices.
+	Rows   uint32
+	Images uint32
+	// Size is the size in bytes of the data.
+	Size uint64
+}
+
+// CalculateTextureCopyLayout returns the layout of the data of a copy of the
+// aspect of the mip level of a texture created from descriptor. copySize is
+// the extent of the copy in texels, the whole mip level if nil.
+//
+// If padded is set, rows are padded to CopyBytesPerRowAlignment as
+// CommandEncoder.CopyBufferToTexture and CommandEncoder.CopyTextureToBuffer
+// require, otherwise they are tightly packed, as Queue.WriteTexture allows.
+func CalculateTextureCopyLayout(descriptor *TextureDescriptor, mipLevel uint32, aspect TextureAspect, copySize *Extent3D, padded bool) (TextureCopyLayout, error) {
+	const op = "wgpu.CalculateTextureCopyLayout()"
+	fail := func(message string) (TextureCopyLayout, error) {
+		return TextureCopyLayout{}, &Error{Type: ErrorType_Validation, Op: op, Label: descriptor.Label, Message: message}
+	}
+
+	blockWidth, blockHeight, blockSize, ok := textureCopyBlock(descriptor.Format, aspect)
+	if !ok {
+		return fail("aspect " + aspect.String() + " of format " + descriptor.Format.String() + " can't be copied")
+	}
+	if mipLevel >= descriptor.MipLevelCount && !(mipLevel == 0 && descriptor.MipLevelCount == 0) {
+		return fail("mip level " + strconv.FormatUint(uint64(mipLevel), 10) + " is out of range")
+	}
+
+	// the physical size of the mip level, rounded up to whole blocks.
+	mipWidth := alignUp(uint64(mipExtent(descriptor.Size.Width, mipLevel)), uint64(blockWidth))
+	mipHeight := alignUp(uint64(mipExtent(descriptor.Size.Height, mipLevel)), uint64(blockHeight))
+	if mipWidth > math.MaxUint32 || mipHeight > math.MaxUint32 {
+		return fail("size of mip level " + strconv.FormatUint(uint64(mipLevel), 10) + " overflows")
+	}
+	mipSize := Extent3D{
+		Width:              uint32(mipWidth),
+		Height:             uint32(mipHeight),
+		DepthOrArrayLayers: max32(descriptor.Size.DepthOrArrayLayers, 1),
+	}
+	switch descriptor.Dimension {
+	case TextureDimension_1D:
+		mipSize.Height = 1
+	case TextureDimension_3D:
+		mipSize.DepthOrArrayLayers = mipExtent(descriptor.Size.DepthOrArrayLayers, mipLevel)
+	}
+
+	size := mipSize
+	if copySize != nil {
+		size = *copySize
+		size.DepthOrArrayLayers = max32(size.DepthOrArrayLayers, 1)
+	}
+	if size.Width%blockWidth != 0 || size.Height%blockHeight != 0 {
+		return fail("copy size must be a multiple of the " + strconv.FormatUint(uint64(blockWidth), 10) + "x" + strconv.FormatUint(uint64(blockHeight), 10) + " blocks of the format")
+	}
+	if size.Width > mipSize.Width || size.Height > mipSize.Height || size.DepthOrArrayLayers > mipSize.DepthOrArrayLayers {
+		return fail("copy size is larger than mip level " + strconv.FormatUint(uint64(mipLevel), 10))
+	}
+
+	v := TextureCopyLayout{
+		RowSize: uint64(size.Width/blockWidth) * uint64(blockSize),
+		Rows:    size.Height / blockHeight,
+		Images:  size.DepthOrArrayLayers,
+	}
+	bytesPerRow := v.RowSize
+	if padded {
+		bytesPerRow = alignUp(bytesPerRow, CopyBytesPerRowAlignment)
+	}
+	if bytesPerRow > math.MaxUint32 {
+		return fail("bytes per row overflow")
+	}
+	v.Layout = TextureDataLayout{
+		BytesPerRow:  uint32(bytesPerRow),
+		RowsPerImage: v.Rows,
+	}
+	hi, imageSize := bits.Mul64(bytesPerRow, uint64(v.Rows))
+	if hi != 0 {
+		return fail("size overflows")
+	}
+	if hi, v.Size = bits.Mul64(imageSize, uint64(v.Images)); hi != 0 {
+		return fail("size overflows")
+	}
+	return v, nil
+}
+
+// Pad returns data, whose rows are tightly packed, laid out with the padding
+// of v.
+func (v *TextureCopyLayout) Pad(data []byte) []byte {
+	if uint64(v.Layout.BytesPerRow) == v.RowSize {
+		return data
+	}
+	padded := make([]byte, v.Size)
+	for row := uint64(0); row < uint64(v.Rows)*uint64(v.Images); row++ {
+		copy(padded[row*uint64(v.Layout.BytesPerRow):], data[row*v.RowSize:(row+1)*v.RowSize])
+	}
+	return padded
+}
+
+// Unpad returns data, laid out with the padding of v, with its rows tightly
+// packed.
+func (v *TextureCopyLayout) Unpad(data []byte) []byte {
+	if uint64(v.Layout.BytesPerRow) == v.RowSize {
+		return data
+	}
+	tight := make([]byte, v.RowSize*uint64(v.Rows)*uint64(v.Images))
+	for row := uint64(0); row < uint64(v.Rows)*uint64(v.Images); row++ {
+		copy(tight[row*v.RowSize:(row+1)*v.RowSize], data[row*uint64(v.Layout.BytesPerRow):])
+	}
+	return tight
+}
+
+// textureCopyBlock returns the size in texels and in bytes of the blocks of
+// the aspect of format in copies between buffers and textures.
+func textureCopyBlock(format TextureFormat, aspect TextureAspect) (blockWidth, blockHeight, blockSize uint32, ok bool) {
+	info := format.Info()
+	switch {
+	case info.Depth && info.Stencil:
+		switch aspect {
+		case TextureAspect_StencilOnly:
+			return 1, 1, 1, true
+		case TextureAspect_DepthOnly:
+			// the depth aspect of TextureFormat_Depth24PlusStencil8 can't be
+			// copied.
+			return 1, 1, 4, format == TextureFormat_Depth32FloatStencil8
+		}
+		return 0, 0, 0, false
+	case info.Depth:
+		ok = aspect != TextureAspect_StencilOnly
+	case info.Stencil:
+		ok = aspect != TextureAspect_DepthOnly
+	default:
+		ok = aspect == TextureAspect_All
+	}
+	return info.BlockWidth, info.BlockHeight, info.BlockSize, ok && info.BlockSize != 0
+}
+
+// mipExtent returns the size of a dimension of size texels at mip level.
+func mipExtent(size, mipLevel uint32) uint32 {
+	return max32(size>>mipLevel, 1)
+}
+
+func max32(x, y uint32) uint32 {
+	if x > y {
+		return x
+	}
+	return y
+}
@@ -0,0 +1,137 @@
+package wgpu
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCalculateTextureCopyLayout(t *testing.T) {
+	texture := func(dimension TextureDimension, format TextureFormat, width, height, depth, mipLevels uint32) *TextureDescriptor {
+		return &TextureDescriptor{
+			Dimension:     dimension,
+			Format:        format,
+			Size:          Extent3D{Width: width, Height: height, DepthOrArrayLayers: depth},
+			MipLevelCount: mipLevels,
+		}
+	}
+	layout := func(bytesPerRow uint32, rowSize uint64, rows, images uint32) TextureCopyLayout {
+		return TextureCopyLayout{
+			Layout:  TextureDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: rows},
+			RowSize: rowSize,
+			Rows:    rows,
+			Images:  images,
+			Size:    uint64(bytesPerRow) * uint64(rows) * uint64(images),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		descriptor *TextureDescriptor
+		mipLevel   uint32
+		copySize   *Extent3D
+		padded     bool
+		expected   TextureCopyLayout
+	}{
+		{"2D", texture(TextureDimension_2D, TextureFormat_RGBA8Unorm, 100, 50, 1, 1), 0, nil, false, layout(400, 400, 50, 1)},
+		{"2D padded", texture(TextureDimension_2D, TextureFormat_RGBA8Unorm, 100, 50, 1, 1), 0, nil, true, layout(512, 400, 50, 1)},
+		{"2D array", texture(TextureDimension_2D, TextureFormat_RGBA8Unorm, 8, 8, 6, 1), 0, nil, false, layout(32, 32, 8, 6)},
+		{"copy size", texture(TextureDimension_2D, TextureFormat_RGBA8Unorm, 8, 8, 6, 1), 0, &Extent3D{Width: 4, Height: 2, DepthOrArrayLayers: 2}, false, layout(16, 16, 2, 2)},
+		{"BC", texture(TextureDimension_2D, TextureFormat_BC1RGBAUnorm, 10, 10, 1, 1), 0, nil, false, layout(24, 24, 3, 1)},
+		{"BC padded", texture(TextureDimension_2D, TextureFormat_BC1RGBAUnorm, 10, 10, 1, 1), 0, nil, true, layout(256, 24, 3, 1)},
+		// the 2x2 mip level is a single block.
+		{"BC mip level", texture(TextureDimension_2D, TextureFormat_BC1RGBAUnorm, 10, 10, 1, 3), 2, nil, false, layout(8, 8, 1, 1)},
+		{"1D", texture(TextureDimension_1D, TextureFormat_R8Unorm, 300, 1, 1, 1), 0, nil, true, layout(512, 300, 1, 1)},
+		{"3D", texture(TextureDimension_3D, TextureFormat_RGBA16Float, 8, 8, 8, 1), 0, nil, false, layout(64, 64, 8, 8)},
+		{"3D mip level", texture(TextureDimension_3D, TextureFormat_RGBA16Float, 8, 8, 8, 4), 1, nil, true, layout(256, 32, 4, 4)},
+		{"stencil aspect", texture(TextureDimension_2D, TextureFormat_Depth24PlusStencil8, 16, 16, 1, 1), 0, nil, false, layout(16, 16, 16, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aspect := TextureAspect_All
+			if tt.descriptor.Format == TextureFormat_Depth24PlusStencil8 {
+				aspect = TextureAspect_StencilOnly
+			}
+			v, err := CalculateTextureCopyLayout(tt.descriptor, tt.mipLevel, aspect, tt.copySize, tt.padded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != tt.expected {
+				t.Errorf("got %+v, expected %+v", v, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalculateTextureCopyLayoutErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor *TextureDescriptor
+		mipLevel   uint32
+		aspect     TextureAspect
+		copySize   *Extent3D
+	}{
+		{"mip level", &TextureDescriptor{Dimension: TextureDimension_2D, Format: TextureFormat_RGBA8Unorm, Size: Extent3D{4, 4, 1}, MipLevelCount: 1}, 1, TextureAspect_All, nil},
+		{"aspect", &TextureDescriptor{Dimension: TextureDimension_2D, Format: TextureFormat_Depth24PlusStencil8, Size: Extent3D{4, 4, 1}, MipLevelCount: 1}, 0, TextureAspect_DepthOnly, nil},
+		{"partial block", &TextureDescriptor{Dimension: TextureDimension_2D, Format: TextureFormat_BC1RGBAUnorm, Size: Extent3D{8, 8, 1}, MipLevelCount: 1}, 0, TextureAspect_All, &Extent3D{6, 4, 1}},
+		{"copy size", &TextureDescriptor{Dimension: TextureDimension_2D, Format: TextureFormat_RGBA8Unorm, Size: Extent3D{4, 4, 1}, MipLevelCount: 1}, 0, TextureAspect_All, &Extent3D{4, 4, 2}},
+		// rounding the width up to whole blocks overflows 32 bits.
+		{"block overflow", &TextureDescriptor{Dimension: TextureDimension_2D, Format: TextureFormat_BC1RGBAUnorm, Size: Extent3D{0xffffffff, 4, 1}, MipLevelCount: 1}, 0, TextureAspect_All, nil},
+		{"bytes per row overflow", &TextureDescriptor{Dimension: TextureDimension_1D, Format: TextureFormat_RGBA16Float, Size: Extent3D{1 << 29, 1, 1}, MipLevelCount: 1}, 0, TextureAspect_All, nil},
+		{"size overflow", &TextureDescriptor{Dimension: TextureDimension_2D, Format: TextureFormat_RGBA8Unorm, Size: Extent3D{1<<30 - 1, 0xffffffff, 0xffffffff}, MipLevelCount: 1}, 0, TextureAspect_All, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := CalculateTextureCopyLayout(tt.descriptor, tt.mipLevel, tt.aspect, tt.copySize, true)
+			if err == nil {
+				t.Errorf("got %+v, expected an error", v)
+			}
+		})
+	}
+}
+
+func TestPadUnpad(t *testing.T) {
+	v, err := CalculateTextureCopyLayout(&TextureDescriptor{
+		Dimension:     TextureDimension_2D,
+		Format:        TextureFormat_BC1RGBAUnorm,
+		Size:          Extent3D{Width: 12, Height: 8, DepthOrArrayLayers: 2},
+		MipLevelCount: 1,
+	}, 0, TextureAspect_All, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, v.RowSize*uint64(v.Rows)*uint64(v.Images))
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+
+	padded := v.Pad(data)
+	if uint64(len(padded)) != v.Size {
+		t.Fatalf("got %d padded bytes, expected %d", len(padded), v.Size)
+	}
+	// the second row of the second image.
+	row := padded[3*uint64(v.Layout.BytesPerRow):][:v.Layout.BytesPerRow]
+	if !bytes.Equal(row[:v.RowSize], data[3*v.RowSize:4*v.RowSize]) || row[v.RowSize] != 0 {
+		t.Errorf("got row %v", row)
+	}
+	if unpadded := v.Unpad(padded); !bytes.Equal(unpadded, data) {
+		t.Errorf("got %v, expected %v", unpadded, data)
+	}
+
+	// tightly packed rows are returned as is.
+	tight, err := CalculateTextureCopyLayout(&TextureDescriptor{
+		Dimension:     TextureDimension_2D,
+		Format:        TextureFormat_RGBA8Unorm,
+		Size:          Extent3D{Width: 64, Height: 2, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+	}, 0, TextureAspect_All, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = make([]byte, tight.Size)
+	if padded := tight.Pad(data); &padded[0] != &data[0] {
+		t.Error("got a copy of tightly packed data")
+	}
+	if unpadded := tight.Unpad(data); &unpadded[0] != &data[0] {
+		t.Error("got a copy of tightly packed data")
+	}
+}
@@ -0,0 +1,180 @@
+package wgpu
+
+import "sync"
+
+type UniformRingDescriptor struct {
+	Label string
+	// Size is the size of the buffers the uniforms are pushed to. It grows
+	// when the uniforms pushed between two submissions don't fit.
+	Size uint64
+}
+
+// UniformRing sub-allocates uniforms from a few large buffers, aligned to be
+// bound with dynamic offsets. The buffers used by a submission are reused
+// once the submission completes, so the device has to be polled for them to
+// be recycled, see (*Device).StartPoller.
+type UniformRing struct {
+	device    *Device
+	queue     *Queue
+	label     string
+	alignment uint64
+
+	mu       sync.Mutex
+	size     uint64
+	free     []*uniformBuffer
+	frame    []*uniformBuffer
+	released bool
+}
+
+type uniformBuffer struct {
+	buffer *Buffer
+	// data holds the contents of the buffer, written by Submit.
+	data    []byte
+	used    uint64
+	flushed uint64
+}
+
+func (p *Device) CreateUniformRing(descriptor *UniformRingDescriptor) *UniformRing {
+	p.checkReleased()
+	if descriptor == nil {
+		panic("got nil descriptor")
+	}
+
+	alignment := uint64(p.GetLimits().Limits.MinUniformBufferOffsetAlignment)
+	if alignment < CopyBufferAlignment {
+		alignment = CopyBufferAlignment
+	}
+	return &UniformRing{
+		device:    p,
+		queue:     p.GetQueue(),
+		label:     descriptor.Label,
+		alignment: alignment,
+		size:      alignUp(max64(descriptor.Size, 1), alignment),
+	}
+}
+
+// Push copies data to one of the buffers of the ring, and returns the buffer
+// and the offset to bind it at. The data is written to the buffer by Submit.
+func (p *UniformRing) Push(data []byte) (buffer *Buffer, offset uint32, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checkReleased()
+
+	size := uint64(len(data))
+	var b *uniformBuffer
+	if n := len(p.frame); n > 0 && p.frame[n-1].used+size <= uint64(len(p.frame[n-1].data)) {
+		b = p.frame[n-1]
+	} else {
+		b, err = p.next(size)
+		if err != nil {
+			return nil, 0, err
+		}
+		p.frame = append(p.frame, b)
+	}
+
+	start := b.used
+	copy(b.data[start:], data)
+	b.used = alignUp(start+size, p.alignment)
+	return b.buffer, uint32(start), nil
+}
+
+// next returns a buffer for at least size bytes, growing the buffers if the
+// current submission overflows them.
+func (p *UniformRing) next(size uint64) (*uniformBuffer, error) {
+	if len(p.frame) > 0 || size > p.size {
+		p.size = alignUp(max64(p.size*2, size), p.alignment)
+	}
+
+	for len(p.free) > 0 {
+		b := p.free[len(p.free)-1]
+		p.free = p.free[:len(p.free)-1]
+		if uint64(len(b.data)) >= p.size {
+			return b, nil
+		}
+		// outgrown
+		b.buffer.Release()
+	}
+
+	buffer, err := p.device.CreateBuffer(&BufferDescriptor{
+		Label: p.label,
+		Size:  p.size,
+		Usage: BufferUsage_Uniform | BufferUsage_CopyDst,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &uniformBuffer{buffer: buffer, data: make([]byte, p.size)}, nil
+}
+
+// Submit writes the uniforms pushed since the last submission to their
+// buffers and submits commands to the queue. The buffers are reused once the
+// submission completes.
+func (p *UniformRing) Submit(commands ...*CommandBuffer) (SubmissionIndex, error) {
+	p.mu.Lock()
+	p.checkReleased()
+	frame := p.frame
+	for _, b := range frame {
+		if b.used == b.flushed {
+			continue
+		}
+		if err := p.queue.WriteBuffer(b.buffer, b.flushed, b.data[b.flushed:b.used]); err != nil {
+			p.mu.Unlock()
+			return 0, err
+		}
+		b.flushed = b.used
+	}
+	p.frame = nil
+	p.mu.Unlock()
+
+	index := p.queue.Submit(commands...)
+	p.queue.OnSubmittedWorkDone(func(QueueWorkDoneStatus) {
+		p.recycle(frame)
+	})
+	return index, nil
+}
+
+func (p *UniformRing) recycle(frame []*uniformBuffer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, b := range frame {
+		if p.released || uint64(len(b.data)) < p.size {
+			b.buffer.Release()
+			continue
+		}
+		b.used, b.flushed = 0, 0
+		p.free = append(p.free, b)
+	}
+}
+
+// Release releases the buffers of the ring, the ones still used by a
+// submission once it completes.
+func (p *UniformRing) Release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.released {
+		return
+	}
+	p.released = true
+
+	for _, b := range p.free {
+		b.buffer.Release()
+	}
+	for _, b := range p.frame {
+		b.buffer.Release()
+	}
+	p.free, p.frame = nil, nil
+	p.queue.Release()
+}
+
+// alignUp rounds v up to a multiple of alignment.
+func alignUp(v, alignment uint64) uint64 {
+	return (v + alignment - 1) / alignment * alignment
+}
+
+func max64(x, y uint64) uint64 {
+	if x > y {
+		return x
+	}
+	return y
+}
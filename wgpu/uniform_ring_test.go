@@ -0,0 +1,113 @@
+package wgpu
+
+import (
+	"bytes"
+	"testing"
+)
+
+func push(t *testing.T, ring *UniformRing, size int) (*Buffer, uint32) {
+	t.Helper()
+
+	data := bytes.Repeat([]byte{byte(size)}, size)
+	buffer, offset, err := ring.Push(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(offset)+uint64(size) > buffer.GetSize() {
+		t.Fatalf("got %d bytes at offset %d of a buffer of %d bytes", size, offset, buffer.GetSize())
+	}
+	return buffer, offset
+}
+
+func TestUniformRingAlignment(t *testing.T) {
+	device := testDevice(t)
+	ring := device.CreateUniformRing(&UniformRingDescriptor{Size: 1})
+	defer ring.Release()
+	alignment := ring.alignment
+	if alignment < 4 || alignment&(alignment-1) != 0 {
+		t.Fatalf("got alignment %d", alignment)
+	}
+	if ring.size != alignment {
+		t.Errorf("got size %d, expected it rounded up to %d", ring.size, alignment)
+	}
+
+	ring = device.CreateUniformRing(&UniformRingDescriptor{Size: 4 * alignment})
+	defer ring.Release()
+	first, _ := push(t, ring, 4)
+	for i, size := range []int{100, int(alignment), 1} {
+		buffer, offset := push(t, ring, size)
+		if buffer != first {
+			t.Errorf("push %d: got another buffer", i)
+		}
+		if expected := uint64(i+1) * alignment; uint64(offset) != expected {
+			t.Errorf("push %d: got offset %d, expected %d", i, offset, expected)
+		}
+	}
+}
+
+func TestUniformRingLargerThanRing(t *testing.T) {
+	device := testDevice(t)
+	ring := device.CreateUniformRing(&UniformRingDescriptor{Size: 256})
+	defer ring.Release()
+
+	small, _ := push(t, ring, 16)
+	large, offset := push(t, ring, 1000)
+	if large == small || offset != 0 {
+		t.Errorf("got offset %d of the same buffer, expected a buffer of its own", offset)
+	}
+	if size := large.GetSize(); size < 1000 || size%ring.alignment != 0 {
+		t.Errorf("got a buffer of %d bytes", size)
+	}
+	// the data is kept until Submit writes it.
+	data := ring.frame[1].data[:1000]
+	if !bytes.Equal(data, bytes.Repeat([]byte{byte(1000 % 256)}, 1000)) {
+		t.Error("got other data")
+	}
+	if _, err := ring.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ring.frame) != 0 {
+		t.Errorf("got %d buffers left in the frame", len(ring.frame))
+	}
+}
+
+func TestUniformRingWrapAround(t *testing.T) {
+	device := testDevice(t)
+	ring := device.CreateUniformRing(&UniformRingDescriptor{Size: 1})
+	defer ring.Release()
+	alignment := int(ring.alignment)
+
+	// the second push overflows the buffer, growing the ring.
+	first, _ := push(t, ring, alignment)
+	second, offset := push(t, ring, alignment)
+	if second == first || offset != 0 {
+		t.Fatalf("got offset %d of the same buffer, expected another buffer", offset)
+	}
+	if ring.size != 2*ring.alignment {
+		t.Errorf("got size %d, expected %d", ring.size, 2*ring.alignment)
+	}
+	if _, err := ring.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	free := func() int {
+		ring.mu.Lock()
+		defer ring.mu.Unlock()
+		return len(ring.free)
+	}
+	for i := 0; i < 100 && free() == 0; i++ {
+		device.Poll(true, nil)
+	}
+
+	// once the submission completes, the buffer that fits the grown ring is
+	// reused from its start, and the outgrown one is released.
+	if free := free(); free != 1 {
+		t.Fatalf("got %d free buffers, expected 1", free)
+	}
+	buffer, offset := push(t, ring, 2*alignment)
+	if buffer != second || offset != 0 {
+		t.Errorf("got offset %d of another buffer, expected the recycled one", offset)
+	}
+	if first.ref != nil {
+		t.Error("outgrown buffer wasn't released")
+	}
+}
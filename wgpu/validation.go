@@ -0,0 +1,239 @@
+package wgpu
+
+/*
+
+#include "./lib/wgpu.h"
+
+extern void gowebgpu_error_callback_c(WGPUErrorType type, char const * message, void * userdata);
+
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime/cgo"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+type ValidationMode uint32
+
+const (
+	// ValidationMode_Immediate wraps every fallible call in its own error
+	// scope, so errors are returned by the call that caused them.
+	ValidationMode_Immediate ValidationMode = 0x00000000
+	// ValidationMode_Deferred skips the per-call error scopes. Errors are
+	// collected on Queue.Submit and Device.FlushErrors instead, and
+	// attributed to the operations recorded since the last collection.
+	ValidationMode_Deferred ValidationMode = 0x00000001
+)
+
+func (v ValidationMode) String() string {
+	switch v {
+	case ValidationMode_Immediate:
+		return "Immediate"
+	case ValidationMode_Deferred:
+		return "Deferred"
+	default:
+		return ""
+	}
+}
+
+// maxDeferredOps bounds the operations remembered between two collections,
+// only the most recent ones are kept.
+const maxDeferredOps = 256
+
+type deferredOp struct {
+	op    string
+	label string
+}
+
+type deferredValidation struct {
+	mu   sync.Mutex
+	ops  []deferredOp
+	errs []error
+}
+
+// SetValidationMode switches how validation errors are reported.
+//
+// In ValidationMode_Deferred the device keeps a single validation error scope
+// open. Native only keeps the first error of a scope, so at most one error is
+// reported per collection. The mode can't be entered while error scopes
+// pushed with PushErrorScope are open. Leaving it returns the errors that
+// were not yet returned by FlushErrors.
+func (p *Device) SetValidationMode(mode ValidationMode) error {
+	p.checkReleased()
+	p.validationMu.Lock()
+	defer p.validationMu.Unlock()
+
+	current := p.deferred.Load()
+	switch mode {
+	case ValidationMode_Deferred:
+		if current != nil {
+			return nil
+		}
+		if p.errorScopeDepth.Load() != 0 {
+			return &Error{
+				Type:    ErrorType_Validation,
+				Op:      "wgpu.(*Device).SetValidationMode()",
+				Message: "can't enter deferred validation with error scopes open",
+			}
+		}
+		p.errorScopeMu.Lock()
+		C.wgpuDevicePushErrorScope(p.ref, C.WGPUErrorFilter_Validation)
+		p.errorScopeMu.Unlock()
+		p.deferred.Store(&deferredValidation{})
+		return nil
+
+	case ValidationMode_Immediate:
+		if current == nil {
+			return nil
+		}
+		if p.errorScopeDepth.Load() != 0 {
+			return &Error{
+				Type:    ErrorType_Validation,
+				Op:      "wgpu.(*Device).SetValidationMode()",
+				Message: "can't leave deferred validation with error scopes open",
+			}
+		}
+		current.collect(p, "wgpu.(*Device).SetValidationMode()", false)
+		p.deferred.Store(nil)
+		return current.takeErrors()
+	}
+
+	return &Error{
+		Type:    ErrorType_Validation,
+		Op:      "wgpu.(*Device).SetValidationMode()",
+		Message: "unknown validation mode " + mode.String(),
+	}
+}
+
+func (p *Device) ValidationMode() ValidationMode {
+	p.checkReleased()
+	if p.deferred.Load() != nil {
+		return ValidationMode_Deferred
+	}
+	return ValidationMode_Immediate
+}
+
+// FlushErrors collects the errors of the operations recorded since the last
+// collection and returns them joined, together with the ones collected by
+// Queue.Submit since the last call. It returns nil in
+// ValidationMode_Immediate.
+func (p *Device) FlushErrors() error {
+	p.checkReleased()
+	p.validationMu.Lock()
+	defer p.validationMu.Unlock()
+
+	d := p.deferred.Load()
+	if d == nil {
+		return nil
+	}
+	if p.errorScopeDepth.Load() == 0 {
+		d.collect(p, "wgpu.(*Device).FlushErrors()", true)
+	}
+	return d.takeErrors()
+}
+
+// recordDeferred records op in ValidationMode_Deferred and reports whether
+// the caller must skip its own error scope.
+func (p *Device) recordDeferred(op string, label string) bool {
+	d := p.deferred.Load()
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	if len(d.ops) == maxDeferredOps {
+		copy(d.ops, d.ops[1:])
+		d.ops = d.ops[:len(d.ops)-1]
+	}
+	d.ops = append(d.ops, deferredOp{op: op, label: label})
+	d.mu.Unlock()
+	return true
+}
+
+// collectDeferred collects errors at op, a no-op unless deferred validation
+// is enabled and no user error scopes are open.
+func (p *Device) collectDeferred(op string) {
+	if p.deferred.Load() == nil {
+		return
+	}
+
+	p.validationMu.Lock()
+	defer p.validationMu.Unlock()
+
+	d := p.deferred.Load()
+	if d == nil || p.errorScopeDepth.Load() != 0 {
+		return
+	}
+	d.collect(p, op, true)
+}
+
+// collect pops the device's deferred scope and reopens it if push is set.
+// Must be called with validationMu held.
+func (d *deferredValidation) collect(device *Device, collectOp string, push bool) {
+	var typ ErrorType
+	var message string
+	var cb errorCallback = func(t ErrorType, m string) {
+		typ, message = t, m
+	}
+	errorCallbackHandle := cgo.NewHandle(cb)
+	defer errorCallbackHandle.Delete()
+
+	device.errorScopeMu.Lock()
+	C.wgpuDevicePopErrorScope(device.ref, C.WGPUErrorCallback(C.gowebgpu_error_callback_c), unsafe.Pointer(&errorCallbackHandle))
+	if push {
+		C.wgpuDevicePushErrorScope(device.ref, C.WGPUErrorFilter_Validation)
+	}
+	device.errorScopeMu.Unlock()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ops := d.ops
+	d.ops = d.ops[:0]
+	if typ == ErrorType_NoError {
+		return
+	}
+
+	recorded := make([]string, len(ops))
+	for i, v := range ops {
+		recorded[i] = v.op
+	}
+	err := &Error{
+		Type:     typ,
+		Op:       collectOp,
+		Message:  message,
+		Recorded: recorded,
+	}
+	if blame, ok := blameDeferred(ops, message); ok {
+		err.Op = blame.op
+		err.Label = blame.label
+	}
+	d.errs = append(d.errs, err)
+}
+
+func (d *deferredValidation) takeErrors() error {
+	d.mu.Lock()
+	errs := d.errs
+	d.errs = nil
+	d.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// blameDeferred picks the operation an error message most likely belongs to:
+// the only recorded one, or the last one whose label is quoted in message.
+func blameDeferred(ops []deferredOp, message string) (deferredOp, bool) {
+	if len(ops) == 1 {
+		return ops[0], true
+	}
+	for i := len(ops) - 1; i >= 0; i-- {
+		if ops[i].label != "" && strings.Contains(message, "'"+ops[i].label+"'") {
+			return ops[i], true
+		}
+	}
+	return deferredOp{}, false
+}
@@ -1,5 +1,7 @@
 package wgpu
 
+//go:generate go run github.com/rajveermalviya/go-webgpu/cmd/gen_enums -i lib/wgpu.h -o enums.go -pkg wgpu
+
 /*
 
 // Android
@@ -106,26 +108,141 @@ func GetVersion() Version {
 }
 
 type (
-	BindGroup       struct{ ref C.WGPUBindGroup }
-	BindGroupLayout struct{ ref C.WGPUBindGroupLayout }
-	CommandBuffer   struct{ ref C.WGPUCommandBuffer }
-	PipelineLayout  struct{ ref C.WGPUPipelineLayout }
-	QuerySet        struct{ ref C.WGPUQuerySet }
-	RenderBundle    struct{ ref C.WGPURenderBundle }
-	Sampler         struct{ ref C.WGPUSampler }
-	ShaderModule    struct{ ref C.WGPUShaderModule }
-	TextureView     struct{ ref C.WGPUTextureView }
+	BindGroup struct {
+		ref   C.WGPUBindGroup
+		label string
+		child *deviceChild
+	}
+
+	BindGroupLayout struct {
+		ref   C.WGPUBindGroupLayout
+		label string
+		child *deviceChild
+	}
+
+	CommandBuffer struct {
+		ref   C.WGPUCommandBuffer
+		label string
+		child *deviceChild
+	}
+
+	PipelineLayout struct {
+		ref   C.WGPUPipelineLayout
+		label string
+		child *deviceChild
+	}
+
+	QuerySet struct {
+		ref   C.WGPUQuerySet
+		label string
+		child *deviceChild
+	}
+
+	RenderBundle struct {
+		ref   C.WGPURenderBundle
+		label string
+		child *deviceChild
+	}
+
+	Sampler struct {
+		ref   C.WGPUSampler
+		label string
+		child *deviceChild
+	}
+
+	ShaderModule struct {
+		ref   C.WGPUShaderModule
+		label string
+		child *deviceChild
+	}
+
+	TextureView struct {
+		ref   C.WGPUTextureView
+		label string
+		child *deviceChild
+	}
 )
 
-func (p *BindGroup) Release()       { C.wgpuBindGroupRelease(p.ref) }
-func (p *BindGroupLayout) Release() { C.wgpuBindGroupLayoutRelease(p.ref) }
-func (p *CommandBuffer) Release()   { C.wgpuCommandBufferRelease(p.ref) }
-func (p *PipelineLayout) Release()  { C.wgpuPipelineLayoutRelease(p.ref) }
-func (p *QuerySet) Release()        { C.wgpuQuerySetRelease(p.ref) }
-func (p *RenderBundle) Release()    { C.wgpuRenderBundleRelease(p.ref) }
-func (p *Sampler) Release()         { C.wgpuSamplerRelease(p.ref) }
-func (p *ShaderModule) Release()    { C.wgpuShaderModuleRelease(p.ref) }
-func (p *TextureView) Release()     { C.wgpuTextureViewRelease(p.ref) }
+func (p *BindGroup) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *BindGroupLayout) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *CommandBuffer) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *PipelineLayout) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *QuerySet) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *RenderBundle) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *Sampler) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *ShaderModule) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
+
+func (p *TextureView) Release() {
+	if p.ref == nil {
+		return
+	}
+	untrackObject(p)
+	p.child.Release()
+	p.ref = nil
+}
 
 // common types
 
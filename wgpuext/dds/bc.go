@@ -0,0 +1,437 @@
+package dds
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+)
+
+// The decoders write the 16 texels of a 4x4 block in row order to px, 4
+// channels per texel.
+
+// decodeBC1 decodes the color block of BC1, BC2 and BC3. BC2 and BC3 blocks
+// always use four colors, BC1 blocks use three and transparent black if
+// their first color isn't greater than the second.
+func decodeBC1(block []byte, px *[16][4]uint8, bc1 bool) {
+	c0, c1 := binary.LittleEndian.Uint16(block), binary.LittleEndian.Uint16(block[2:])
+	var colors [4][4]uint8
+	colors[0] = rgb565(c0)
+	colors[1] = rgb565(c1)
+	for c := 0; c < 3; c++ {
+		a, b := uint32(colors[0][c]), uint32(colors[1][c])
+		if c0 > c1 || !bc1 {
+			colors[2][c] = uint8((2*a + b + 1) / 3)
+			colors[3][c] = uint8((a + 2*b + 1) / 3)
+		} else {
+			colors[2][c] = uint8((a + b + 1) / 2)
+		}
+	}
+	colors[2][3] = 255
+	if c0 > c1 || !bc1 {
+		colors[3][3] = 255
+	}
+
+	indices := binary.LittleEndian.Uint32(block[4:])
+	for i := range px {
+		px[i] = colors[indices>>(2*i)&3]
+	}
+}
+
+func rgb565(c uint16) [4]uint8 {
+	r, g, b := uint8(c>>11), uint8(c>>5&0x3f), uint8(c&0x1f)
+	return [4]uint8{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 255}
+}
+
+// decodeBC2Alpha decodes the explicit alpha block of BC2 to channel 3.
+func decodeBC2Alpha(block []byte, px *[16][4]uint8) {
+	alpha := binary.LittleEndian.Uint64(block)
+	for i := range px {
+		px[i][3] = uint8(alpha>>(4*i)&0xf) * 17
+	}
+}
+
+// decodeBC4 decodes a BC4 block, or the alpha block of BC3, to channel. If
+// signed is set, values are stored as int8.
+func decodeBC4(block []byte, px *[16][4]uint8, channel int, signed bool) {
+	var values [8]int32
+	lo, hi := int32(-127), int32(127)
+	if signed {
+		values[0], values[1] = max(int32(int8(block[0])), lo), max(int32(int8(block[1])), lo)
+	} else {
+		lo, hi = 0, 255
+		values[0], values[1] = int32(block[0]), int32(block[1])
+	}
+	if values[0] > values[1] {
+		for i := int32(1); i < 7; i++ {
+			values[i+1] = divRound((7-i)*values[0]+i*values[1], 7)
+		}
+	} else {
+		for i := int32(1); i < 5; i++ {
+			values[i+1] = divRound((5-i)*values[0]+i*values[1], 5)
+		}
+		values[6], values[7] = lo, hi
+	}
+
+	indices := uint64(binary.LittleEndian.Uint16(block[2:])) | uint64(binary.LittleEndian.Uint32(block[4:]))<<16
+	for i := range px {
+		px[i][channel] = uint8(values[indices>>(3*i)&7])
+	}
+}
+
+// divRound divides v by d, rounding half away from zero.
+func divRound(v, d int32) int32 {
+	if v < 0 {
+		return -((-v + d/2) / d)
+	}
+	return (v + d/2) / d
+}
+
+// bitReader reads the bits of a 128-bit block, starting with the least
+// significant bit of its first byte.
+type bitReader struct {
+	lo, hi uint64
+}
+
+func newBitReader(block []byte) bitReader {
+	return bitReader{binary.LittleEndian.Uint64(block), binary.LittleEndian.Uint64(block[8:])}
+}
+
+func (r *bitReader) read(n int) int {
+	if n == 0 {
+		return 0
+	}
+	v := int(r.lo & (1<<n - 1))
+	r.lo = r.lo>>n | r.hi<<(64-n)
+	r.hi >>= n
+	return v
+}
+
+var weights = [5][]int{
+	2: {0, 21, 43, 64},
+	3: {0, 9, 18, 27, 37, 46, 55, 64},
+	4: {0, 4, 9, 13, 17, 21, 26, 30, 34, 38, 43, 47, 51, 55, 60, 64},
+}
+
+func interpolate(a, b, weight int) int {
+	return (a*(64-weight) + b*weight + 32) >> 6
+}
+
+type bc7Mode struct {
+	subsets, partitionBits, rotationBits, indexModeBits int
+	colorBits, alphaBits                                int
+	endpointPBits, sharedPBits                          bool
+	indexBits, index2Bits                               int
+}
+
+var bc7Modes = [8]bc7Mode{
+	{subsets: 3, partitionBits: 4, colorBits: 4, endpointPBits: true, indexBits: 3},
+	{subsets: 2, partitionBits: 6, colorBits: 6, sharedPBits: true, indexBits: 3},
+	{subsets: 3, partitionBits: 6, colorBits: 5, indexBits: 2},
+	{subsets: 2, partitionBits: 6, colorBits: 7, endpointPBits: true, indexBits: 2},
+	{subsets: 1, rotationBits: 2, indexModeBits: 1, colorBits: 5, alphaBits: 6, indexBits: 2, index2Bits: 3},
+	{subsets: 1, rotationBits: 2, colorBits: 7, alphaBits: 8, indexBits: 2, index2Bits: 2},
+	{subsets: 1, colorBits: 7, alphaBits: 7, endpointPBits: true, indexBits: 4},
+	{subsets: 2, partitionBits: 6, colorBits: 5, alphaBits: 5, endpointPBits: true, indexBits: 2},
+}
+
+func decodeBC7(block []byte, px *[16][4]uint8) {
+	r := newBitReader(block)
+	mode := 0
+	for mode < 8 && r.read(1) == 0 {
+		mode++
+	}
+	if mode == 8 {
+		// reserved mode, decoded as transparent black.
+		*px = [16][4]uint8{}
+		return
+	}
+	m := bc7Modes[mode]
+	partition := r.read(m.partitionBits)
+	rotation := r.read(m.rotationBits)
+	indexMode := r.read(m.indexModeBits)
+
+	// endpoints[subset*2+endpoint][channel]
+	var endpoints [6][4]int
+	for c := 0; c < 3; c++ {
+		for e := 0; e < m.subsets*2; e++ {
+			endpoints[e][c] = r.read(m.colorBits)
+		}
+	}
+	for e := 0; e < m.subsets*2; e++ {
+		if m.alphaBits != 0 {
+			endpoints[e][3] = r.read(m.alphaBits)
+		} else {
+			endpoints[e][3] = 255
+		}
+	}
+
+	colorBits, alphaBits := m.colorBits, m.alphaBits
+	if m.endpointPBits || m.sharedPBits {
+		var pbits [6]int
+		for e := 0; e < m.subsets*2; e++ {
+			if m.endpointPBits {
+				pbits[e] = r.read(1)
+			} else if e%2 == 0 {
+				pbits[e] = r.read(1)
+				pbits[e+1] = pbits[e]
+			}
+		}
+		for e := 0; e < m.subsets*2; e++ {
+			for c := 0; c < 3; c++ {
+				endpoints[e][c] = endpoints[e][c]<<1 | pbits[e]
+			}
+			if m.alphaBits != 0 {
+				endpoints[e][3] = endpoints[e][3]<<1 | pbits[e]
+			}
+		}
+		colorBits++
+		if m.alphaBits != 0 {
+			alphaBits++
+		}
+	}
+	for e := 0; e < m.subsets*2; e++ {
+		for c := 0; c < 3; c++ {
+			endpoints[e][c] = expand(endpoints[e][c], colorBits)
+		}
+		if m.alphaBits != 0 {
+			endpoints[e][3] = expand(endpoints[e][3], alphaBits)
+		}
+	}
+
+	var subsets [16]uint8
+	anchors := [3]int{0, 0, 0}
+	switch m.subsets {
+	case 2:
+		subsets = partitions2[partition]
+		anchors[1] = int(anchors2[partition])
+	case 3:
+		subsets = partitions3[partition]
+		anchors[1], anchors[2] = int(anchors3a[partition]), int(anchors3b[partition])
+	}
+
+	var indices, indices2 [16]int
+	for i := range indices {
+		n := m.indexBits
+		if i == anchors[subsets[i]] {
+			n--
+		}
+		indices[i] = r.read(n)
+	}
+	if m.index2Bits != 0 {
+		for i := range indices2 {
+			n := m.index2Bits
+			if i == 0 {
+				n--
+			}
+			indices2[i] = r.read(n)
+		}
+	}
+
+	for i := range px {
+		e := endpoints[subsets[i]*2 : subsets[i]*2+2]
+		colorWeight := weights[m.indexBits][indices[i]]
+		alphaWeight := colorWeight
+		if m.index2Bits != 0 {
+			alphaWeight = weights[m.index2Bits][indices2[i]]
+			if indexMode != 0 {
+				colorWeight, alphaWeight = alphaWeight, colorWeight
+			}
+		}
+		for c := 0; c < 3; c++ {
+			px[i][c] = uint8(interpolate(e[0][c], e[1][c], colorWeight))
+		}
+		px[i][3] = uint8(interpolate(e[0][3], e[1][3], alphaWeight))
+		if rotation != 0 {
+			px[i][3], px[i][rotation-1] = px[i][rotation-1], px[i][3]
+		}
+	}
+}
+
+// expand expands a value of bits bits to 8 bits.
+func expand(v, bits int) int {
+	v <<= 8 - bits
+	return v | v>>bits
+}
+
+type bc6hMode struct {
+	transformed bool
+	// bits is the precision of the endpoints, delta the precision of the
+	// deltas of the r, g and b channels of transformed endpoints.
+	bits   int
+	delta  [3]int
+	fields []bc6hField
+}
+
+// bc6hField is a range of the bits of a channel of an endpoint, read from
+// first to last.
+type bc6hField struct {
+	endpoint, channel int
+	first, last       int
+}
+
+// bc6hModes are the modes of BC6H, by the value of their 5 mode bits, with
+// the layout of their endpoints in the notation of the format specification.
+var bc6hModes = map[int]*bc6hMode{
+	0b00000: newBC6HMode(true, 10, 5, 5, 5, "gy4 by4 bz4 rw9:0 gw9:0 bw9:0 rx4:0 gz4 gy3:0 gx4:0 bz0 gz3:0 bx4:0 bz1 by3:0 ry4:0 bz2 rz4:0 bz3"),
+	0b00001: newBC6HMode(true, 7, 6, 6, 6, "gy5 gz4 gz5 rw6:0 bz0 bz1 by4 gw6:0 by5 bz2 gy4 bw6:0 bz3 bz5 bz4 rx5:0 gy3:0 gx5:0 gz3:0 bx5:0 by3:0 ry5:0 rz5:0"),
+	0b00010: newBC6HMode(true, 11, 5, 4, 4, "rw9:0 gw9:0 bw9:0 rx4:0 rw10 gy3:0 gx3:0 gw10 bz0 gz3:0 bx3:0 bw10 bz1 by3:0 ry4:0 bz2 rz4:0 bz3"),
+	0b00110: newBC6HMode(true, 11, 4, 5, 4, "rw9:0 gw9:0 bw9:0 rx3:0 rw10 gz4 gy3:0 gx4:0 gw10 gz3:0 bx3:0 bw10 bz1 by3:0 ry3:0 bz0 bz2 rz3:0 gy4 bz3"),
+	0b01010: newBC6HMode(true, 11, 4, 4, 5, "rw9:0 gw9:0 bw9:0 rx3:0 rw10 by4 gy3:0 gx3:0 gw10 bz0 gz3:0 bx4:0 bw10 by3:0 ry3:0 bz1 bz2 rz3:0 bz4 bz3"),
+	0b01110: newBC6HMode(true, 9, 5, 5, 5, "rw8:0 by4 gw8:0 gy4 bw8:0 bz4 rx4:0 gz4 gy3:0 gx4:0 bz0 gz3:0 bx4:0 bz1 by3:0 ry4:0 bz2 rz4:0 bz3"),
+	0b10010: newBC6HMode(true, 8, 6, 5, 5, "rw7:0 gz4 by4 gw7:0 bz2 gy4 bw7:0 bz3 bz4 rx5:0 gy3:0 gx4:0 bz0 gz3:0 bx4:0 bz1 by3:0 ry5:0 rz5:0"),
+	0b10110: newBC6HMode(true, 8, 5, 6, 5, "rw7:0 bz0 by4 gw7:0 gy5 gy4 bw7:0 gz5 bz4 rx4:0 gz4 gy3:0 gx5:0 gz3:0 bx4:0 bz1 by3:0 ry4:0 bz2 rz4:0 bz3"),
+	0b11010: newBC6HMode(true, 8, 5, 5, 6, "rw7:0 bz1 by4 gw7:0 by5 gy4 bw7:0 bz5 bz4 rx4:0 gz4 gy3:0 gx4:0 bz0 gz3:0 bx5:0 by3:0 ry4:0 bz2 rz4:0 bz3"),
+	0b11110: newBC6HMode(false, 6, 6, 6, 6, "rw5:0 gz4 bz0 bz1 by4 gw5:0 gy5 by5 bz2 gy4 bw5:0 gz5 bz3 bz5 bz4 rx5:0 gy3:0 gx5:0 gz3:0 bx5:0 by3:0 ry5:0 rz5:0"),
+	0b00011: newBC6HMode(false, 10, 10, 10, 10, "rw9:0 gw9:0 bw9:0 rx9:0 gx9:0 bx9:0"),
+	0b00111: newBC6HMode(true, 11, 9, 9, 9, "rw9:0 gw9:0 bw9:0 rx8:0 rw10 gx8:0 gw10 bx8:0 bw10"),
+	0b01011: newBC6HMode(true, 12, 8, 8, 8, "rw9:0 gw9:0 bw9:0 rx7:0 rw10:11 gx7:0 gw10:11 bx7:0 bw10:11"),
+	0b01111: newBC6HMode(true, 16, 4, 4, 4, "rw9:0 gw9:0 bw9:0 rx3:0 rw10:15 gx3:0 gw10:15 bx3:0 bw10:15"),
+}
+
+// newBC6HMode parses layout, fields like rw9:0 are the bits 0 to 9 of the r
+// channel of the endpoint w, from the least significant bit, rw10:15 the bits
+// 15 to 10.
+func newBC6HMode(transformed bool, bits, deltaR, deltaG, deltaB int, layout string) *bc6hMode {
+	m := &bc6hMode{transformed: transformed, bits: bits, delta: [3]int{deltaR, deltaG, deltaB}}
+	for _, field := range strings.Fields(layout) {
+		f := bc6hField{
+			channel:  strings.IndexByte("rgb", field[0]),
+			endpoint: strings.IndexByte("wxyz", field[1]),
+		}
+		hi, lo, ok := strings.Cut(field[2:], ":")
+		if !ok {
+			lo = hi
+		}
+		f.last, _ = strconv.Atoi(hi)
+		f.first, _ = strconv.Atoi(lo)
+		m.fields = append(m.fields, f)
+	}
+	return m
+}
+
+// decodeBC6H decodes a BC6H block to the half-precision float bits of the
+// rgb channels of its texels.
+func decodeBC6H(block []byte, px *[16][3]uint16, signed bool) {
+	r := newBitReader(block)
+	modeBits := r.read(2)
+	if modeBits > 1 {
+		modeBits |= r.read(3) << 2
+	}
+	m, ok := bc6hModes[modeBits]
+	if !ok {
+		// reserved modes, decoded as black.
+		*px = [16][3]uint16{}
+		return
+	}
+
+	// endpoints[endpoint][channel], with the endpoints w, x, y and z.
+	var endpoints [4][3]int
+	for _, f := range m.fields {
+		step := 1
+		if f.last < f.first {
+			step = -1
+		}
+		for bit := f.first; ; bit += step {
+			endpoints[f.endpoint][f.channel] |= r.read(1) << bit
+			if bit == f.last {
+				break
+			}
+		}
+	}
+	// the modes whose 2 low bits aren't both set have two regions.
+	regions := 1
+	partition := 0
+	if modeBits&3 != 3 {
+		regions = 2
+		partition = r.read(5)
+	}
+
+	// transformed endpoints are deltas from the endpoint w.
+	for e := 0; e < regions*2; e++ {
+		for c := 0; c < 3; c++ {
+			if e == 0 || !m.transformed {
+				if signed {
+					endpoints[e][c] = signExtend(endpoints[e][c], m.bits)
+				}
+				continue
+			}
+			v := signExtend(endpoints[e][c], m.delta[c])
+			v = (endpoints[0][c] + v) & (1<<m.bits - 1)
+			if signed {
+				v = signExtend(v, m.bits)
+			}
+			endpoints[e][c] = v
+		}
+	}
+	for e := 0; e < regions*2; e++ {
+		for c := 0; c < 3; c++ {
+			endpoints[e][c] = unquantizeBC6H(endpoints[e][c], m.bits, signed)
+		}
+	}
+
+	indexBits := 4
+	anchor := 0
+	var subsets [16]uint8
+	if regions == 2 {
+		indexBits = 3
+		subsets = partitions2[partition]
+		anchor = int(anchors2[partition])
+	}
+	for i := range px {
+		n := indexBits
+		if i == 0 || i == anchor {
+			n--
+		}
+		weight := weights[indexBits][r.read(n)]
+		e := endpoints[subsets[i]*2 : subsets[i]*2+2]
+		for c := 0; c < 3; c++ {
+			v := interpolate(e[0][c], e[1][c], weight)
+			if signed {
+				var sign uint16
+				if v < 0 {
+					sign, v = 0x8000, -v
+				}
+				px[i][c] = sign | uint16(v*31>>5)
+			} else {
+				px[i][c] = uint16(v * 31 >> 6)
+			}
+		}
+	}
+}
+
+func signExtend(v, bits int) int {
+	shift := 64 - bits
+	return int(int64(v)<<shift) >> shift
+}
+
+// unquantizeBC6H expands an endpoint of bits bits to 16 bits.
+func unquantizeBC6H(v, bits int, signed bool) int {
+	if !signed {
+		switch {
+		case bits >= 15, v == 0:
+			return v
+		case v == 1<<bits-1:
+			return 0xffff
+		}
+		return (v<<16 + 0x8000) >> bits
+	}
+
+	if bits >= 16 {
+		return v
+	}
+	negative := v < 0
+	if negative {
+		v = -v
+	}
+	switch {
+	case v == 0:
+	case v >= 1<<(bits-1)-1:
+		v = 0x7fff
+	default:
+		v = (v<<15 + 0x4000) >> (bits - 1)
+	}
+	if negative {
+		v = -v
+	}
+	return v
+}
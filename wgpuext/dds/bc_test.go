@@ -0,0 +1,430 @@
+package dds
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+// bitWriter writes the bits of a 128-bit block, starting with the least
+// significant bit of its first byte.
+type bitWriter struct {
+	block [16]byte
+	n     int
+}
+
+// put writes the bits low bits of v, from the least significant bit.
+func (w *bitWriter) put(v, bits int) {
+	for i := 0; i < bits; i++ {
+		w.block[w.n/8] |= byte(v>>i&1) << (w.n % 8)
+		w.n++
+	}
+}
+
+// putReversed writes the bits hi down to lo of v, from bit hi.
+func (w *bitWriter) putReversed(v, hi, lo int) {
+	for bit := hi; bit >= lo; bit-- {
+		w.put(v>>bit, 1)
+	}
+}
+
+func (w *bitWriter) bytes(t *testing.T) []byte {
+	t.Helper()
+	if w.n != 128 {
+		t.Fatalf("wrote %d bits, expected 128", w.n)
+	}
+	return w.block[:]
+}
+
+func bc1Block(c0, c1 uint16, indices [16]int) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, c0)
+	b = binary.LittleEndian.AppendUint16(b, c1)
+	var v uint32
+	for i, index := range indices {
+		v |= uint32(index) << (2 * i)
+	}
+	return binary.LittleEndian.AppendUint32(b, v)
+}
+
+func bc4Block(v0, v1 byte, indices [16]int) []byte {
+	var v uint64
+	for i, index := range indices {
+		v |= uint64(index) << (3 * i)
+	}
+	b := binary.LittleEndian.AppendUint64([]byte{v0, v1}, v)
+	return b[:8]
+}
+
+// pattern returns the indices repeating values.
+func pattern(values ...int) (indices [16]int) {
+	for i := range indices {
+		indices[i] = values[i%len(values)]
+	}
+	return indices
+}
+
+// repeat returns the texels repeating texels.
+func repeat(texels ...[4]uint8) (px [16][4]uint8) {
+	for i := range px {
+		px[i] = texels[i%len(texels)]
+	}
+	return px
+}
+
+func TestDecodeBC(t *testing.T) {
+	ramp := pattern(0, 1, 2, 3)
+	ramp8 := pattern(0, 1, 2, 3, 4, 5, 6, 7)
+
+	tests := []struct {
+		name     string
+		format   wgpu.TextureFormat
+		block    func(t *testing.T) []byte
+		expected [16][4]uint8
+	}{
+		{
+			name:   "BC1 four colors",
+			format: wgpu.TextureFormat_BC1RGBAUnorm,
+			block:  func(t *testing.T) []byte { return bc1Block(0xf800, 0x001f, ramp) },
+			expected: repeat(
+				[4]uint8{255, 0, 0, 255}, [4]uint8{0, 0, 255, 255},
+				[4]uint8{170, 0, 85, 255}, [4]uint8{85, 0, 170, 255},
+			),
+		},
+		{
+			name:   "BC1 three colors",
+			format: wgpu.TextureFormat_BC1RGBAUnormSrgb,
+			block:  func(t *testing.T) []byte { return bc1Block(0x0000, 0x8410, ramp) },
+			expected: repeat(
+				[4]uint8{0, 0, 0, 255}, [4]uint8{132, 130, 132, 255},
+				[4]uint8{66, 65, 66, 255}, [4]uint8{0, 0, 0, 0},
+			),
+		},
+		{
+			name:   "BC2",
+			format: wgpu.TextureFormat_BC2RGBAUnorm,
+			block: func(t *testing.T) []byte {
+				// alpha i for texel i, and four colors although c0 < c1.
+				alpha := []byte{0x10, 0x32, 0x54, 0x76, 0x98, 0xba, 0xdc, 0xfe}
+				return append(alpha, bc1Block(0x0000, 0xffff, ramp)...)
+			},
+			expected: [16][4]uint8{
+				{0, 0, 0, 0}, {255, 255, 255, 17}, {85, 85, 85, 34}, {170, 170, 170, 51},
+				{0, 0, 0, 68}, {255, 255, 255, 85}, {85, 85, 85, 102}, {170, 170, 170, 119},
+				{0, 0, 0, 136}, {255, 255, 255, 153}, {85, 85, 85, 170}, {170, 170, 170, 187},
+				{0, 0, 0, 204}, {255, 255, 255, 221}, {85, 85, 85, 238}, {170, 170, 170, 255},
+			},
+		},
+		{
+			name:   "BC3",
+			format: wgpu.TextureFormat_BC3RGBAUnorm,
+			block: func(t *testing.T) []byte {
+				return append(bc4Block(210, 0, ramp8), bc1Block(0xf800, 0x001f, ramp)...)
+			},
+			expected: [16][4]uint8{
+				{255, 0, 0, 210}, {0, 0, 255, 0}, {170, 0, 85, 180}, {85, 0, 170, 150},
+				{255, 0, 0, 120}, {0, 0, 255, 90}, {170, 0, 85, 60}, {85, 0, 170, 30},
+				{255, 0, 0, 210}, {0, 0, 255, 0}, {170, 0, 85, 180}, {85, 0, 170, 150},
+				{255, 0, 0, 120}, {0, 0, 255, 90}, {170, 0, 85, 60}, {85, 0, 170, 30},
+			},
+		},
+		{
+			name:   "BC4 six values",
+			format: wgpu.TextureFormat_BC4RUnorm,
+			block:  func(t *testing.T) []byte { return bc4Block(0, 250, ramp8) },
+			expected: repeat(
+				[4]uint8{0, 0, 0, 255}, [4]uint8{250, 0, 0, 255}, [4]uint8{50, 0, 0, 255}, [4]uint8{100, 0, 0, 255},
+				[4]uint8{150, 0, 0, 255}, [4]uint8{200, 0, 0, 255}, [4]uint8{0, 0, 0, 255}, [4]uint8{255, 0, 0, 255},
+			),
+		},
+		{
+			name:   "BC4 signed",
+			format: wgpu.TextureFormat_BC4RSnorm,
+			// -128 is clamped to -127.
+			block: func(t *testing.T) []byte { return bc4Block(0x80, 0x64, ramp8) },
+			expected: repeat(
+				[4]uint8{0x81, 0, 0, 127}, [4]uint8{100, 0, 0, 127}, [4]uint8{0xae, 0, 0, 127}, [4]uint8{0xdc, 0, 0, 127},
+				[4]uint8{0x09, 0, 0, 127}, [4]uint8{0x37, 0, 0, 127}, [4]uint8{0x81, 0, 0, 127}, [4]uint8{127, 0, 0, 127},
+			),
+		},
+		{
+			name:   "BC5",
+			format: wgpu.TextureFormat_BC5RGUnorm,
+			block: func(t *testing.T) []byte {
+				return append(bc4Block(210, 0, ramp8), bc4Block(0, 250, ramp8)...)
+			},
+			expected: repeat(
+				[4]uint8{210, 0, 0, 255}, [4]uint8{0, 250, 0, 255}, [4]uint8{180, 50, 0, 255}, [4]uint8{150, 100, 0, 255},
+				[4]uint8{120, 150, 0, 255}, [4]uint8{90, 200, 0, 255}, [4]uint8{60, 0, 0, 255}, [4]uint8{30, 255, 0, 255},
+			),
+		},
+		{
+			name:   "BC5 signed",
+			format: wgpu.TextureFormat_BC5RGSnorm,
+			block: func(t *testing.T) []byte {
+				// six values from -100 to 100, and eight from 70 to -70.
+				return append(bc4Block(0x9c, 0x64, ramp8), bc4Block(70, 0xba, ramp8)...)
+			},
+			expected: repeat(
+				[4]uint8{0x9c, 70, 0, 127}, [4]uint8{100, 0xba, 0, 127}, [4]uint8{0xc4, 50, 0, 127}, [4]uint8{0xec, 30, 0, 127},
+				[4]uint8{20, 10, 0, 127}, [4]uint8{60, 0xf6, 0, 127}, [4]uint8{0x81, 0xe2, 0, 127}, [4]uint8{127, 0xce, 0, 127},
+			),
+		},
+		{
+			name:   "BC7 mode 6",
+			format: wgpu.TextureFormat_BC7RGBAUnorm,
+			block: func(t *testing.T) []byte {
+				var w bitWriter
+				w.put(1<<6, 7)
+				for _, v := range []int{0, 127, 0, 0, 63, 63, 127, 127} { // r0 r1 g0 g1 b0 b1 a0 a1
+					w.put(v, 7)
+				}
+				w.put(0, 1) // p0
+				w.put(1, 1) // p1
+				w.put(0, 3) // anchor
+				for i := 1; i < 16; i++ {
+					w.put(i, 4)
+				}
+				return w.bytes(t)
+			},
+			expected: [16][4]uint8{
+				{0, 0, 126, 254}, {16, 0, 126, 254}, {36, 0, 126, 254}, {52, 0, 126, 254},
+				{68, 0, 126, 254}, {84, 0, 126, 254}, {104, 0, 126, 254}, {120, 0, 126, 254},
+				{135, 1, 127, 255}, {151, 1, 127, 255}, {171, 1, 127, 255}, {187, 1, 127, 255},
+				{203, 1, 127, 255}, {219, 1, 127, 255}, {239, 1, 127, 255}, {255, 1, 127, 255},
+			},
+		},
+		{
+			name:   "BC7 mode 1",
+			format: wgpu.TextureFormat_BC7RGBAUnormSrgb,
+			block: func(t *testing.T) []byte {
+				var w bitWriter
+				w.put(1<<1, 2)
+				w.put(0, 6) // partition, columns 2 and 3 in subset 1
+				for _, v := range []int{0, 63, 0, 0, 0, 0, 0, 63, 0, 0, 0, 0} {
+					w.put(v, 6)
+				}
+				w.put(1, 1) // p of subset 0
+				w.put(0, 1) // p of subset 1
+				indices := pattern(0, 1, 2, 3, 4, 5, 6, 7)
+				indices[15] = 3
+				for i, index := range indices {
+					if i == 0 || i == 15 {
+						w.put(index, 2)
+					} else {
+						w.put(index, 3)
+					}
+				}
+				return w.bytes(t)
+			},
+			expected: [16][4]uint8{
+				{2, 2, 2, 255}, {38, 2, 2, 255}, {0, 71, 0, 255}, {0, 107, 0, 255},
+				{148, 2, 2, 255}, {184, 2, 2, 255}, {0, 217, 0, 255}, {0, 253, 0, 255},
+				{2, 2, 2, 255}, {38, 2, 2, 255}, {0, 71, 0, 255}, {0, 107, 0, 255},
+				{148, 2, 2, 255}, {184, 2, 2, 255}, {0, 217, 0, 255}, {0, 107, 0, 255},
+			},
+		},
+		{
+			name:   "BC7 mode 5 rotated",
+			format: wgpu.TextureFormat_BC7RGBAUnorm,
+			block: func(t *testing.T) []byte {
+				var w bitWriter
+				w.put(1<<5, 6)
+				w.put(1, 2) // rotation, swapping r and a
+				for _, v := range []int{0, 127, 0, 0, 0, 0} {
+					w.put(v, 7)
+				}
+				w.put(200, 8)
+				w.put(100, 8)
+				w.put(0, 1)
+				for i := 1; i < 16; i++ {
+					w.put(i%4, 2)
+				}
+				w.put(0, 31)
+				return w.bytes(t)
+			},
+			expected: repeat(
+				[4]uint8{200, 0, 0, 0}, [4]uint8{200, 0, 0, 84}, [4]uint8{200, 0, 0, 171}, [4]uint8{200, 0, 0, 255},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, texelSize, decode := blockDecoder(tt.format)
+			if decode == nil || texelSize != 4 {
+				t.Fatalf("no decoder of %s to 4 byte texels", tt.format)
+			}
+			if srgb := format.Info().Srgb; srgb != tt.format.Info().Srgb {
+				t.Errorf("decoded to %s", format)
+			}
+
+			var texels [16][8]byte
+			decode(tt.block(t), &texels)
+			for i := range texels {
+				if got := [4]uint8(texels[i][:4]); got != tt.expected[i] {
+					t.Errorf("texel %d is %v, expected %v", i, got, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeBC6H(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   wgpu.TextureFormat
+		block    func(t *testing.T) []byte
+		expected [16][3]uint16
+	}{
+		{
+			name:   "mode 11",
+			format: wgpu.TextureFormat_BC6HRGBUfloat,
+			block: func(t *testing.T) []byte {
+				var w bitWriter
+				w.put(0b00011, 5)
+				for _, v := range []int{0, 512, 0, 1023, 512, 0} { // rw gw bw rx gx bx
+					w.put(v, 10)
+				}
+				w.put(0, 3)
+				for i := 1; i < 16; i++ {
+					w.put(i, 4)
+				}
+				return w.bytes(t)
+			},
+			expected: [16][3]uint16{
+				{0x0000, 0x3e0f, 0}, {0x07c0, 0x3e0f, 0}, {0x1170, 0x3e0f, 0}, {0x1930, 0x3e0f, 0},
+				{0x20f0, 0x3e0f, 0}, {0x28b0, 0x3e0f, 0}, {0x3260, 0x3e0f, 0}, {0x3a20, 0x3e0f, 0},
+				{0x41df, 0x3e0f, 0}, {0x499f, 0x3e0f, 0}, {0x534f, 0x3e0f, 0}, {0x5b0f, 0x3e0f, 0},
+				{0x62cf, 0x3e0f, 0}, {0x6a8f, 0x3e0f, 0}, {0x743f, 0x3e0f, 0}, {0x7bff, 0x3e0f, 0},
+			},
+		},
+		{
+			name:   "mode 11 signed",
+			format: wgpu.TextureFormat_BC6HRGBFloat,
+			block: func(t *testing.T) []byte {
+				var w bitWriter
+				w.put(0b00011, 5)
+				for _, v := range []int{-512, -100, 0, 511, 100, 0} { // rw gw bw rx gx bx
+					w.put(v, 10)
+				}
+				w.put(0, 3)
+				for i := 1; i < 16; i++ {
+					w.put(i, 4)
+				}
+				return w.bytes(t)
+			},
+			expected: [16][3]uint16{
+				{0xfbff, 0x9857, 0}, {0xec7f, 0x954c, 0}, {0xd91f, 0x917e, 0}, {0xc99f, 0x8e73, 0},
+				{0xba20, 0x8b68, 0}, {0xaaa0, 0x885d, 0}, {0x9740, 0x8490, 0}, {0x87c0, 0x8185, 0},
+				{0x07c0, 0x0185, 0}, {0x1740, 0x0490, 0}, {0x2aa0, 0x085d, 0}, {0x3a20, 0x0b68, 0},
+				{0x499f, 0x0e73, 0}, {0x591f, 0x117e, 0}, {0x6c7f, 0x154c, 0}, {0x7bff, 0x1857, 0},
+			},
+		},
+		{
+			name:   "mode 1",
+			format: wgpu.TextureFormat_BC6HRGBUfloat,
+			block: func(t *testing.T) []byte {
+				// w is (100, 200, 300), and x, y and z the deltas (5, -3, 0),
+				// (-10, -7, -15) and (1, -16, -1) from it.
+				rx, gx, bx := 5, 0b11101, 0
+				ry, gy, by := 0b10110, 0b11001, 0b10001
+				rz, gz, bz := 0b00001, 0b10000, 0b11111
+				bit := func(v, n int) int { return v >> n & 1 }
+
+				var w bitWriter
+				w.put(0b00, 2)
+				w.put(bit(gy, 4), 1)
+				w.put(bit(by, 4), 1)
+				w.put(bit(bz, 4), 1)
+				w.put(100, 10)
+				w.put(200, 10)
+				w.put(300, 10)
+				w.put(rx, 5)
+				w.put(bit(gz, 4), 1)
+				w.put(gy, 4)
+				w.put(gx, 5)
+				w.put(bit(bz, 0), 1)
+				w.put(gz, 4)
+				w.put(bx, 5)
+				w.put(bit(bz, 1), 1)
+				w.put(by, 4)
+				w.put(ry, 5)
+				w.put(bit(bz, 2), 1)
+				w.put(rz, 5)
+				w.put(bit(bz, 3), 1)
+				w.put(17, 5) // partition, anchor of subset 1 at texel 2
+				for i := 0; i < 16; i++ {
+					if i == 0 || i == 2 {
+						w.put(i*3%8&3, 2)
+					} else {
+						w.put(i*3%8, 3)
+					}
+				}
+				return w.bytes(t)
+			},
+			expected: [16][3]uint16{
+				{0x0c2b, 0x1847, 0x2463}, {0x0b85, 0x16f8, 0x2349}, {0x0b55, 0x1720, 0x230c}, {0x0b25, 0x1747, 0x22cf},
+				{0x0c85, 0x1811, 0x2463}, {0x0cc6, 0x17ea, 0x2463}, {0x0c57, 0x182d, 0x2463}, {0x0bea, 0x16a5, 0x23ca},
+				{0x0c2b, 0x1847, 0x2463}, {0x0c6c, 0x1820, 0x2463}, {0x0cb0, 0x17f7, 0x2463}, {0x0c41, 0x183a, 0x2463},
+				{0x0c85, 0x1811, 0x2463}, {0x0cc6, 0x17ea, 0x2463}, {0x0c57, 0x182d, 0x2463}, {0x0c9a, 0x1804, 0x2463},
+			},
+		},
+		{
+			name:   "mode 14",
+			format: wgpu.TextureFormat_BC6HRGBUfloat,
+			block: func(t *testing.T) []byte {
+				// w is (0x8000, 0x4000, 0xf000), and x the delta (7, -8, 0)
+				// from it.
+				rw, gw, bw := 0x8000, 0x4000, 0xf000
+
+				var w bitWriter
+				w.put(0b01111, 5)
+				w.put(rw, 10)
+				w.put(gw, 10)
+				w.put(bw, 10)
+				w.put(7, 4)
+				w.putReversed(rw, 15, 10)
+				w.put(-8, 4)
+				w.putReversed(gw, 15, 10)
+				w.put(0, 4)
+				w.putReversed(bw, 15, 10)
+				w.put(0, 3)
+				for i := 1; i < 16; i++ {
+					w.put(i, 4)
+				}
+				return w.bytes(t)
+			},
+			expected: [16][3]uint16{
+				{0x3e00, 0x1f00, 0x7440}, {0x3e00, 0x1f00, 0x7440}, {0x3e00, 0x1eff, 0x7440}, {0x3e00, 0x1eff, 0x7440},
+				{0x3e00, 0x1eff, 0x7440}, {0x3e00, 0x1efe, 0x7440}, {0x3e01, 0x1efe, 0x7440}, {0x3e01, 0x1efe, 0x7440},
+				{0x3e01, 0x1efe, 0x7440}, {0x3e01, 0x1efd, 0x7440}, {0x3e02, 0x1efd, 0x7440}, {0x3e02, 0x1efd, 0x7440},
+				{0x3e02, 0x1efd, 0x7440}, {0x3e02, 0x1efc, 0x7440}, {0x3e03, 0x1efc, 0x7440}, {0x3e03, 0x1efc, 0x7440},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, texelSize, decode := blockDecoder(tt.format)
+			if decode == nil || format != wgpu.TextureFormat_RGBA16Float || texelSize != 8 {
+				t.Fatalf("no decoder of %s to RGBA16Float", tt.format)
+			}
+
+			var texels [16][8]byte
+			decode(tt.block(t), &texels)
+			for i := range texels {
+				got := [4]uint16{
+					binary.LittleEndian.Uint16(texels[i][0:]),
+					binary.LittleEndian.Uint16(texels[i][2:]),
+					binary.LittleEndian.Uint16(texels[i][4:]),
+					binary.LittleEndian.Uint16(texels[i][6:]),
+				}
+				expected := [4]uint16{tt.expected[i][0], tt.expected[i][1], tt.expected[i][2], 0x3c00}
+				if got != expected {
+					t.Errorf("texel %d is %#04x, expected %#04x", i, got, expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Package dds reads DirectDraw Surface files, and creates textures from them.
+package dds // import "github.com/rajveermalviya/go-webgpu/wgpuext/dds"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+const (
+	headerSize     = 128 // including the magic number
+	dx10HeaderSize = 20
+	magic          = 0x20534444 // "DDS "
+	pixelFourCC    = 0x4
+	pixelRGB       = 0x40
+	pixelLuminance = 0x20000
+	caps2Cubemap   = 0x200
+	caps2AllFaces  = 0xfc00
+	caps2Volume    = 0x200000
+	miscCube       = 0x4
+
+	dimension1D = 2
+	dimension2D = 3
+	dimension3D = 4
+
+	// maxDimension and maxLayers bound the size Decode accepts, well above
+	// what devices support.
+	maxDimension = 1 << 16
+	maxLayers    = 1 << 11
+)
+
+// File is a DDS file.
+type File struct {
+	Format wgpu.TextureFormat
+	// Width is the width of the base level in texels. Height is zero for 1D
+	// textures, and Depth for non 3D textures.
+	Width  uint32
+	Height uint32
+	Depth  uint32
+	// Layers is the number of array layers, zero if the texture isn't an
+	// array. Faces is 6 for cube maps, 1 otherwise.
+	Layers uint32
+	Faces  uint32
+	// Levels are the mip levels, starting with the base level. Each holds
+	// the tightly packed blocks of every layer, face and depth slice, in that
+	// order.
+	Levels [][]byte
+}
+
+// Descriptor returns the descriptor of a texture holding the contents of p.
+func (p *File) Descriptor() wgpu.TextureDescriptor {
+	desc := wgpu.TextureDescriptor{
+		Dimension: wgpu.TextureDimension_2D,
+		Size: wgpu.Extent3D{
+			Width:              p.Width,
+			Height:             max(p.Height, 1),
+			DepthOrArrayLayers: max(p.Layers, 1) * max(p.Faces, 1),
+		},
+		Format:        p.Format,
+		MipLevelCount: max(uint32(len(p.Levels)), 1),
+		SampleCount:   1,
+	}
+	switch {
+	case p.Height == 0:
+		desc.Dimension = wgpu.TextureDimension_1D
+	case p.Depth != 0:
+		desc.Dimension = wgpu.TextureDimension_3D
+		desc.Size.DepthOrArrayLayers = p.Depth
+	}
+	return desc
+}
+
+// ViewDimension returns the dimension of views of the whole texture.
+func (p *File) ViewDimension() wgpu.TextureViewDimension {
+	switch {
+	case p.Height == 0:
+		return wgpu.TextureViewDimension_1D
+	case p.Depth != 0:
+		return wgpu.TextureViewDimension_3D
+	case p.Faces == 6 && p.Layers != 0:
+		return wgpu.TextureViewDimension_CubeArray
+	case p.Faces == 6:
+		return wgpu.TextureViewDimension_Cube
+	case p.Layers != 0:
+		return wgpu.TextureViewDimension_2DArray
+	default:
+		return wgpu.TextureViewDimension_2D
+	}
+}
+
+// levelLayout returns the layout of the tightly packed data of level.
+func (p *File) levelLayout(level uint32) (wgpu.TextureCopyLayout, error) {
+	desc := p.Descriptor()
+	return wgpu.CalculateTextureCopyLayout(&desc, level, wgpu.TextureAspect_All, nil, false)
+}
+
+// Decode reads a DDS file from r. Files with a DX10 header may hold any
+// format of the package, legacy files BC1 to BC5, float and 8-bit RGBA, BGRA,
+// RG and R formats.
+func Decode(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	le := binary.LittleEndian
+	if len(data) < headerSize || le.Uint32(data) != magic || le.Uint32(data[4:]) != 124 {
+		return nil, errors.New("dds: invalid header")
+	}
+
+	f := &File{
+		Height: le.Uint32(data[12:]),
+		Width:  le.Uint32(data[16:]),
+		Faces:  1,
+	}
+	depth := le.Uint32(data[24:])
+	// writers don't always set the flag of the mip map count.
+	levelCount := max(le.Uint32(data[28:]), 1)
+	pixelFlags := le.Uint32(data[80:])
+	code := le.Uint32(data[84:])
+	caps2 := le.Uint32(data[112:])
+	offset := headerSize
+
+	if pixelFlags&pixelFourCC != 0 && code == fourCC("DX10") {
+		if len(data) < headerSize+dx10HeaderSize {
+			return nil, errors.New("dds: truncated DX10 header")
+		}
+		dx10 := data[headerSize:]
+		offset += dx10HeaderSize
+
+		dxgiFormat := le.Uint32(dx10)
+		var ok bool
+		if f.Format, ok = dxgiFormats[dxgiFormat]; !ok {
+			return nil, fmt.Errorf("dds: unsupported DXGI format %d", dxgiFormat)
+		}
+		arraySize := le.Uint32(dx10[12:])
+		if arraySize == 0 {
+			return nil, errors.New("dds: invalid array size")
+		}
+		switch le.Uint32(dx10[4:]) {
+		case dimension1D:
+			f.Height = 0
+		case dimension2D:
+			if le.Uint32(dx10[8:])&miscCube != 0 {
+				f.Faces = 6
+			}
+		case dimension3D:
+			if arraySize != 1 {
+				return nil, errors.New("dds: 3D texture arrays are not supported")
+			}
+			f.Depth = max(depth, 1)
+		default:
+			return nil, errors.New("dds: invalid resource dimension")
+		}
+		if arraySize > 1 {
+			f.Layers = arraySize
+		}
+	} else {
+		var ok bool
+		switch {
+		case pixelFlags&pixelFourCC != 0:
+			f.Format, ok = fourCCFormats[code]
+		case pixelFlags&(pixelRGB|pixelLuminance) != 0:
+			f.Format, ok = maskFormat(le.Uint32(data[88:]), le.Uint32(data[92:]), le.Uint32(data[96:]), le.Uint32(data[100:]), le.Uint32(data[104:]))
+		}
+		if !ok {
+			return nil, errors.New("dds: unsupported pixel format")
+		}
+		switch {
+		case caps2&caps2Cubemap != 0:
+			if caps2&caps2AllFaces != caps2AllFaces {
+				return nil, errors.New("dds: cube maps with missing faces are not supported")
+			}
+			f.Faces = 6
+		case caps2&caps2Volume != 0:
+			f.Depth = max(depth, 1)
+		}
+	}
+	if f.Width == 0 || (f.Height == 0 && f.Depth != 0) {
+		return nil, errors.New("dds: invalid texture size")
+	}
+	if f.Width > maxDimension || f.Height > maxDimension || f.Depth > maxDimension || f.Layers > maxLayers {
+		return nil, fmt.Errorf("dds: texture size %dx%dx%d with %d layers is too large", f.Width, f.Height, f.Depth, f.Layers)
+	}
+	if levelCount > 32 {
+		return nil, errors.New("dds: invalid mip map count")
+	}
+
+	// the file holds every level of the first layer or face, then of the
+	// next one and so on, while levels hold every layer and face.
+	elements := uint64(max(f.Layers, 1)) * uint64(f.Faces)
+	f.Levels = make([][]byte, levelCount)
+	sizes := make([]uint64, levelCount)
+	total := uint64(0)
+	for level := range f.Levels {
+		layout, err := f.levelLayout(uint32(level))
+		if err != nil {
+			return nil, fmt.Errorf("dds: %w", err)
+		}
+		sizes[level] = layout.Size / elements
+		total += layout.Size
+	}
+	if total > uint64(len(data)-offset) {
+		return nil, errors.New("dds: truncated data")
+	}
+	for level := range f.Levels {
+		f.Levels[level] = make([]byte, 0, sizes[level]*elements)
+	}
+	for element := uint64(0); element < elements; element++ {
+		for level := range f.Levels {
+			f.Levels[level] = append(f.Levels[level], data[offset:uint64(offset)+sizes[level]]...)
+			offset += int(sizes[level])
+		}
+	}
+	return f, nil
+}
@@ -0,0 +1,113 @@
+package dds
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+// header describes the header of a test file.
+type header struct {
+	width, height, depth, levels uint32
+	pixelFlags, code, caps2      uint32
+	// dx10 adds a DX10 header, with the fields below.
+	dx10                              bool
+	dxgiFormat, dimension, misc, size uint32
+}
+
+func (h header) bytes(data []byte) []byte {
+	b := make([]byte, headerSize, headerSize+dx10HeaderSize+len(data))
+	le := binary.LittleEndian
+	le.PutUint32(b, magic)
+	le.PutUint32(b[4:], 124)
+	le.PutUint32(b[12:], h.height)
+	le.PutUint32(b[16:], h.width)
+	le.PutUint32(b[24:], h.depth)
+	le.PutUint32(b[28:], h.levels)
+	le.PutUint32(b[80:], h.pixelFlags)
+	le.PutUint32(b[84:], h.code)
+	le.PutUint32(b[112:], h.caps2)
+	if h.dx10 {
+		le.PutUint32(b[80:], pixelFourCC)
+		le.PutUint32(b[84:], fourCC("DX10"))
+		dx10 := make([]byte, dx10HeaderSize)
+		le.PutUint32(dx10, h.dxgiFormat)
+		le.PutUint32(dx10[4:], h.dimension)
+		le.PutUint32(dx10[8:], h.misc)
+		le.PutUint32(dx10[12:], h.size)
+		b = append(b, dx10...)
+	}
+	return append(b, data...)
+}
+
+func TestDecode(t *testing.T) {
+	// two layers of two levels, a 2x2 one and a 1x1 one.
+	data := make([]byte, 2*(16+4))
+	for i := range data {
+		data[i] = byte(i)
+	}
+	f, err := Decode(bytes.NewReader(header{
+		width: 2, height: 2, levels: 2,
+		dx10: true, dxgiFormat: 28, dimension: dimension2D, size: 2,
+	}.bytes(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Format != wgpu.TextureFormat_RGBA8Unorm || f.Width != 2 || f.Height != 2 || f.Layers != 2 || f.Faces != 1 {
+		t.Errorf("got %+v", f)
+	}
+	// the levels hold every layer.
+	expected := [][]byte{
+		append(append([]byte{}, data[0:16]...), data[20:36]...),
+		append(append([]byte{}, data[16:20]...), data[36:40]...),
+	}
+	for level := range expected {
+		if !bytes.Equal(f.Levels[level], expected[level]) {
+			t.Errorf("got level %d %v, expected %v", level, f.Levels[level], expected[level])
+		}
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	dxt1 := header{width: 4, height: 4, levels: 1, pixelFlags: pixelFourCC, code: fourCC("DXT1")}
+	rgba := header{width: 4, height: 4, levels: 1, dx10: true, dxgiFormat: 28, dimension: dimension2D, size: 1}
+	modify := func(h header, f func(h *header)) header {
+		f(&h)
+		return h
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad magic", []byte("DDS!")},
+		{"truncated header", dxt1.bytes(nil)[:100]},
+		{"truncated DX10 header", rgba.bytes(nil)[:headerSize+8]},
+		{"truncated data", dxt1.bytes(make([]byte, 7))},
+		{"unsupported format", modify(dxt1, func(h *header) { h.code = fourCC("ABCD") }).bytes(make([]byte, 8))},
+		{"unsupported DXGI format", modify(rgba, func(h *header) { h.dxgiFormat = 1 }).bytes(make([]byte, 64))},
+		{"zero width", modify(dxt1, func(h *header) { h.width = 0 }).bytes(make([]byte, 8))},
+		{"missing cube faces", modify(dxt1, func(h *header) { h.caps2 = caps2Cubemap | 0x400 }).bytes(make([]byte, 48))},
+		{"zero array size", modify(rgba, func(h *header) { h.size = 0 }).bytes(make([]byte, 64))},
+		{"3D array", modify(rgba, func(h *header) { h.dimension, h.size = dimension3D, 2 }).bytes(make([]byte, 128))},
+		{"invalid dimension", modify(rgba, func(h *header) { h.dimension = 7 }).bytes(make([]byte, 64))},
+		{"too many levels", modify(dxt1, func(h *header) { h.levels = 33 }).bytes(make([]byte, 8))},
+		{"huge width", modify(dxt1, func(h *header) { h.width = 0xffffffff }).bytes(make([]byte, 8))},
+		{"huge height", modify(rgba, func(h *header) { h.height = 1 << 17 }).bytes(make([]byte, 64))},
+		{"huge depth", modify(dxt1, func(h *header) { h.caps2, h.depth = caps2Volume, 1<<31 }).bytes(make([]byte, 8))},
+		// the layer count times the faces overflows 32 bits.
+		{"huge cube array", modify(rgba, func(h *header) { h.misc, h.size = miscCube, 1<<31 }).bytes(make([]byte, 64))},
+		{"huge array", modify(rgba, func(h *header) { h.size = maxLayers + 1 }).bytes(make([]byte, 64))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Decode(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Errorf("got %+v, expected an error", f)
+			}
+		})
+	}
+}
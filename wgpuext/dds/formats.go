@@ -0,0 +1,90 @@
+package dds
+
+import "github.com/rajveermalviya/go-webgpu/wgpu"
+
+// dxgiFormats maps the DXGI_FORMAT values of the formats supported by the
+// package to texture formats. Typeless block compressed formats are read as
+// their Unorm variant.
+var dxgiFormats = map[uint32]wgpu.TextureFormat{
+	2:  wgpu.TextureFormat_RGBA32Float,
+	10: wgpu.TextureFormat_RGBA16Float,
+	16: wgpu.TextureFormat_RG32Float,
+	24: wgpu.TextureFormat_RGB10A2Unorm,
+	26: wgpu.TextureFormat_RG11B10Ufloat,
+	28: wgpu.TextureFormat_RGBA8Unorm,
+	29: wgpu.TextureFormat_RGBA8UnormSrgb,
+	31: wgpu.TextureFormat_RGBA8Snorm,
+	34: wgpu.TextureFormat_RG16Float,
+	41: wgpu.TextureFormat_R32Float,
+	49: wgpu.TextureFormat_RG8Unorm,
+	51: wgpu.TextureFormat_RG8Snorm,
+	54: wgpu.TextureFormat_R16Float,
+	61: wgpu.TextureFormat_R8Unorm,
+	63: wgpu.TextureFormat_R8Snorm,
+	67: wgpu.TextureFormat_RGB9E5Ufloat,
+	70: wgpu.TextureFormat_BC1RGBAUnorm,
+	71: wgpu.TextureFormat_BC1RGBAUnorm,
+	72: wgpu.TextureFormat_BC1RGBAUnormSrgb,
+	73: wgpu.TextureFormat_BC2RGBAUnorm,
+	74: wgpu.TextureFormat_BC2RGBAUnorm,
+	75: wgpu.TextureFormat_BC2RGBAUnormSrgb,
+	76: wgpu.TextureFormat_BC3RGBAUnorm,
+	77: wgpu.TextureFormat_BC3RGBAUnorm,
+	78: wgpu.TextureFormat_BC3RGBAUnormSrgb,
+	79: wgpu.TextureFormat_BC4RUnorm,
+	80: wgpu.TextureFormat_BC4RUnorm,
+	81: wgpu.TextureFormat_BC4RSnorm,
+	82: wgpu.TextureFormat_BC5RGUnorm,
+	83: wgpu.TextureFormat_BC5RGUnorm,
+	84: wgpu.TextureFormat_BC5RGSnorm,
+	87: wgpu.TextureFormat_BGRA8Unorm,
+	91: wgpu.TextureFormat_BGRA8UnormSrgb,
+	94: wgpu.TextureFormat_BC6HRGBUfloat,
+	95: wgpu.TextureFormat_BC6HRGBUfloat,
+	96: wgpu.TextureFormat_BC6HRGBFloat,
+	97: wgpu.TextureFormat_BC7RGBAUnorm,
+	98: wgpu.TextureFormat_BC7RGBAUnorm,
+	99: wgpu.TextureFormat_BC7RGBAUnormSrgb,
+}
+
+// fourCCFormats maps the FourCC codes and D3DFORMAT values of legacy headers
+// to texture formats.
+var fourCCFormats = map[uint32]wgpu.TextureFormat{
+	fourCC("DXT1"): wgpu.TextureFormat_BC1RGBAUnorm,
+	fourCC("DXT2"): wgpu.TextureFormat_BC2RGBAUnorm,
+	fourCC("DXT3"): wgpu.TextureFormat_BC2RGBAUnorm,
+	fourCC("DXT4"): wgpu.TextureFormat_BC3RGBAUnorm,
+	fourCC("DXT5"): wgpu.TextureFormat_BC3RGBAUnorm,
+	fourCC("ATI1"): wgpu.TextureFormat_BC4RUnorm,
+	fourCC("BC4U"): wgpu.TextureFormat_BC4RUnorm,
+	fourCC("BC4S"): wgpu.TextureFormat_BC4RSnorm,
+	fourCC("ATI2"): wgpu.TextureFormat_BC5RGUnorm,
+	fourCC("BC5U"): wgpu.TextureFormat_BC5RGUnorm,
+	fourCC("BC5S"): wgpu.TextureFormat_BC5RGSnorm,
+	111:            wgpu.TextureFormat_R16Float,
+	112:            wgpu.TextureFormat_RG16Float,
+	113:            wgpu.TextureFormat_RGBA16Float,
+	114:            wgpu.TextureFormat_R32Float,
+	115:            wgpu.TextureFormat_RG32Float,
+	116:            wgpu.TextureFormat_RGBA32Float,
+}
+
+func fourCC(s string) uint32 {
+	return uint32(s[0]) | uint32(s[1])<<8 | uint32(s[2])<<16 | uint32(s[3])<<24
+}
+
+// maskFormat returns the texture format of uncompressed legacy pixel
+// formats, described by their bit count and channel masks.
+func maskFormat(bitCount, r, g, b, a uint32) (wgpu.TextureFormat, bool) {
+	switch {
+	case bitCount == 32 && r == 0xff && g == 0xff00 && b == 0xff0000 && (a == 0xff000000 || a == 0):
+		return wgpu.TextureFormat_RGBA8Unorm, true
+	case bitCount == 32 && r == 0xff0000 && g == 0xff00 && b == 0xff && (a == 0xff000000 || a == 0):
+		return wgpu.TextureFormat_BGRA8Unorm, true
+	case bitCount == 16 && r == 0xff && g == 0xff00 && b == 0 && a == 0:
+		return wgpu.TextureFormat_RG8Unorm, true
+	case bitCount == 8 && r == 0xff && g == 0 && b == 0 && a == 0:
+		return wgpu.TextureFormat_R8Unorm, true
+	}
+	return wgpu.TextureFormat_Undefined, false
+}
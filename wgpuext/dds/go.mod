@@ -0,0 +1,5 @@
+module github.com/rajveermalviya/go-webgpu/wgpuext/dds
+
+go 1.22
+
+require github.com/rajveermalviya/go-webgpu/wgpu v0.17.1
@@ -0,0 +1,204 @@
+package dds
+
+import (
+	"fmt"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+type TextureDescriptor struct {
+	Label string
+	// Usage is TextureUsage_TextureBinding by default, TextureUsage_CopyDst
+	// is always added.
+	Usage wgpu.TextureUsage
+}
+
+// RequiredFeatures returns the features of adapter to request in
+// wgpu.DeviceDescriptor.RequiredFeatures for BC compressed files to be
+// uploaded as is, rather than decompressed by CreateTexture.
+func RequiredFeatures(adapter *wgpu.Adapter) []wgpu.FeatureName {
+	if adapter.HasFeature(wgpu.FeatureName_TextureCompressionBC) {
+		return []wgpu.FeatureName{wgpu.FeatureName_TextureCompressionBC}
+	}
+	return nil
+}
+
+// CreateTexture creates a texture of the format, dimension, size and mip
+// level count of p, and writes every level of p to it. Views of the whole
+// texture have the dimension p.ViewDimension().
+//
+// If p is BC compressed and device doesn't have
+// FeatureName_TextureCompressionBC, p is decompressed first, see
+// (*File).Decompress.
+func (p *File) CreateTexture(device *wgpu.Device, descriptor *TextureDescriptor) (*wgpu.Texture, error) {
+	var d TextureDescriptor
+	if descriptor != nil {
+		d = *descriptor
+	}
+	if d.Usage == 0 {
+		d.Usage = wgpu.TextureUsage_TextureBinding
+	}
+
+	f := p
+	if feature := p.Format.Info().RequiredFeature; feature != wgpu.FeatureName_Undefined && !device.HasFeature(feature) {
+		if feature != wgpu.FeatureName_TextureCompressionBC {
+			return nil, fmt.Errorf("dds: format %s requires feature %s", p.Format, feature)
+		}
+		var err error
+		if f, err = p.Decompress(); err != nil {
+			return nil, err
+		}
+	}
+
+	desc := f.Descriptor()
+	desc.Label = d.Label
+	desc.Usage = d.Usage | wgpu.TextureUsage_CopyDst
+	texture, err := device.CreateTexture(&desc)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := device.GetQueue()
+	defer queue.Release()
+	info := f.Format.Info()
+	for level, data := range f.Levels {
+		layout, err := f.levelLayout(uint32(level))
+		if err == nil && uint64(len(data)) != layout.Size {
+			err = fmt.Errorf("dds: level %d has %d bytes, expected %d", level, len(data), layout.Size)
+		}
+		if err == nil {
+			err = queue.WriteTexture(&wgpu.ImageCopyTexture{
+				Texture:  texture,
+				MipLevel: uint32(level),
+				Aspect:   wgpu.TextureAspect_All,
+			}, data, &layout.Layout, &wgpu.Extent3D{
+				Width:              uint32(layout.RowSize/uint64(info.BlockSize)) * info.BlockWidth,
+				Height:             layout.Rows * info.BlockHeight,
+				DepthOrArrayLayers: layout.Images,
+			})
+		}
+		if err != nil {
+			texture.Release()
+			return nil, err
+		}
+	}
+	return texture, nil
+}
+
+// Decompress returns a copy of p with its BC compressed levels decoded, to
+// TextureFormat_RGBA8Unorm or TextureFormat_RGBA8UnormSrgb, or
+// TextureFormat_RGBA8Snorm for the snorm formats. BC6H is decoded to
+// TextureFormat_RGBA16Float, as 8-bit formats can't hold its range.
+func (p *File) Decompress() (*File, error) {
+	format, texelSize, decode := blockDecoder(p.Format)
+	if decode == nil {
+		return nil, fmt.Errorf("dds: can't decompress format %s", p.Format)
+	}
+
+	out := *p
+	out.Format = format
+	out.Levels = make([][]byte, len(p.Levels))
+	info := p.Format.Info()
+	var texels [16][8]byte
+	for level, data := range p.Levels {
+		layout, err := p.levelLayout(uint32(level))
+		if err != nil {
+			return nil, fmt.Errorf("dds: %w", err)
+		}
+		if uint64(len(data)) != layout.Size {
+			return nil, fmt.Errorf("dds: level %d has %d bytes, expected %d", level, len(data), layout.Size)
+		}
+		width := int(max(p.Width>>level, 1))
+		height := int(max(max(p.Height, 1)>>level, 1))
+		rowSize := width * texelSize
+		blocksPerRow := int(layout.RowSize / uint64(info.BlockSize))
+
+		dst := make([]byte, rowSize*height*int(layout.Images))
+		for image := 0; image < int(layout.Images); image++ {
+			for by := 0; by < int(layout.Rows); by++ {
+				for bx := 0; bx < blocksPerRow; bx++ {
+					offset := (image*int(layout.Rows)+by)*int(layout.RowSize) + bx*int(info.BlockSize)
+					decode(data[offset:offset+int(info.BlockSize)], &texels)
+					for y := 0; y < 4 && by*4+y < height; y++ {
+						for x := 0; x < 4 && bx*4+x < width; x++ {
+							i := (image*height+by*4+y)*rowSize + (bx*4+x)*texelSize
+							copy(dst[i:i+texelSize], texels[y*4+x][:texelSize])
+						}
+					}
+				}
+			}
+		}
+		out.Levels[level] = dst
+	}
+	return &out, nil
+}
+
+// blockDecoder returns the format BC compressed blocks of format are decoded
+// to, its texel size, and the function decoding a block, nil if format isn't
+// BC compressed.
+func blockDecoder(format wgpu.TextureFormat) (wgpu.TextureFormat, int, func(block []byte, texels *[16][8]byte)) {
+	rgba8 := func(decode func(block []byte, px *[16][4]uint8)) func(block []byte, texels *[16][8]byte) {
+		return func(block []byte, texels *[16][8]byte) {
+			var px [16][4]uint8
+			decode(block, &px)
+			for i := range px {
+				copy(texels[i][:4], px[i][:])
+			}
+		}
+	}
+	linear := wgpu.TextureFormat_RGBA8Unorm
+	if format.Info().Srgb {
+		linear = wgpu.TextureFormat_RGBA8UnormSrgb
+	}
+
+	switch format {
+	case wgpu.TextureFormat_BC1RGBAUnorm, wgpu.TextureFormat_BC1RGBAUnormSrgb:
+		return linear, 4, rgba8(func(block []byte, px *[16][4]uint8) {
+			decodeBC1(block, px, true)
+		})
+	case wgpu.TextureFormat_BC2RGBAUnorm, wgpu.TextureFormat_BC2RGBAUnormSrgb:
+		return linear, 4, rgba8(func(block []byte, px *[16][4]uint8) {
+			decodeBC1(block[8:], px, false)
+			decodeBC2Alpha(block, px)
+		})
+	case wgpu.TextureFormat_BC3RGBAUnorm, wgpu.TextureFormat_BC3RGBAUnormSrgb:
+		return linear, 4, rgba8(func(block []byte, px *[16][4]uint8) {
+			decodeBC1(block[8:], px, false)
+			decodeBC4(block, px, 3, false)
+		})
+	case wgpu.TextureFormat_BC4RUnorm, wgpu.TextureFormat_BC4RSnorm,
+		wgpu.TextureFormat_BC5RGUnorm, wgpu.TextureFormat_BC5RGSnorm:
+		signed := format == wgpu.TextureFormat_BC4RSnorm || format == wgpu.TextureFormat_BC5RGSnorm
+		rg := format == wgpu.TextureFormat_BC5RGUnorm || format == wgpu.TextureFormat_BC5RGSnorm
+		var one uint8 = 255
+		if signed {
+			linear, one = wgpu.TextureFormat_RGBA8Snorm, 127
+		}
+		return linear, 4, rgba8(func(block []byte, px *[16][4]uint8) {
+			*px = [16][4]uint8{}
+			for i := range px {
+				px[i][3] = one
+			}
+			decodeBC4(block, px, 0, signed)
+			if rg {
+				decodeBC4(block[8:], px, 1, signed)
+			}
+		})
+	case wgpu.TextureFormat_BC6HRGBUfloat, wgpu.TextureFormat_BC6HRGBFloat:
+		signed := format == wgpu.TextureFormat_BC6HRGBFloat
+		return wgpu.TextureFormat_RGBA16Float, 8, func(block []byte, texels *[16][8]byte) {
+			var px [16][3]uint16
+			decodeBC6H(block, &px, signed)
+			for i := range px {
+				t := &texels[i]
+				t[0], t[1] = byte(px[i][0]), byte(px[i][0]>>8)
+				t[2], t[3] = byte(px[i][1]), byte(px[i][1]>>8)
+				t[4], t[5] = byte(px[i][2]), byte(px[i][2]>>8)
+				t[6], t[7] = 0x00, 0x3c // 1.0
+			}
+		}
+	case wgpu.TextureFormat_BC7RGBAUnorm, wgpu.TextureFormat_BC7RGBAUnormSrgb:
+		return linear, 4, rgba8(decodeBC7)
+	}
+	return wgpu.TextureFormat_Undefined, 0, nil
+}
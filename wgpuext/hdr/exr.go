@@ -0,0 +1,480 @@
+package hdr
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+type PixelType uint32
+
+const (
+	PixelType_Uint  PixelType = 0
+	PixelType_Half  PixelType = 1
+	PixelType_Float PixelType = 2
+)
+
+func (v PixelType) String() string {
+	switch v {
+	case PixelType_Uint:
+		return "Uint"
+	case PixelType_Half:
+		return "Half"
+	case PixelType_Float:
+		return "Float"
+	default:
+		return ""
+	}
+}
+
+func (v PixelType) size() int {
+	if v == PixelType_Half {
+		return 2
+	}
+	return 4
+}
+
+type Compression uint8
+
+const (
+	Compression_None Compression = 0
+	Compression_RLE  Compression = 1
+	Compression_ZIPS Compression = 2
+	Compression_ZIP  Compression = 3
+	Compression_PIZ  Compression = 4
+)
+
+func (v Compression) String() string {
+	switch v {
+	case Compression_None:
+		return "None"
+	case Compression_RLE:
+		return "RLE"
+	case Compression_ZIPS:
+		return "ZIPS"
+	case Compression_ZIP:
+		return "ZIP"
+	case Compression_PIZ:
+		return "PIZ"
+	default:
+		return ""
+	}
+}
+
+// linesPerBlock returns the number of scanlines compressed together.
+func (v Compression) linesPerBlock() int {
+	if v == Compression_ZIP {
+		return 16
+	}
+	return 1
+}
+
+// maxZipRatio bounds the ratio of uncompressed to compressed size of
+// deflate.
+const maxZipRatio = 1032
+
+const (
+	exrMagic         = 20000630
+	exrVersion       = 2
+	exrFlagTiled     = 0x200
+	exrFlagDeep      = 0x800
+	exrFlagMultipart = 0x1000
+)
+
+type exrChannel struct {
+	name      string
+	pixelType PixelType
+}
+
+// DecodeEXR reads a single part scanline OpenEXR image, uncompressed or
+// with ZIP or ZIPS compression, from r.
+//
+// The R, G, B and A channels are read, Y as gray if there are no R, G and B
+// channels. Missing color channels are 0, and a missing A channel 1.
+func DecodeEXR(r io.Reader) (*Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	le := binary.LittleEndian
+	if len(data) < 8 || le.Uint32(data) != exrMagic {
+		return nil, errors.New("hdr: invalid EXR magic number")
+	}
+	version := le.Uint32(data[4:])
+	if version&0xff != exrVersion {
+		return nil, fmt.Errorf("hdr: unsupported EXR version %d", version&0xff)
+	}
+	if version&(exrFlagTiled|exrFlagDeep|exrFlagMultipart) != 0 {
+		return nil, errors.New("hdr: tiled, deep and multipart EXR images are not supported")
+	}
+
+	var (
+		channels    []exrChannel
+		compression = Compression(0xff)
+		dataWindow  [4]int32
+		hasWindow   bool
+	)
+	rest := data[8:]
+	for {
+		name, ok := cutString(&rest)
+		if !ok {
+			return nil, errors.New("hdr: truncated EXR header")
+		}
+		if name == "" {
+			break
+		}
+		typ, ok := cutString(&rest)
+		if !ok || len(rest) < 4 {
+			return nil, errors.New("hdr: truncated EXR header")
+		}
+		size := le.Uint32(rest)
+		if uint64(size) > uint64(len(rest)-4) {
+			return nil, errors.New("hdr: truncated EXR header")
+		}
+		value := rest[4 : 4+size]
+		rest = rest[4+size:]
+
+		switch {
+		case name == "channels" && typ == "chlist":
+			for {
+				name, ok := cutString(&value)
+				if !ok || (name != "" && len(value) < 16) {
+					return nil, errors.New("hdr: invalid EXR channel list")
+				}
+				if name == "" {
+					break
+				}
+				if le.Uint32(value[8:]) != 1 || le.Uint32(value[12:]) != 1 {
+					return nil, errors.New("hdr: subsampled EXR channels are not supported")
+				}
+				channels = append(channels, exrChannel{name, PixelType(le.Uint32(value))})
+				if channels[len(channels)-1].pixelType > PixelType_Float {
+					return nil, errors.New("hdr: invalid EXR pixel type")
+				}
+				value = value[16:]
+			}
+		case name == "compression" && typ == "compression" && len(value) == 1:
+			compression = Compression(value[0])
+		case name == "dataWindow" && typ == "box2i" && len(value) == 16:
+			for i := range dataWindow {
+				dataWindow[i] = int32(le.Uint32(value[i*4:]))
+			}
+			hasWindow = true
+		}
+	}
+	if len(channels) == 0 || !hasWindow {
+		return nil, errors.New("hdr: EXR header misses channels or dataWindow")
+	}
+	switch compression {
+	case Compression_None, Compression_ZIPS, Compression_ZIP:
+	default:
+		return nil, fmt.Errorf("hdr: unsupported EXR compression %s", compression)
+	}
+
+	width := int64(dataWindow[2]) - int64(dataWindow[0]) + 1
+	height := int64(dataWindow[3]) - int64(dataWindow[1]) + 1
+	if width <= 0 || height <= 0 || width > maxDimension || height > maxDimension {
+		return nil, errors.New("hdr: invalid EXR data window")
+	}
+	lineSize := 0
+	for _, c := range channels {
+		lineSize += int(width) * c.pixelType.size()
+	}
+	// the pixels must fit in the data, once decompressed.
+	ratio := int64(1)
+	if compression != Compression_None {
+		ratio = maxZipRatio
+	}
+	if int64(lineSize)*height > int64(len(data))*ratio {
+		return nil, errors.New("hdr: EXR data window is larger than the data")
+	}
+
+	// channel returns the index of the channel name, or -1.
+	channel := func(name string) int {
+		for i, c := range channels {
+			if c.name == name {
+				return i
+			}
+		}
+		return -1
+	}
+	targets := make([]int, len(channels))
+	for i := range targets {
+		targets[i] = -1
+	}
+	for i, name := range []string{"R", "G", "B", "A"} {
+		if c := channel(name); c >= 0 {
+			targets[c] = i
+		}
+	}
+	gray := channel("Y")
+	if channel("R") >= 0 || channel("G") >= 0 || channel("B") >= 0 {
+		gray = -1
+	}
+
+	img := NewImage(int(width), int(height))
+	if channel("A") < 0 {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 1
+		}
+	}
+
+	lines := compression.linesPerBlock()
+	chunks := int((height + int64(lines) - 1) / int64(lines))
+	if uint64(chunks)*8 > uint64(len(rest)) {
+		return nil, errors.New("hdr: truncated EXR offset table")
+	}
+	var block []byte
+	for i := 0; i < chunks; i++ {
+		offset := le.Uint64(rest[i*8:])
+		if offset > uint64(len(data)) || uint64(len(data))-offset < 8 {
+			return nil, errors.New("hdr: invalid EXR chunk offset")
+		}
+		chunk := data[offset:]
+		y := int64(int32(le.Uint32(chunk))) - int64(dataWindow[1])
+		size := uint64(le.Uint32(chunk[4:]))
+		if size > uint64(len(chunk)-8) || y < 0 || y >= height || y%int64(lines) != 0 {
+			return nil, errors.New("hdr: invalid EXR chunk")
+		}
+		n := int(min(int64(lines), height-y))
+		packed := chunk[8 : 8+size]
+
+		blockSize := n * lineSize
+		if cap(block) < blockSize {
+			block = make([]byte, blockSize)
+		}
+		block = block[:blockSize]
+		if uint64(blockSize) == size || compression == Compression_None {
+			if uint64(blockSize) != size {
+				return nil, errors.New("hdr: invalid EXR chunk size")
+			}
+			copy(block, packed)
+		} else if err := unzip(block, packed); err != nil {
+			return nil, err
+		}
+
+		for line := 0; line < n; line++ {
+			src := block[line*lineSize:]
+			pix := img.Pix[(int(y)+line)*int(width)*4:]
+			for c, ch := range channels {
+				size := ch.pixelType.size()
+				for x := 0; x < int(width); x++ {
+					var v float32
+					switch ch.pixelType {
+					case PixelType_Half:
+						v = Float16(le.Uint16(src[x*2:]))
+					case PixelType_Float:
+						v = math.Float32frombits(le.Uint32(src[x*4:]))
+					default:
+						v = float32(le.Uint32(src[x*4:]))
+					}
+					switch {
+					case targets[c] >= 0:
+						pix[x*4+targets[c]] = v
+					case c == gray:
+						pix[x*4], pix[x*4+1], pix[x*4+2] = v, v, v
+					}
+				}
+				src = src[int(width)*size:]
+			}
+		}
+	}
+	return img, nil
+}
+
+// EXROptions are the options of EncodeEXR.
+type EXROptions struct {
+	// PixelType is PixelType_Half or PixelType_Float. The zero value,
+	// PixelType_Uint, is taken as PixelType_Half.
+	PixelType PixelType
+	// Compression is Compression_None, Compression_ZIPS or Compression_ZIP.
+	Compression Compression
+}
+
+// EncodeEXR writes img to w as a single part scanline OpenEXR image with
+// R, G, B and A channels. If options is nil, channels are half floats with
+// ZIP compression.
+func EncodeEXR(w io.Writer, img *Image, options *EXROptions) error {
+	o := EXROptions{PixelType: PixelType_Half, Compression: Compression_ZIP}
+	if options != nil {
+		o = *options
+		if o.PixelType == PixelType_Uint {
+			o.PixelType = PixelType_Half
+		}
+	}
+	if o.PixelType != PixelType_Half && o.PixelType != PixelType_Float {
+		return fmt.Errorf("hdr: unsupported EXR pixel type %s", o.PixelType)
+	}
+	switch o.Compression {
+	case Compression_None, Compression_ZIPS, Compression_ZIP:
+	default:
+		return fmt.Errorf("hdr: unsupported EXR compression %s", o.Compression)
+	}
+	if img.Width <= 0 || img.Height <= 0 || len(img.Pix) != img.Width*img.Height*4 {
+		return errors.New("hdr: invalid image size")
+	}
+
+	le := binary.LittleEndian
+	var header []byte
+	header = le.AppendUint32(header, exrMagic)
+	header = le.AppendUint32(header, exrVersion)
+	attribute := func(name, typ string, value []byte) {
+		header = append(header, name...)
+		header = append(header, 0)
+		header = append(header, typ...)
+		header = append(header, 0)
+		header = le.AppendUint32(header, uint32(len(value)))
+		header = append(header, value...)
+	}
+	box := func(x, y int) []byte {
+		var b []byte
+		b = le.AppendUint32(b, 0)
+		b = le.AppendUint32(b, 0)
+		b = le.AppendUint32(b, uint32(x))
+		b = le.AppendUint32(b, uint32(y))
+		return b
+	}
+	float := func(v float32) []byte { return le.AppendUint32(nil, math.Float32bits(v)) }
+
+	// channels are sorted by name.
+	names := []string{"A", "B", "G", "R"}
+	components := []int{3, 2, 1, 0}
+	var chlist []byte
+	for _, name := range names {
+		chlist = append(chlist, name...)
+		chlist = append(chlist, 0)
+		chlist = le.AppendUint32(chlist, uint32(o.PixelType))
+		chlist = append(chlist, 0, 0, 0, 0) // pLinear and reserved
+		chlist = le.AppendUint32(chlist, 1)
+		chlist = le.AppendUint32(chlist, 1)
+	}
+	chlist = append(chlist, 0)
+	attribute("channels", "chlist", chlist)
+	attribute("compression", "compression", []byte{byte(o.Compression)})
+	attribute("dataWindow", "box2i", box(img.Width-1, img.Height-1))
+	attribute("displayWindow", "box2i", box(img.Width-1, img.Height-1))
+	attribute("lineOrder", "lineOrder", []byte{0}) // increasing y
+	attribute("pixelAspectRatio", "float", float(1))
+	attribute("screenWindowCenter", "v2f", append(float(0), float(0)...))
+	attribute("screenWindowWidth", "float", float(1))
+	header = append(header, 0)
+
+	lines := o.Compression.linesPerBlock()
+	chunks := (img.Height + lines - 1) / lines
+	lineSize := img.Width * len(names) * o.PixelType.size()
+
+	var out bytes.Buffer
+	out.Write(header)
+	out.Write(make([]byte, chunks*8)) // offset table, filled in below
+	offsets := make([]uint64, chunks)
+	block := make([]byte, lines*lineSize)
+	for i := range offsets {
+		y := i * lines
+		n := min(lines, img.Height-y)
+		block = block[:n*lineSize]
+		for line := 0; line < n; line++ {
+			dst := block[line*lineSize:]
+			pix := img.Pix[(y+line)*img.Width*4:]
+			for _, component := range components {
+				for x := 0; x < img.Width; x++ {
+					v := pix[x*4+component]
+					if o.PixelType == PixelType_Half {
+						le.PutUint16(dst[x*2:], Float16Bits(v))
+					} else {
+						le.PutUint32(dst[x*4:], math.Float32bits(v))
+					}
+				}
+				dst = dst[img.Width*o.PixelType.size():]
+			}
+		}
+
+		packed := block
+		if o.Compression != Compression_None {
+			// blocks that don't shrink are stored as is.
+			if zipped := zip(block); len(zipped) < len(block) {
+				packed = zipped
+			}
+		}
+		offsets[i] = uint64(out.Len())
+		var chunkHeader [8]byte
+		le.PutUint32(chunkHeader[:], uint32(y))
+		le.PutUint32(chunkHeader[4:], uint32(len(packed)))
+		out.Write(chunkHeader[:])
+		out.Write(packed)
+	}
+
+	b := out.Bytes()
+	for i, offset := range offsets {
+		le.PutUint64(b[len(header)+i*8:], offset)
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// zip compresses data as the ZIP and ZIPS compressions do, splitting the
+// even and odd bytes and delta encoding them before deflating them.
+func zip(data []byte) []byte {
+	tmp := make([]byte, len(data))
+	half := (len(data) + 1) / 2
+	for i, v := range data {
+		if i%2 == 0 {
+			tmp[i/2] = v
+		} else {
+			tmp[half+i/2] = v
+		}
+	}
+	p := tmp[0]
+	for i := 1; i < len(tmp); i++ {
+		d := tmp[i] - p + 128
+		p = tmp[i]
+		tmp[i] = d
+	}
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(tmp)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// unzip decompresses data to dst, reversing zip.
+func unzip(dst, data []byte) error {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("hdr: %w", err)
+	}
+	tmp := make([]byte, len(dst))
+	_, err = io.ReadFull(zr, tmp)
+	zr.Close()
+	if err != nil {
+		return fmt.Errorf("hdr: %w", err)
+	}
+
+	for i := 1; i < len(tmp); i++ {
+		tmp[i] = tmp[i-1] + tmp[i] - 128
+	}
+	half := (len(dst) + 1) / 2
+	for i := range dst {
+		if i%2 == 0 {
+			dst[i] = tmp[i/2]
+		} else {
+			dst[i] = tmp[half+i/2]
+		}
+	}
+	return nil
+}
+
+// cutString cuts the NUL terminated string at the start of b.
+func cutString(b *[]byte) (string, bool) {
+	i := bytes.IndexByte(*b, 0)
+	if i < 0 {
+		return "", false
+	}
+	s := string((*b)[:i])
+	*b = (*b)[i+1:]
+	return s, true
+}
@@ -0,0 +1,100 @@
+package hdr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// testImage returns an image of values half floats hold exactly.
+func testImage(width, height int) *Image {
+	img := NewImage(width, height)
+	for i := range img.Pix {
+		img.Pix[i] = Float16(uint16(i*7919) & 0x7bff)
+		if i%5 == 0 {
+			// runs of the same value, for ZIP to compress.
+			img.Pix[i] = 0.25
+		}
+	}
+	return img
+}
+
+func TestEncodeDecodeEXR(t *testing.T) {
+	img := testImage(37, 41)
+	for _, pixelType := range []PixelType{PixelType_Half, PixelType_Float} {
+		for _, compression := range []Compression{Compression_None, Compression_ZIPS, Compression_ZIP} {
+			t.Run(pixelType.String()+"/"+compression.String(), func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := EncodeEXR(&buf, img, &EXROptions{PixelType: pixelType, Compression: compression}); err != nil {
+					t.Fatal(err)
+				}
+				got, err := DecodeEXR(&buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.Width != img.Width || got.Height != img.Height || !equal(got.Pix, img.Pix) {
+					t.Error("decoded image differs from the encoded one")
+				}
+			})
+		}
+	}
+
+	// float values survive PixelType_Float only.
+	img.Pix[0] = 1.0 / 3
+	var buf bytes.Buffer
+	if err := EncodeEXR(&buf, img, &EXROptions{PixelType: PixelType_Float}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecodeEXR(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Pix[0] != 1.0/3 {
+		t.Errorf("got %v, expected %v", got.Pix[0], float32(1.0/3))
+	}
+}
+
+func TestDecodeEXRInvalid(t *testing.T) {
+	valid := func(compression Compression) []byte {
+		var buf bytes.Buffer
+		if err := EncodeEXR(&buf, testImage(8, 8), &EXROptions{Compression: compression}); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	// dataWindow returns the offset of the value of the dataWindow attribute.
+	dataWindow := func(b []byte) int {
+		return bytes.Index(b, []byte("dataWindow\x00box2i\x00")) + len("dataWindow\x00box2i\x00") + 4
+	}
+	le := binary.LittleEndian
+
+	tests := []struct {
+		name        string
+		compression Compression
+		modify      func(b []byte) []byte
+	}{
+		{"empty", Compression_None, func(b []byte) []byte { return nil }},
+		{"magic", Compression_None, func(b []byte) []byte { b[0]++; return b }},
+		{"tiled", Compression_None, func(b []byte) []byte { b[5] |= 0x2; return b }},
+		{"truncated header", Compression_None, func(b []byte) []byte { return b[:40] }},
+		{"truncated data", Compression_None, func(b []byte) []byte { return b[:len(b)-1] }},
+		{"truncated zip data", Compression_ZIP, func(b []byte) []byte { return b[:len(b)-1] }},
+		{"large data window", Compression_None, func(b []byte) []byte {
+			le.PutUint32(b[dataWindow(b)+8:], 1<<30)
+			le.PutUint32(b[dataWindow(b)+12:], 1<<30)
+			return b
+		}},
+		{"data window larger than the data", Compression_ZIP, func(b []byte) []byte {
+			le.PutUint32(b[dataWindow(b)+8:], maxDimension-1)
+			le.PutUint32(b[dataWindow(b)+12:], maxDimension-1)
+			return b
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeEXR(bytes.NewReader(tt.modify(valid(tt.compression)))); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
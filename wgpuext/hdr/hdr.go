@@ -0,0 +1,91 @@
+// Package hdr reads Radiance RGBE and OpenEXR images, writes OpenEXR images,
+// and creates float textures from them.
+package hdr // import "github.com/rajveermalviya/go-webgpu/wgpuext/hdr"
+
+import "math"
+
+// Image is an image of linear float RGBA colors with straight alpha.
+type Image struct {
+	Width  int
+	Height int
+	// Pix holds the r, g, b and a values of the pixels, row by row from the
+	// top left pixel.
+	Pix []float32
+}
+
+// maxDimension bounds the width and height of decoded images.
+const maxDimension = 1 << 16
+
+// NewImage returns a transparent black image of the size.
+func NewImage(width, height int) *Image {
+	return &Image{
+		Width:  width,
+		Height: height,
+		Pix:    make([]float32, width*height*4),
+	}
+}
+
+// Float16 returns the float of the IEEE 754 half-precision float bits h.
+func Float16(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch {
+	case exp == 0x1f:
+		// infinity or NaN
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case exp != 0:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+	case mant == 0:
+		return math.Float32frombits(sign)
+	}
+	// subnormal, normalized as a float32.
+	exp = 127 - 15 + 1
+	for mant&0x400 == 0 {
+		mant <<= 1
+		exp--
+	}
+	return math.Float32frombits(sign | exp<<23 | (mant&0x3ff)<<13)
+}
+
+// Float16Bits returns the IEEE 754 half-precision float bits of f, rounding
+// to nearest even.
+func Float16Bits(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case bits&0x7fffffff >= 0x7f800000:
+		// infinity or NaN
+		h := sign | 0x7c00
+		if mant != 0 {
+			h |= 0x200
+		}
+		return h
+	case exp >= 0x1f:
+		return sign | 0x7c00
+	case exp <= 0:
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		h := sign | uint16(mant>>shift)
+		rem := mant & (1<<shift - 1)
+		half := uint32(1) << (shift - 1)
+		if rem > half || (rem == half && h&1 != 0) {
+			h++
+		}
+		return h
+	}
+	h := sign | uint16(exp)<<10 | uint16(mant>>13)
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && h&1 != 0) {
+		// may carry into the exponent, up to infinity.
+		h++
+	}
+	return h
+}
@@ -0,0 +1,54 @@
+package hdr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat16RoundTrip(t *testing.T) {
+	for h := 0; h <= math.MaxUint16; h++ {
+		f := Float16(uint16(h))
+		got := Float16Bits(f)
+		if f != f {
+			if got&0x7c00 != 0x7c00 || got&0x3ff == 0 {
+				t.Errorf("Float16Bits(Float16(%#04x)) = %#04x, expected a NaN", h, got)
+			}
+			continue
+		}
+		if got != uint16(h) {
+			t.Errorf("Float16Bits(Float16(%#04x)) = %#04x (%v)", h, got, f)
+		}
+	}
+}
+
+func TestFloat16Bits(t *testing.T) {
+	tests := []struct {
+		f float32
+		h uint16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{65504, 0x7bff},
+		// halfway to the next half float rounds to even.
+		{1 + 1.0/2048, 0x3c00},
+		{1 + 3.0/2048, 0x3c02},
+		{65519, 0x7bff},
+		{65520, 0x7c00},
+		{1e10, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		// subnormals
+		{float32(math.Ldexp(1, -24)), 0x0001},
+		{float32(math.Ldexp(1, -25)), 0x0000},
+		{float32(math.Ldexp(3, -25)), 0x0002},
+		{float32(math.Ldexp(1023, -24)), 0x03ff},
+		{float32(math.Ldexp(1, -14)), 0x0400},
+		{1e-10, 0x0000},
+	}
+	for _, tt := range tests {
+		if got := Float16Bits(tt.f); got != tt.h {
+			t.Errorf("Float16Bits(%v) = %#04x, expected %#04x", tt.f, got, tt.h)
+		}
+	}
+}
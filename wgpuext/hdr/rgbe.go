@@ -0,0 +1,167 @@
+package hdr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// DecodeRGBE reads a Radiance RGBE image, usually with the .hdr extension,
+// from r. Its pixels are opaque.
+//
+// The pixels are allocated as scanlines are read, so a header claiming a
+// large size doesn't allocate more than the data holds.
+func DecodeRGBE(r io.Reader) (*Image, error) {
+	br := bufio.NewReader(r)
+
+	line, err := br.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "#?") {
+		return nil, errors.New("hdr: invalid RGBE header")
+	}
+	for {
+		line, err = br.ReadString('\n')
+		if err != nil {
+			return nil, errors.New("hdr: truncated RGBE header")
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if format, ok := strings.CutPrefix(line, "FORMAT="); ok && format != "32-bit_rle_rgbe" {
+			return nil, fmt.Errorf("hdr: unsupported RGBE format %s", format)
+		}
+	}
+
+	line, err = br.ReadString('\n')
+	if err != nil {
+		return nil, errors.New("hdr: truncated RGBE header")
+	}
+	var yAxis, xAxis string
+	var width, height int
+	if _, err := fmt.Sscanf(line, "%s %d %s %d", &yAxis, &height, &xAxis, &width); err != nil {
+		return nil, errors.New("hdr: invalid RGBE resolution")
+	}
+	if (yAxis != "-Y" && yAxis != "+Y") || xAxis != "+X" {
+		return nil, fmt.Errorf("hdr: unsupported RGBE orientation %s %s", yAxis, xAxis)
+	}
+	if width <= 0 || height <= 0 || width > maxDimension || height > maxDimension {
+		return nil, fmt.Errorf("hdr: invalid RGBE size %dx%d", width, height)
+	}
+
+	img := &Image{Width: width, Height: height}
+	scanline := make([]byte, width*4)
+	for y := 0; y < height; y++ {
+		if err := readRGBEScanline(br, scanline); err != nil {
+			return nil, err
+		}
+		img.Pix = append(img.Pix, make([]float32, width*4)...)
+		pix := img.Pix[y*width*4:]
+		for x := 0; x < width; x++ {
+			r, g, b, e := scanline[x*4], scanline[x*4+1], scanline[x*4+2], scanline[x*4+3]
+			if e != 0 {
+				f := float32(math.Ldexp(1, int(e)-(128+8)))
+				pix[x*4] = (float32(r) + 0.5) * f
+				pix[x*4+1] = (float32(g) + 0.5) * f
+				pix[x*4+2] = (float32(b) + 0.5) * f
+			}
+			pix[x*4+3] = 1
+		}
+	}
+
+	// +Y images are stored from the bottom.
+	if yAxis == "+Y" {
+		row := make([]float32, width*4)
+		for y := 0; y < height/2; y++ {
+			top := img.Pix[y*width*4 : (y+1)*width*4]
+			bottom := img.Pix[(height-1-y)*width*4 : (height-y)*width*4]
+			copy(row, top)
+			copy(top, bottom)
+			copy(bottom, row)
+		}
+	}
+	return img, nil
+}
+
+// readRGBEScanline reads a scanline of RGBE pixels to scanline, in the run
+// length encoding of each component of current files, or flat with the run
+// length encoding of old ones.
+func readRGBEScanline(br *bufio.Reader, scanline []byte) error {
+	width := len(scanline) / 4
+	head, err := br.Peek(4)
+	if err != nil {
+		return errors.New("hdr: truncated RGBE data")
+	}
+	if width >= 8 && width < 0x8000 && head[0] == 2 && head[1] == 2 && head[2]&0x80 == 0 {
+		if int(head[2])<<8|int(head[3]) != width {
+			return errors.New("hdr: invalid RGBE scanline width")
+		}
+		br.Discard(4)
+		for c := 0; c < 4; c++ {
+			for x := 0; x < width; {
+				n, err := br.ReadByte()
+				if err != nil {
+					return errors.New("hdr: truncated RGBE data")
+				}
+				count := int(n)
+				run := count > 128
+				if run {
+					count -= 128
+				}
+				if count == 0 || x+count > width {
+					return errors.New("hdr: invalid RGBE run length")
+				}
+				if run {
+					v, err := br.ReadByte()
+					if err != nil {
+						return errors.New("hdr: truncated RGBE data")
+					}
+					for i := 0; i < count; i++ {
+						scanline[(x+i)*4+c] = v
+					}
+				} else {
+					for i := 0; i < count; i++ {
+						v, err := br.ReadByte()
+						if err != nil {
+							return errors.New("hdr: truncated RGBE data")
+						}
+						scanline[(x+i)*4+c] = v
+					}
+				}
+				x += count
+			}
+		}
+		return nil
+	}
+
+	// pixels of 1, 1, 1 repeat the previous pixel, their exponent times the
+	// repeat count of consecutive repeats.
+	shift := 0
+	for x := 0; x < width; {
+		var px [4]byte
+		if _, err := io.ReadFull(br, px[:]); err != nil {
+			return errors.New("hdr: truncated RGBE data")
+		}
+		if px[0] == 1 && px[1] == 1 && px[2] == 1 {
+			if x == 0 {
+				return errors.New("hdr: invalid RGBE run length")
+			}
+			count := int(px[3]) << shift
+			if x+count > width {
+				return errors.New("hdr: invalid RGBE run length")
+			}
+			for i := 0; i < count; i++ {
+				copy(scanline[x*4:x*4+4], scanline[(x-1)*4:x*4])
+				x++
+			}
+			shift += 8
+			continue
+		}
+		copy(scanline[x*4:x*4+4], px[:])
+		x++
+		shift = 0
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package hdr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// rgbe returns the float of the component v of a pixel with exponent e.
+func rgbe(v, e byte) float32 {
+	if e == 0 {
+		return 0
+	}
+	f := float32(v) + 0.5
+	for i := 0; i < int(e)-136; i++ {
+		f *= 2
+	}
+	for i := 0; i > int(e)-136; i-- {
+		f /= 2
+	}
+	return f
+}
+
+func TestDecodeRGBE(t *testing.T) {
+	// pixels of a 10x2 image, with the run length encoding of each component.
+	var current bytes.Buffer
+	current.WriteString("#?RADIANCE\n# comment\nFORMAT=32-bit_rle_rgbe\n\n-Y 2 +X 10\n")
+	for y := 0; y < 2; y++ {
+		current.Write([]byte{2, 2, 0, 10})
+		current.Write([]byte{128 + 10, 100 + byte(y)})          // r: run
+		current.Write([]byte{10, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) // g: literal
+		current.Write([]byte{4, 9, 8, 7, 6, 128 + 6, 50})       // b: literal and run
+		current.Write([]byte{128 + 10, 129})                    // e: run
+	}
+
+	img, err := DecodeRGBE(&current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width != 10 || img.Height != 2 || len(img.Pix) != 10*2*4 {
+		t.Fatalf("got %dx%d image with %d values", img.Width, img.Height, len(img.Pix))
+	}
+	b := []byte{9, 8, 7, 6, 50, 50, 50, 50, 50, 50}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 10; x++ {
+			expected := [4]float32{rgbe(100+byte(y), 129), rgbe(byte(x), 129), rgbe(b[x], 129), 1}
+			var got [4]float32
+			copy(got[:], img.Pix[(y*10+x)*4:])
+			if got != expected {
+				t.Errorf("pixel %d,%d is %v, expected %v", x, y, got, expected)
+			}
+		}
+	}
+
+	// pixels of a 3x2 image stored from the bottom, flat with runs of the
+	// previous pixel.
+	var old bytes.Buffer
+	old.WriteString("#?RGBE\n\n+Y 2 +X 3\n")
+	old.Write([]byte{128, 0, 0, 129, 1, 1, 1, 2}) // bottom row
+	old.Write([]byte{0, 0, 0, 0, 64, 64, 64, 130, 1, 1, 1, 1})
+
+	img, err = DecodeRGBE(&old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float32{
+		0, 0, 0, 1, rgbe(64, 130), rgbe(64, 130), rgbe(64, 130), 1, rgbe(64, 130), rgbe(64, 130), rgbe(64, 130), 1,
+		rgbe(128, 129), rgbe(0, 129), rgbe(0, 129), 1, rgbe(128, 129), rgbe(0, 129), rgbe(0, 129), 1, rgbe(128, 129), rgbe(0, 129), rgbe(0, 129), 1,
+	}
+	if !equal(img.Pix, expected) {
+		t.Errorf("got pixels %v, expected %v", img.Pix, expected)
+	}
+}
+
+func TestDecodeRGBEInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"RADIANCE\n\n-Y 1 +X 1\n\x00\x00\x00\x00",
+		"#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n\x00\x00\x00\x00",
+		"#?RADIANCE\n\n+X 1 -Y 1\n\x00\x00\x00\x00",
+		"#?RADIANCE\n\n-Y 0 +X 1\n",
+		"#?RADIANCE\n\n-Y 100000 +X 100000\n\x00\x00\x00\x00",
+		// truncated data
+		"#?RADIANCE\n\n-Y 2 +X 1\n\x00\x00\x00\x00",
+		// run longer than the scanline
+		"#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x08\x89\x00",
+		// run of a missing previous pixel
+		"#?RADIANCE\n\n-Y 1 +X 2\n\x01\x01\x01\x02",
+	}
+	for _, tt := range tests {
+		if _, err := DecodeRGBE(strings.NewReader(tt)); err == nil {
+			t.Errorf("DecodeRGBE(%q) succeeded, expected an error", tt)
+		}
+	}
+}
+
+func equal(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
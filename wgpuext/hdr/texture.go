@@ -0,0 +1,140 @@
+package hdr
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+type TextureDescriptor struct {
+	Label string
+	// Format is TextureFormat_RGBA16Float by default, or
+	// TextureFormat_RGBA32Float.
+	Format wgpu.TextureFormat
+	// Usage is TextureUsage_TextureBinding by default, TextureUsage_CopyDst
+	// is always added.
+	Usage wgpu.TextureUsage
+}
+
+// CreateTexture creates a 2D texture of the size of p with a single mip
+// level, and writes p to it.
+func (p *Image) CreateTexture(device *wgpu.Device, descriptor *TextureDescriptor) (*wgpu.Texture, error) {
+	var d TextureDescriptor
+	if descriptor != nil {
+		d = *descriptor
+	}
+	if d.Format == wgpu.TextureFormat_Undefined {
+		d.Format = wgpu.TextureFormat_RGBA16Float
+	}
+	if d.Usage == 0 {
+		d.Usage = wgpu.TextureUsage_TextureBinding
+	}
+	if len(p.Pix) != p.Width*p.Height*4 {
+		return nil, fmt.Errorf("hdr: image of %dx%d has %d values", p.Width, p.Height, len(p.Pix))
+	}
+
+	var data []byte
+	switch d.Format {
+	case wgpu.TextureFormat_RGBA16Float:
+		data = make([]byte, len(p.Pix)*2)
+		for i, v := range p.Pix {
+			binary.LittleEndian.PutUint16(data[i*2:], Float16Bits(v))
+		}
+	case wgpu.TextureFormat_RGBA32Float:
+		data = make([]byte, len(p.Pix)*4)
+		for i, v := range p.Pix {
+			binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(v))
+		}
+	default:
+		return nil, fmt.Errorf("hdr: unsupported texture format %s", d.Format)
+	}
+
+	size := wgpu.Extent3D{
+		Width:              uint32(p.Width),
+		Height:             uint32(p.Height),
+		DepthOrArrayLayers: 1,
+	}
+	texture, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         d.Label,
+		Usage:         d.Usage | wgpu.TextureUsage_CopyDst,
+		Dimension:     wgpu.TextureDimension_2D,
+		Size:          size,
+		Format:        d.Format,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queue := device.GetQueue()
+	defer queue.Release()
+	err = queue.WriteTexture(&wgpu.ImageCopyTexture{
+		Texture: texture,
+		Aspect:  wgpu.TextureAspect_All,
+	}, data, &wgpu.TextureDataLayout{
+		BytesPerRow:  uint32(len(data) / p.Height),
+		RowsPerImage: uint32(p.Height),
+	}, &size)
+	if err != nil {
+		texture.Release()
+		return nil, err
+	}
+	return texture, nil
+}
+
+// FromTexture reads the first mip level of the first layer of texture,
+// which must have TextureUsage_CopySrc, to an image. The texture format is
+// one of the R, RG or RGBA 16 and 32-bit float formats. Missing color
+// channels are 0, and alpha is 1 if the format has none.
+func FromTexture(ctx context.Context, device *wgpu.Device, texture *wgpu.Texture) (*Image, error) {
+	format := texture.GetFormat()
+	var components, size int
+	switch format {
+	case wgpu.TextureFormat_R16Float:
+		components, size = 1, 2
+	case wgpu.TextureFormat_RG16Float:
+		components, size = 2, 2
+	case wgpu.TextureFormat_RGBA16Float:
+		components, size = 4, 2
+	case wgpu.TextureFormat_R32Float:
+		components, size = 1, 4
+	case wgpu.TextureFormat_RG32Float:
+		components, size = 2, 4
+	case wgpu.TextureFormat_RGBA32Float:
+		components, size = 4, 4
+	default:
+		return nil, fmt.Errorf("hdr: unsupported texture format %s", format)
+	}
+
+	width, height := texture.GetWidth(), texture.GetHeight()
+	data, err := device.ReadTexture(ctx, wgpu.ImageCopyTexture{
+		Texture: texture,
+		Aspect:  wgpu.TextureAspect_All,
+	}, wgpu.Extent3D{
+		Width:              width,
+		Height:             height,
+		DepthOrArrayLayers: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img := NewImage(int(width), int(height))
+	for i := 0; i < int(width*height); i++ {
+		texel := data[i*components*size:]
+		pix := img.Pix[i*4 : i*4+4]
+		pix[3] = 1
+		for c := 0; c < components; c++ {
+			if size == 2 {
+				pix[c] = Float16(binary.LittleEndian.Uint16(texel[c*2:]))
+			} else {
+				pix[c] = math.Float32frombits(binary.LittleEndian.Uint32(texel[c*4:]))
+			}
+		}
+	}
+	return img, nil
+}
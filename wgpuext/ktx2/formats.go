@@ -0,0 +1,322 @@
+package ktx2
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+// vkFormats maps the VkFormat values of the formats supported by the package
+// to texture formats.
+var vkFormats = map[uint32]wgpu.TextureFormat{
+	9:   wgpu.TextureFormat_R8Unorm,
+	10:  wgpu.TextureFormat_R8Snorm,
+	13:  wgpu.TextureFormat_R8Uint,
+	14:  wgpu.TextureFormat_R8Sint,
+	16:  wgpu.TextureFormat_RG8Unorm,
+	17:  wgpu.TextureFormat_RG8Snorm,
+	20:  wgpu.TextureFormat_RG8Uint,
+	21:  wgpu.TextureFormat_RG8Sint,
+	37:  wgpu.TextureFormat_RGBA8Unorm,
+	38:  wgpu.TextureFormat_RGBA8Snorm,
+	41:  wgpu.TextureFormat_RGBA8Uint,
+	42:  wgpu.TextureFormat_RGBA8Sint,
+	43:  wgpu.TextureFormat_RGBA8UnormSrgb,
+	44:  wgpu.TextureFormat_BGRA8Unorm,
+	50:  wgpu.TextureFormat_BGRA8UnormSrgb,
+	64:  wgpu.TextureFormat_RGB10A2Unorm,
+	74:  wgpu.TextureFormat_R16Uint,
+	75:  wgpu.TextureFormat_R16Sint,
+	76:  wgpu.TextureFormat_R16Float,
+	81:  wgpu.TextureFormat_RG16Uint,
+	82:  wgpu.TextureFormat_RG16Sint,
+	83:  wgpu.TextureFormat_RG16Float,
+	95:  wgpu.TextureFormat_RGBA16Uint,
+	96:  wgpu.TextureFormat_RGBA16Sint,
+	97:  wgpu.TextureFormat_RGBA16Float,
+	98:  wgpu.TextureFormat_R32Uint,
+	99:  wgpu.TextureFormat_R32Sint,
+	100: wgpu.TextureFormat_R32Float,
+	101: wgpu.TextureFormat_RG32Uint,
+	102: wgpu.TextureFormat_RG32Sint,
+	103: wgpu.TextureFormat_RG32Float,
+	107: wgpu.TextureFormat_RGBA32Uint,
+	108: wgpu.TextureFormat_RGBA32Sint,
+	109: wgpu.TextureFormat_RGBA32Float,
+	122: wgpu.TextureFormat_RG11B10Ufloat,
+	123: wgpu.TextureFormat_RGB9E5Ufloat,
+	124: wgpu.TextureFormat_Depth16Unorm,
+	126: wgpu.TextureFormat_Depth32Float,
+	127: wgpu.TextureFormat_Stencil8,
+	133: wgpu.TextureFormat_BC1RGBAUnorm,
+	134: wgpu.TextureFormat_BC1RGBAUnormSrgb,
+	135: wgpu.TextureFormat_BC2RGBAUnorm,
+	136: wgpu.TextureFormat_BC2RGBAUnormSrgb,
+	137: wgpu.TextureFormat_BC3RGBAUnorm,
+	138: wgpu.TextureFormat_BC3RGBAUnormSrgb,
+	139: wgpu.TextureFormat_BC4RUnorm,
+	140: wgpu.TextureFormat_BC4RSnorm,
+	141: wgpu.TextureFormat_BC5RGUnorm,
+	142: wgpu.TextureFormat_BC5RGSnorm,
+	143: wgpu.TextureFormat_BC6HRGBUfloat,
+	144: wgpu.TextureFormat_BC6HRGBFloat,
+	145: wgpu.TextureFormat_BC7RGBAUnorm,
+	146: wgpu.TextureFormat_BC7RGBAUnormSrgb,
+	147: wgpu.TextureFormat_ETC2RGB8Unorm,
+	148: wgpu.TextureFormat_ETC2RGB8UnormSrgb,
+	149: wgpu.TextureFormat_ETC2RGB8A1Unorm,
+	150: wgpu.TextureFormat_ETC2RGB8A1UnormSrgb,
+	151: wgpu.TextureFormat_ETC2RGBA8Unorm,
+	152: wgpu.TextureFormat_ETC2RGBA8UnormSrgb,
+	153: wgpu.TextureFormat_EACR11Unorm,
+	154: wgpu.TextureFormat_EACR11Snorm,
+	155: wgpu.TextureFormat_EACRG11Unorm,
+	156: wgpu.TextureFormat_EACRG11Snorm,
+	157: wgpu.TextureFormat_ASTC4x4Unorm,
+	158: wgpu.TextureFormat_ASTC4x4UnormSrgb,
+	159: wgpu.TextureFormat_ASTC5x4Unorm,
+	160: wgpu.TextureFormat_ASTC5x4UnormSrgb,
+	161: wgpu.TextureFormat_ASTC5x5Unorm,
+	162: wgpu.TextureFormat_ASTC5x5UnormSrgb,
+	163: wgpu.TextureFormat_ASTC6x5Unorm,
+	164: wgpu.TextureFormat_ASTC6x5UnormSrgb,
+	165: wgpu.TextureFormat_ASTC6x6Unorm,
+	166: wgpu.TextureFormat_ASTC6x6UnormSrgb,
+	167: wgpu.TextureFormat_ASTC8x5Unorm,
+	168: wgpu.TextureFormat_ASTC8x5UnormSrgb,
+	169: wgpu.TextureFormat_ASTC8x6Unorm,
+	170: wgpu.TextureFormat_ASTC8x6UnormSrgb,
+	171: wgpu.TextureFormat_ASTC8x8Unorm,
+	172: wgpu.TextureFormat_ASTC8x8UnormSrgb,
+	173: wgpu.TextureFormat_ASTC10x5Unorm,
+	174: wgpu.TextureFormat_ASTC10x5UnormSrgb,
+	175: wgpu.TextureFormat_ASTC10x6Unorm,
+	176: wgpu.TextureFormat_ASTC10x6UnormSrgb,
+	177: wgpu.TextureFormat_ASTC10x8Unorm,
+	178: wgpu.TextureFormat_ASTC10x8UnormSrgb,
+	179: wgpu.TextureFormat_ASTC10x10Unorm,
+	180: wgpu.TextureFormat_ASTC10x10UnormSrgb,
+	181: wgpu.TextureFormat_ASTC12x10Unorm,
+	182: wgpu.TextureFormat_ASTC12x10UnormSrgb,
+	183: wgpu.TextureFormat_ASTC12x12Unorm,
+	184: wgpu.TextureFormat_ASTC12x12UnormSrgb,
+}
+
+// VkFormat returns the VkFormat of format, or false if it isn't supported.
+func VkFormat(format wgpu.TextureFormat) (uint32, bool) {
+	for vk, f := range vkFormats {
+		if f == format {
+			return vk, true
+		}
+	}
+	return 0, false
+}
+
+// Data format descriptor color models, channels and sample qualifiers.
+const (
+	modelRGBSDA = 1
+	modelBC1A   = 128
+	modelBC2    = 129
+	modelBC3    = 130
+	modelBC4    = 131
+	modelBC5    = 132
+	modelBC6H   = 133
+	modelBC7    = 134
+	modelETC2   = 161
+	modelASTC   = 162
+
+	channelR       = 0
+	channelG       = 1
+	channelB       = 2
+	channelStencil = 13
+	channelDepth   = 14
+	channelA       = 15
+
+	channelETC2Red   = 0
+	channelETC2Green = 1
+	channelETC2Color = 2
+	channelETC2Alpha = 15
+
+	qualifierLinear   = 0x10
+	qualifierExponent = 0x20
+	qualifierSigned   = 0x40
+	qualifierFloat    = 0x80
+)
+
+type dfdSample struct {
+	offset, length uint16
+	channel        uint8
+	lower, upper   uint32
+}
+
+// dfd returns the data format descriptor of format, a basic descriptor block
+// preceded by its total size.
+func dfd(format wgpu.TextureFormat) []byte {
+	info := format.Info()
+	model := uint8(modelRGBSDA)
+	var samples []dfdSample
+
+	// sample returns a sample of the channel of length bits at offset, with
+	// the range of values of the numeric type of format.
+	sample := func(channel uint8, offset, length uint16) dfdSample {
+		s := dfdSample{offset: offset, length: length, channel: channel}
+		name := format.String()
+		switch {
+		case strings.HasSuffix(name, "Float"):
+			s.channel |= qualifierFloat | qualifierSigned
+			s.lower, s.upper = math.Float32bits(-1), math.Float32bits(1)
+		case strings.HasSuffix(name, "Ufloat"):
+			s.channel |= qualifierFloat
+			s.upper = math.Float32bits(1)
+		case strings.HasSuffix(name, "Sint"):
+			s.channel |= qualifierSigned
+			s.lower, s.upper = math.MaxUint32, 1
+		case strings.HasSuffix(name, "Uint") || format == wgpu.TextureFormat_Stencil8:
+			s.upper = 1
+		case strings.HasSuffix(name, "Snorm"):
+			s.channel |= qualifierSigned
+			if length >= 32 {
+				s.lower, s.upper = 1<<31, math.MaxInt32
+			} else {
+				s.lower, s.upper = uint32(-int32(1<<(length-1)-1)), 1<<(length-1)-1
+			}
+		default:
+			if length >= 32 {
+				s.upper = math.MaxUint32
+			} else {
+				s.upper = 1<<length - 1
+			}
+		}
+		return s
+	}
+
+	switch {
+	case info.Compressed:
+		name := format.String()
+		switch {
+		case strings.HasPrefix(name, "BC1"):
+			model = modelBC1A
+			samples = []dfdSample{sample(1, 0, 64)}
+		case strings.HasPrefix(name, "BC2"), strings.HasPrefix(name, "BC3"):
+			model = modelBC2
+			if strings.HasPrefix(name, "BC3") {
+				model = modelBC3
+			}
+			samples = []dfdSample{sample(channelA, 0, 64), sample(0, 64, 64)}
+		case strings.HasPrefix(name, "BC4"):
+			model = modelBC4
+			samples = []dfdSample{sample(0, 0, 64)}
+		case strings.HasPrefix(name, "BC5"):
+			model = modelBC5
+			samples = []dfdSample{sample(0, 0, 64), sample(1, 64, 64)}
+		case strings.HasPrefix(name, "BC6H"):
+			model = modelBC6H
+			samples = []dfdSample{sample(0, 0, 128)}
+		case strings.HasPrefix(name, "BC7"):
+			model = modelBC7
+			samples = []dfdSample{sample(0, 0, 128)}
+		case strings.HasPrefix(name, "ETC2RGBA8"):
+			model = modelETC2
+			samples = []dfdSample{sample(channelETC2Alpha, 0, 64), sample(channelETC2Color, 64, 64)}
+		case strings.HasPrefix(name, "ETC2"):
+			model = modelETC2
+			samples = []dfdSample{sample(channelETC2Color, 0, 64)}
+		case strings.HasPrefix(name, "EACRG11"):
+			model = modelETC2
+			samples = []dfdSample{sample(channelETC2Red, 0, 64), sample(channelETC2Green, 64, 64)}
+		case strings.HasPrefix(name, "EACR11"):
+			model = modelETC2
+			samples = []dfdSample{sample(channelETC2Red, 0, 64)}
+		default:
+			model = modelASTC
+			samples = []dfdSample{sample(0, 0, 128)}
+		}
+		for i := range samples {
+			// the whole block is the value of the samples.
+			if samples[i].channel&qualifierFloat == 0 {
+				samples[i].lower, samples[i].upper = 0, math.MaxUint32
+				if samples[i].channel&qualifierSigned != 0 {
+					samples[i].lower, samples[i].upper = 1<<31, math.MaxInt32
+				}
+			}
+		}
+
+	case format == wgpu.TextureFormat_Depth16Unorm, format == wgpu.TextureFormat_Depth32Float:
+		samples = []dfdSample{sample(channelDepth, 0, uint16(info.BlockSize*8))}
+	case format == wgpu.TextureFormat_Stencil8:
+		samples = []dfdSample{sample(channelStencil, 0, 8)}
+
+	case format == wgpu.TextureFormat_RGB10A2Unorm:
+		samples = []dfdSample{sample(channelR, 0, 10), sample(channelG, 10, 10), sample(channelB, 20, 10), sample(channelA, 30, 2)}
+	case format == wgpu.TextureFormat_RG11B10Ufloat:
+		samples = []dfdSample{sample(channelR, 0, 11), sample(channelG, 11, 11), sample(channelB, 22, 10)}
+	case format == wgpu.TextureFormat_RGB9E5Ufloat:
+		// each channel is a mantissa sample followed by the shared exponent.
+		for i, channel := range []uint8{channelR, channelG, channelB} {
+			samples = append(samples,
+				dfdSample{offset: uint16(i * 9), length: 9, channel: channel, upper: 1<<9 - 1},
+				dfdSample{offset: 27, length: 5, channel: channel | qualifierExponent, lower: 15, upper: 31},
+			)
+		}
+
+	default:
+		channels := []uint8{channelR, channelG, channelB, channelA}[:info.Components]
+		if format == wgpu.TextureFormat_BGRA8Unorm || format == wgpu.TextureFormat_BGRA8UnormSrgb {
+			channels = []uint8{channelB, channelG, channelR, channelA}
+		}
+		bits := uint16(info.BlockSize * 8 / info.Components)
+		for i, channel := range channels {
+			samples = append(samples, sample(channel, uint16(i)*bits, bits))
+		}
+	}
+
+	var primaries, transfer uint8 = 1, 1 // BT.709, linear
+	if info.Srgb {
+		transfer = 2
+		for i := range samples {
+			if samples[i].channel&0xf == channelA && !info.Compressed {
+				samples[i].channel |= qualifierLinear
+			}
+		}
+	}
+	if !info.Color {
+		primaries = 0
+	}
+
+	blockSize := 24 + 16*len(samples)
+	b := make([]byte, 4+blockSize)
+	le := binary.LittleEndian
+	le.PutUint32(b[0:], uint32(len(b)))
+	le.PutUint32(b[4:], 0) // vendor and descriptor type
+	le.PutUint16(b[8:], 2) // version
+	le.PutUint16(b[10:], uint16(blockSize))
+	b[12], b[13], b[14], b[15] = model, primaries, transfer, 0
+	b[16], b[17] = uint8(info.BlockWidth-1), uint8(info.BlockHeight-1)
+	b[20] = uint8(info.BlockSize)
+	for i, s := range samples {
+		o := 28 + 16*i
+		le.PutUint16(b[o:], s.offset)
+		b[o+2] = uint8(s.length - 1)
+		b[o+3] = s.channel
+		le.PutUint32(b[o+8:], s.lower)
+		le.PutUint32(b[o+12:], s.upper)
+	}
+	return b
+}
+
+// typeSize returns the size of the data type of format, for endianness
+// conversion.
+func typeSize(format wgpu.TextureFormat) uint32 {
+	info := format.Info()
+	switch {
+	case info.Compressed:
+		return 1
+	case format == wgpu.TextureFormat_RGB10A2Unorm,
+		format == wgpu.TextureFormat_RG11B10Ufloat,
+		format == wgpu.TextureFormat_RGB9E5Ufloat:
+		return 4
+	}
+	return info.BlockSize / info.Components
+}
@@ -0,0 +1,8 @@
+module github.com/rajveermalviya/go-webgpu/wgpuext/ktx2
+
+go 1.22
+
+require (
+	github.com/klauspost/compress v1.18.0
+	github.com/rajveermalviya/go-webgpu/wgpu v0.17.1
+)
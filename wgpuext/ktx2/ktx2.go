@@ -0,0 +1,411 @@
+// Package ktx2 reads and writes KTX 2.0 texture containers, and creates
+// textures from them.
+package ktx2 // import "github.com/rajveermalviya/go-webgpu/wgpuext/ktx2"
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+type Supercompression uint32
+
+const (
+	Supercompression_None    Supercompression = 0
+	Supercompression_BasisLZ Supercompression = 1
+	Supercompression_Zstd    Supercompression = 2
+	Supercompression_Zlib    Supercompression = 3
+)
+
+func (v Supercompression) String() string {
+	switch v {
+	case Supercompression_None:
+		return "None"
+	case Supercompression_BasisLZ:
+		return "BasisLZ"
+	case Supercompression_Zstd:
+		return "Zstd"
+	case Supercompression_Zlib:
+		return "Zlib"
+	default:
+		return ""
+	}
+}
+
+var identifier = [12]byte{0xab, 'K', 'T', 'X', ' ', '2', '0', 0xbb, '\r', '\n', 0x1a, '\n'}
+
+const (
+	headerSize     = 80
+	levelIndexSize = 24
+
+	// maxDimension, maxLayers and maxLevels bound the size Decode accepts,
+	// well above what devices support.
+	maxDimension = 1 << 16
+	maxLayers    = 1 << 11
+	maxLevels    = 17
+)
+
+// maxCompressionRatio returns a bound on the ratio of uncompressed to
+// compressed size scheme reaches, on constant data, so that a small file
+// can't make Decode allocate much memory.
+func maxCompressionRatio(scheme Supercompression) uint64 {
+	switch scheme {
+	case Supercompression_Zstd:
+		// an RLE block of 4 bytes decodes to up to 128 KiB.
+		return 1 << 15
+	case Supercompression_Zlib:
+		return 1032
+	}
+	return 1
+}
+
+// File is a KTX 2.0 file.
+type File struct {
+	Format wgpu.TextureFormat
+	// Width is the width of the base level in texels. Height is zero for 1D
+	// textures, and Depth for non 3D textures.
+	Width  uint32
+	Height uint32
+	Depth  uint32
+	// Layers is the number of array layers, zero if the texture isn't an
+	// array. Faces is 6 for cube maps, 1 otherwise.
+	Layers uint32
+	Faces  uint32
+	// Levels are the mip levels, starting with the base level. Each holds
+	// the tightly packed blocks of every layer, face and depth slice, in that
+	// order.
+	Levels [][]byte
+	// KeyValues is the key/value data. Values of string keys defined by the
+	// specification, like KTXorientation, end with a NUL byte.
+	KeyValues map[string][]byte
+	// Supercompression is the scheme levels are compressed with in the file.
+	// Decode decompresses them, Encode compresses them with it, it must be
+	// Supercompression_None, Supercompression_Zstd or Supercompression_Zlib.
+	Supercompression Supercompression
+}
+
+// Descriptor returns the descriptor of a texture holding the contents of p.
+func (p *File) Descriptor() wgpu.TextureDescriptor {
+	desc := wgpu.TextureDescriptor{
+		Dimension: wgpu.TextureDimension_2D,
+		Size: wgpu.Extent3D{
+			Width:              p.Width,
+			Height:             max(p.Height, 1),
+			DepthOrArrayLayers: max(p.Layers, 1) * max(p.Faces, 1),
+		},
+		Format:        p.Format,
+		MipLevelCount: max(uint32(len(p.Levels)), 1),
+		SampleCount:   1,
+	}
+	switch {
+	case p.Height == 0:
+		desc.Dimension = wgpu.TextureDimension_1D
+	case p.Depth != 0:
+		desc.Dimension = wgpu.TextureDimension_3D
+		desc.Size.DepthOrArrayLayers = p.Depth
+	}
+	return desc
+}
+
+// ViewDimension returns the dimension of views of the whole texture.
+func (p *File) ViewDimension() wgpu.TextureViewDimension {
+	switch {
+	case p.Height == 0:
+		return wgpu.TextureViewDimension_1D
+	case p.Depth != 0:
+		return wgpu.TextureViewDimension_3D
+	case p.Faces == 6 && p.Layers != 0:
+		return wgpu.TextureViewDimension_CubeArray
+	case p.Faces == 6:
+		return wgpu.TextureViewDimension_Cube
+	case p.Layers != 0:
+		return wgpu.TextureViewDimension_2DArray
+	default:
+		return wgpu.TextureViewDimension_2D
+	}
+}
+
+// levelLayout returns the layout of the tightly packed data of level.
+func (p *File) levelLayout(level uint32) (wgpu.TextureCopyLayout, error) {
+	desc := p.Descriptor()
+	return wgpu.CalculateTextureCopyLayout(&desc, level, wgpu.TextureAspect_All, nil, false)
+}
+
+// Decode reads a KTX 2.0 file from r.
+func Decode(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < headerSize || !bytes.Equal(data[:12], identifier[:]) {
+		return nil, errors.New("ktx2: invalid identifier")
+	}
+
+	le := binary.LittleEndian
+	vkFormat := le.Uint32(data[12:])
+	levelCount := le.Uint32(data[40:])
+	f := &File{
+		Width:            le.Uint32(data[20:]),
+		Height:           le.Uint32(data[24:]),
+		Depth:            le.Uint32(data[28:]),
+		Layers:           le.Uint32(data[32:]),
+		Faces:            le.Uint32(data[36:]),
+		Supercompression: Supercompression(le.Uint32(data[44:])),
+		KeyValues:        map[string][]byte{},
+	}
+	kvdOffset, kvdLength := uint64(le.Uint32(data[56:])), uint64(le.Uint32(data[60:]))
+
+	var ok bool
+	f.Format, ok = vkFormats[vkFormat]
+	if !ok {
+		return nil, fmt.Errorf("ktx2: unsupported vkFormat %d", vkFormat)
+	}
+	switch f.Supercompression {
+	case Supercompression_None, Supercompression_Zstd, Supercompression_Zlib:
+	default:
+		return nil, fmt.Errorf("ktx2: unsupported supercompression scheme %d", f.Supercompression)
+	}
+	if f.Width == 0 || (f.Depth != 0 && f.Height == 0) || (f.Depth != 0 && f.Layers != 0) || (f.Faces != 1 && f.Faces != 6) {
+		return nil, errors.New("ktx2: invalid texture size")
+	}
+	if f.Width > maxDimension || f.Height > maxDimension || f.Depth > maxDimension || f.Layers > maxLayers {
+		return nil, fmt.Errorf("ktx2: texture size %dx%dx%d with %d layers is too large", f.Width, f.Height, f.Depth, f.Layers)
+	}
+	// a level count of zero asks the loader to generate the mip levels, only
+	// the base level is stored.
+	if levelCount == 0 {
+		levelCount = 1
+	}
+	if levelCount > maxLevels {
+		return nil, fmt.Errorf("ktx2: %d levels is too many", levelCount)
+	}
+	if uint64(levelCount)*levelIndexSize > uint64(len(data)-headerSize) {
+		return nil, errors.New("ktx2: truncated level index")
+	}
+
+	kvd, err := section(data, kvdOffset, kvdLength)
+	if err != nil {
+		return nil, err
+	}
+	for len(kvd) >= 4 {
+		n := uint64(le.Uint32(kvd))
+		if n > uint64(len(kvd)-4) {
+			return nil, errors.New("ktx2: truncated key/value data")
+		}
+		kv := kvd[4 : 4+n]
+		i := bytes.IndexByte(kv, 0)
+		if i < 0 {
+			return nil, errors.New("ktx2: key without NUL terminator")
+		}
+		f.KeyValues[string(kv[:i])] = append([]byte(nil), kv[i+1:]...)
+		kvd = kvd[min(alignUp(4+n, 4), uint64(len(kvd))):]
+	}
+
+	var zstdDecoder *zstd.Decoder
+	defer func() {
+		if zstdDecoder != nil {
+			zstdDecoder.Close()
+		}
+	}()
+
+	f.Levels = make([][]byte, levelCount)
+	for level := range f.Levels {
+		index := data[headerSize+level*levelIndexSize:]
+		offset, length, uncompressedLength := le.Uint64(index), le.Uint64(index[8:]), le.Uint64(index[16:])
+		b, err := section(data, offset, length)
+		if err != nil {
+			return nil, err
+		}
+
+		layout, err := f.levelLayout(uint32(level))
+		if err != nil {
+			return nil, fmt.Errorf("ktx2: %w", err)
+		}
+		if f.Supercompression != Supercompression_None && uncompressedLength != layout.Size {
+			return nil, fmt.Errorf("ktx2: level %d has %d bytes, expected %d", level, uncompressedLength, layout.Size)
+		}
+		if layout.Size > uint64(len(b))*maxCompressionRatio(f.Supercompression) {
+			return nil, fmt.Errorf("ktx2: level %d has %d bytes, expected %d", level, len(b), layout.Size)
+		}
+
+		switch f.Supercompression {
+		case Supercompression_Zstd:
+			if zstdDecoder == nil {
+				zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(len(data))*maxCompressionRatio(f.Supercompression)))
+				if err != nil {
+					return nil, err
+				}
+			}
+			b, err = zstdDecoder.DecodeAll(b, make([]byte, 0, uncompressedLength))
+			if err != nil {
+				return nil, fmt.Errorf("ktx2: level %d: %w", level, err)
+			}
+		case Supercompression_Zlib:
+			zr, err := zlib.NewReader(bytes.NewReader(b))
+			if err != nil {
+				return nil, fmt.Errorf("ktx2: level %d: %w", level, err)
+			}
+			b = make([]byte, uncompressedLength)
+			_, err = io.ReadFull(zr, b)
+			zr.Close()
+			if err != nil {
+				return nil, fmt.Errorf("ktx2: level %d: %w", level, err)
+			}
+		default:
+			b = append([]byte(nil), b...)
+		}
+		if uint64(len(b)) != layout.Size {
+			return nil, fmt.Errorf("ktx2: level %d has %d bytes, expected %d", level, len(b), layout.Size)
+		}
+		f.Levels[level] = b
+	}
+	return f, nil
+}
+
+// Encode writes f to w as a KTX 2.0 file.
+func Encode(w io.Writer, f *File) error {
+	vkFormat, ok := VkFormat(f.Format)
+	if !ok {
+		return fmt.Errorf("ktx2: unsupported format %s", f.Format)
+	}
+	if len(f.Levels) == 0 {
+		return errors.New("ktx2: no levels")
+	}
+	for level, b := range f.Levels {
+		layout, err := f.levelLayout(uint32(level))
+		if err != nil {
+			return fmt.Errorf("ktx2: %w", err)
+		}
+		if uint64(len(b)) != layout.Size {
+			return fmt.Errorf("ktx2: level %d has %d bytes, expected %d", level, len(b), layout.Size)
+		}
+	}
+
+	le := binary.LittleEndian
+	info := f.Format.Info()
+
+	levels := f.Levels
+	// levels are aligned to their blocks when stored as is.
+	alignment := lcm(uint64(info.BlockSize), 4)
+	switch f.Supercompression {
+	case Supercompression_None:
+	case Supercompression_Zstd:
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+		levels = make([][]byte, len(f.Levels))
+		for i, b := range f.Levels {
+			levels[i] = enc.EncodeAll(b, nil)
+		}
+		enc.Close()
+		alignment = 1
+	case Supercompression_Zlib:
+		levels = make([][]byte, len(f.Levels))
+		for i, b := range f.Levels {
+			var buf bytes.Buffer
+			zw := zlib.NewWriter(&buf)
+			zw.Write(b)
+			zw.Close()
+			levels[i] = buf.Bytes()
+		}
+		alignment = 1
+	default:
+		return fmt.Errorf("ktx2: unsupported supercompression scheme %d", f.Supercompression)
+	}
+
+	dfd := dfd(f.Format)
+
+	keys := make([]string, 0, len(f.KeyValues))
+	for k := range f.KeyValues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var kvd []byte
+	for _, k := range keys {
+		v := f.KeyValues[k]
+		kvd = le.AppendUint32(kvd, uint32(len(k)+1+len(v)))
+		kvd = append(kvd, k...)
+		kvd = append(kvd, 0)
+		kvd = append(kvd, v...)
+		for len(kvd)%4 != 0 {
+			kvd = append(kvd, 0)
+		}
+	}
+
+	dfdOffset := uint64(headerSize + levelIndexSize*len(levels))
+	kvdOffset := dfdOffset + uint64(len(dfd))
+	end := kvdOffset + uint64(len(kvd))
+	if len(kvd) == 0 {
+		kvdOffset = 0
+	}
+
+	// level data is stored from the smallest level to the base level.
+	offsets := make([]uint64, len(levels))
+	for level := len(levels) - 1; level >= 0; level-- {
+		end = alignUp(end, alignment)
+		offsets[level] = end
+		end += uint64(len(levels[level]))
+	}
+
+	out := make([]byte, 0, end)
+	out = append(out, identifier[:]...)
+	out = le.AppendUint32(out, vkFormat)
+	out = le.AppendUint32(out, typeSize(f.Format))
+	out = le.AppendUint32(out, f.Width)
+	out = le.AppendUint32(out, f.Height)
+	out = le.AppendUint32(out, f.Depth)
+	out = le.AppendUint32(out, f.Layers)
+	out = le.AppendUint32(out, max(f.Faces, 1))
+	out = le.AppendUint32(out, uint32(len(levels)))
+	out = le.AppendUint32(out, uint32(f.Supercompression))
+	out = le.AppendUint32(out, uint32(dfdOffset))
+	out = le.AppendUint32(out, uint32(len(dfd)))
+	out = le.AppendUint32(out, uint32(kvdOffset))
+	out = le.AppendUint32(out, uint32(len(kvd)))
+	out = le.AppendUint64(out, 0) // supercompression global data
+	out = le.AppendUint64(out, 0)
+	for level, b := range levels {
+		out = le.AppendUint64(out, offsets[level])
+		out = le.AppendUint64(out, uint64(len(b)))
+		out = le.AppendUint64(out, uint64(len(f.Levels[level])))
+	}
+	out = append(out, dfd...)
+	out = append(out, kvd...)
+	for level := len(levels) - 1; level >= 0; level-- {
+		for uint64(len(out)) < offsets[level] {
+			out = append(out, 0)
+		}
+		out = append(out, levels[level]...)
+	}
+
+	_, err := w.Write(out)
+	return err
+}
+
+// section returns the length bytes of data at offset.
+func section(data []byte, offset, length uint64) ([]byte, error) {
+	if offset > uint64(len(data)) || length > uint64(len(data))-offset {
+		return nil, errors.New("ktx2: section out of bounds")
+	}
+	return data[offset : offset+length], nil
+}
+
+func alignUp(v, alignment uint64) uint64 {
+	return (v + alignment - 1) / alignment * alignment
+}
+
+func lcm(a, b uint64) uint64 {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
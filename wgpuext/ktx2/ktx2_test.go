@@ -0,0 +1,138 @@
+package ktx2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+// testFile returns a file of the format and size with every level filled.
+func testFile(t *testing.T, f File, levels int) *File {
+	t.Helper()
+
+	f.Levels = make([][]byte, levels)
+	f.KeyValues = map[string][]byte{
+		"KTXorientation": []byte("rd\x00"),
+		"KTXwriter":      []byte("go-webgpu\x00"),
+	}
+	for level := range f.Levels {
+		layout, err := f.levelLayout(uint32(level))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, layout.Size)
+		for i := range b {
+			b[i] = byte(i*7 + level)
+		}
+		f.Levels[level] = b
+	}
+	return &f
+}
+
+func encode(t *testing.T, f *File) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, f); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestEncodeDecode(t *testing.T) {
+	files := []struct {
+		name   string
+		file   File
+		levels int
+	}{
+		{"2d array", File{Format: wgpu.TextureFormat_RGBA8UnormSrgb, Width: 13, Height: 7, Layers: 2, Faces: 1}, 4},
+		{"cube", File{Format: wgpu.TextureFormat_BC1RGBAUnorm, Width: 16, Height: 16, Faces: 6}, 5},
+		{"astc", File{Format: wgpu.TextureFormat_ASTC10x8Unorm, Width: 33, Height: 17, Faces: 1}, 3},
+		{"1d", File{Format: wgpu.TextureFormat_R16Float, Width: 9, Faces: 1}, 2},
+		{"3d", File{Format: wgpu.TextureFormat_RGB9E5Ufloat, Width: 4, Height: 4, Depth: 4, Faces: 1}, 3},
+	}
+	schemes := []Supercompression{Supercompression_None, Supercompression_Zlib, Supercompression_Zstd}
+
+	for _, tt := range files {
+		for _, scheme := range schemes {
+			t.Run(tt.name+"/"+scheme.String(), func(t *testing.T) {
+				f := testFile(t, tt.file, tt.levels)
+				f.Supercompression = scheme
+
+				data := encode(t, f)
+				got, err := Decode(bytes.NewReader(data))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, f) {
+					t.Errorf("decoded file differs from the encoded one")
+				}
+
+				if scheme != Supercompression_None {
+					return
+				}
+				alignment := lcm(uint64(f.Format.Info().BlockSize), 4)
+				for level := range f.Levels {
+					offset := binary.LittleEndian.Uint64(data[headerSize+level*levelIndexSize:])
+					if offset%alignment != 0 {
+						t.Errorf("level %d at offset %d, expected a multiple of %d", level, offset, alignment)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	valid := func(scheme Supercompression) []byte {
+		f := testFile(t, File{Format: wgpu.TextureFormat_RGBA8Unorm, Width: 8, Height: 8, Faces: 1}, 2)
+		f.Supercompression = scheme
+		return encode(t, f)
+	}
+	le := binary.LittleEndian
+
+	tests := []struct {
+		name   string
+		scheme Supercompression
+		modify func(b []byte) []byte
+	}{
+		{"empty", Supercompression_None, func(b []byte) []byte { return nil }},
+		{"identifier", Supercompression_None, func(b []byte) []byte { b[1] = 'X'; return b }},
+		{"truncated header", Supercompression_None, func(b []byte) []byte { return b[:headerSize-1] }},
+		{"truncated level index", Supercompression_None, func(b []byte) []byte { return b[:headerSize+levelIndexSize] }},
+		{"truncated levels", Supercompression_None, func(b []byte) []byte { return b[:len(b)-1] }},
+		{"truncated zstd levels", Supercompression_Zstd, func(b []byte) []byte { return b[:len(b)-1] }},
+		{"width", Supercompression_None, func(b []byte) []byte { le.PutUint32(b[20:], maxDimension+1); return b }},
+		{"height", Supercompression_None, func(b []byte) []byte { le.PutUint32(b[24:], 1<<31); return b }},
+		{"layers", Supercompression_None, func(b []byte) []byte { le.PutUint32(b[32:], 1<<31); return b }},
+		{"faces", Supercompression_None, func(b []byte) []byte { le.PutUint32(b[36:], 2); return b }},
+		{"3d array", Supercompression_None, func(b []byte) []byte {
+			le.PutUint32(b[28:], 1)
+			le.PutUint32(b[32:], 2)
+			return b
+		}},
+		{"levels", Supercompression_None, func(b []byte) []byte { le.PutUint32(b[40:], 1<<20); return b }},
+		{"level size", Supercompression_None, func(b []byte) []byte {
+			le.PutUint32(b[20:], maxDimension)
+			le.PutUint32(b[24:], maxDimension)
+			return b
+		}},
+		{"uncompressed level size", Supercompression_Zlib, func(b []byte) []byte {
+			le.PutUint32(b[20:], maxDimension)
+			le.PutUint32(b[24:], maxDimension)
+			le.PutUint64(b[headerSize+16:], maxDimension*maxDimension*4)
+			return b
+		}},
+		{"level offset", Supercompression_None, func(b []byte) []byte { le.PutUint64(b[headerSize:], 1<<62); return b }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(bytes.NewReader(tt.modify(valid(tt.scheme)))); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
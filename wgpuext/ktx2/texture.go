@@ -0,0 +1,122 @@
+package ktx2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rajveermalviya/go-webgpu/wgpu"
+)
+
+type TextureDescriptor struct {
+	Label string
+	// Usage is TextureUsage_TextureBinding by default, TextureUsage_CopyDst
+	// is always added.
+	Usage wgpu.TextureUsage
+}
+
+// CreateTexture creates a texture of the format, dimension, size and mip
+// level count of p, and writes every level of p to it.
+func (p *File) CreateTexture(device *wgpu.Device, descriptor *TextureDescriptor) (*wgpu.Texture, error) {
+	var d TextureDescriptor
+	if descriptor != nil {
+		d = *descriptor
+	}
+	if d.Usage == 0 {
+		d.Usage = wgpu.TextureUsage_TextureBinding
+	}
+
+	if feature := p.Format.Info().RequiredFeature; feature != wgpu.FeatureName_Undefined && !device.HasFeature(feature) {
+		return nil, fmt.Errorf("ktx2: format %s requires feature %s", p.Format, feature)
+	}
+
+	desc := p.Descriptor()
+	desc.Label = d.Label
+	desc.Usage = d.Usage | wgpu.TextureUsage_CopyDst
+	texture, err := device.CreateTexture(&desc)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := device.GetQueue()
+	defer queue.Release()
+	info := p.Format.Info()
+	for level, data := range p.Levels {
+		layout, err := p.levelLayout(uint32(level))
+		if err == nil && uint64(len(data)) != layout.Size {
+			err = fmt.Errorf("ktx2: level %d has %d bytes, expected %d", level, len(data), layout.Size)
+		}
+		if err == nil {
+			err = queue.WriteTexture(&wgpu.ImageCopyTexture{
+				Texture:  texture,
+				MipLevel: uint32(level),
+				Aspect:   wgpu.TextureAspect_All,
+			}, data, &layout.Layout, &wgpu.Extent3D{
+				Width:              uint32(layout.RowSize/uint64(info.BlockSize)) * info.BlockWidth,
+				Height:             layout.Rows * info.BlockHeight,
+				DepthOrArrayLayers: layout.Images,
+			})
+		}
+		if err != nil {
+			texture.Release()
+			return nil, err
+		}
+	}
+	return texture, nil
+}
+
+// FromTexture reads back every mip level of texture, which must have
+// TextureUsage_CopySrc, and returns them as a File. If cube is set, the
+// layers of the 2D texture are the faces of cube maps.
+func FromTexture(ctx context.Context, device *wgpu.Device, texture *wgpu.Texture, cube bool) (*File, error) {
+	f := &File{
+		Format:    texture.GetFormat(),
+		Width:     texture.GetWidth(),
+		Height:    texture.GetHeight(),
+		Faces:     1,
+		Levels:    make([][]byte, texture.GetMipLevelCount()),
+		KeyValues: map[string][]byte{},
+	}
+	if _, ok := VkFormat(f.Format); !ok {
+		return nil, fmt.Errorf("ktx2: unsupported format %s", f.Format)
+	}
+
+	layers := texture.GetDepthOrArrayLayers()
+	switch texture.GetDimension() {
+	case wgpu.TextureDimension_1D:
+		f.Height = 0
+	case wgpu.TextureDimension_3D:
+		f.Depth = layers
+	default:
+		if cube {
+			if layers%6 != 0 {
+				return nil, fmt.Errorf("ktx2: cube texture has %d layers", layers)
+			}
+			f.Faces = 6
+			layers /= 6
+		}
+		if layers > 1 {
+			f.Layers = layers
+		}
+	}
+
+	info := f.Format.Info()
+	for level := range f.Levels {
+		layout, err := f.levelLayout(uint32(level))
+		if err != nil {
+			return nil, err
+		}
+		f.Levels[level], err = device.ReadTexture(ctx, wgpu.ImageCopyTexture{
+			Texture:  texture,
+			MipLevel: uint32(level),
+			Aspect:   wgpu.TextureAspect_All,
+		}, wgpu.Extent3D{
+			Width:              uint32(layout.RowSize/uint64(info.BlockSize)) * info.BlockWidth,
+			Height:             layout.Rows * info.BlockHeight,
+			DepthOrArrayLayers: layout.Images,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}